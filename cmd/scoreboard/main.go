@@ -2,20 +2,39 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"scoreboard-api/internal"
+	"scoreboard-api/internal/achievement"
+	"scoreboard-api/internal/badge"
+	"scoreboard-api/internal/bracket"
 	"scoreboard-api/internal/config"
+	"scoreboard-api/internal/contest"
 	"scoreboard-api/internal/database"
+	"scoreboard-api/internal/eventlog"
+	"scoreboard-api/internal/graph"
+	"scoreboard-api/internal/league"
+	"scoreboard-api/internal/memory"
+	"scoreboard-api/internal/metrics"
+	"scoreboard-api/internal/rpc"
 	"scoreboard-api/internal/scoreboard"
+	"scoreboard-api/internal/sqlite"
+	"scoreboard-api/internal/stage"
+	"scoreboard-api/internal/stream"
+	"scoreboard-api/internal/user"
+	"scoreboard-api/internal/webhook"
+	"scoreboard-api/internal/widget"
 
 	_ "github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -25,27 +44,111 @@ func main() {
 	}
 
 	cfg := config.Load()
+	flag.StringVar(&cfg.Storage, "storage", cfg.Storage, "storage backend: postgres, sqlite, or memory to run without a database")
+	prune := flag.Bool("prune", false, "with rebuild, delete scoreboards missing from the event log and everything attached to them")
+	flag.Parse()
+
 	logger, err := zap.NewDevelopment()
 	validator := internal.NewValidator()
-	if err != nil {
-		panic(err)
-	}
-	err = database.MigrationUp(cfg.MigrationSource, cfg.DatabaseURL, logger)
+	internal.RegisterCustomValidations(validator)
 	if err != nil {
 		panic(err)
 	}
 
-	db, err := pgxpool.New(context.Background(), cfg.DatabaseURL)
-	if err != nil {
-		panic(err)
+	instruments := metrics.New()
+
+	// The SQLite and in-memory backends only serve scoreboards, entries and
+	// their settings; every other feature needs Postgres.
+	var db *pgxpool.Pool
+	var service *scoreboard.Service
+	var transactor scoreboard.Transactor
+	var users *user.Service
+	switch cfg.Storage {
+	case "memory":
+		logger.Warn("Using in-memory storage, nothing is kept after shutdown")
+		memoryDB := memory.New()
+		store := memoryDB.Scoreboards()
+		service = scoreboard.NewServiceWithQuerier(logger, store, store)
+		transactor = store
+		users = user.NewServiceWithQuerier(logger, memoryDB.Users())
+	case "sqlite":
+		err = instruments.Migrate("sqlite", func() error {
+			return database.MigrationUp(cfg.SQLiteMigrationSource, "sqlite3://"+cfg.SQLitePath, logger)
+		})
+		if err != nil {
+			panic(err)
+		}
+
+		file, err := sqlite.Open(cfg.SQLitePath)
+		if err != nil {
+			panic(err)
+		}
+		defer file.Close()
+		store := file.Scoreboards()
+		service = scoreboard.NewServiceWithQuerier(logger, store, store)
+		transactor = store
+		users = user.NewServiceWithQuerier(logger, file.Users())
+	case "postgres":
+		err = instruments.Migrate("postgres", func() error {
+			return database.MigrationUp(cfg.MigrationSource, cfg.DatabaseURL, logger)
+		})
+		if err != nil {
+			panic(err)
+		}
+
+		db, err = pgxpool.New(context.Background(), cfg.DatabaseURL)
+		if err != nil {
+			panic(err)
+		}
+		defer db.Close()
+		instruments.ObservePool(db)
+		service = scoreboard.NewService(logger, db)
+		transactor = scoreboard.NewPostgresTransactor(logger, db)
+		users = user.NewService(logger, db)
+	default:
+		logger.Fatal("Unknown storage backend", zap.String("storage", cfg.Storage))
 	}
-	defer db.Close()
 
-	service := scoreboard.NewService(logger, db)
+	// "scoreboard rebuild" replaces the scoreboard and entry tables with a
+	// replay of the event log, then exits. "scoreboard -prune rebuild" also
+	// deletes the scoreboards the log doesn't know.
+	if flag.Arg(0) == "rebuild" {
+		projection, err := eventlog.Rebuild(context.Background(), transactor, *prune)
+		if err != nil {
+			logger.Fatal("Failed to rebuild from the event log", zap.Error(err))
+		}
+		logger.Info("Rebuilt scoreboards from the event log",
+			zap.Int("events", projection.Applied),
+			zap.Int("scoreboards", len(projection.Scoreboards)),
+			zap.Int("entries", len(projection.SortedEntries())-projection.Skipped),
+			zap.Int("skipped_entries", projection.Skipped))
+		return
+	}
+	service.Instrument(instruments)
 	handler := scoreboard.NewHandler(validator, logger, service)
+	broker := stream.NewBroker(logger, service)
+	// On Postgres the changes of this instance reach the broker through the
+	// outbox, and those of other instances through the listener.
+	var outbox *scoreboard.OutboxDispatcher
+	if db != nil {
+		outbox = scoreboard.NewOutboxDispatcher(logger, db, service)
+		outbox.Register(broker)
+		outbox.Register(scoreboard.NewLogSink(logger))
+		service.Observe(scoreboard.RemoteOnly(broker))
+		service.Observe(outbox)
+	} else {
+		service.Observe(broker)
+	}
+	streamHandler := stream.NewHandler(validator, logger, service, broker)
+	graphHandler := graph.NewHandler(validator, logger, service, users, broker)
+	widgetHandler := widget.NewHandler(logger, service, users)
+	badgeHandler := badge.NewHandler(logger, service, users)
 
 	mux := http.NewServeMux()
 
+	// Prometheus metrics of this instance
+	mux.Handle("GET /metrics", instruments.Handler())
+
 	// Handles GET /api/scoreboards and POST /api/scoreboards
 	mux.HandleFunc("/api/scoreboards", func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
@@ -75,9 +178,119 @@ func main() {
 		}
 	})
 
+	// Player entries and the ranked leaderboard of a scoreboard
+	mux.HandleFunc("GET /api/scoreboards/{id}/settings", handler.GetSettingsHandler)
+	mux.HandleFunc("PUT /api/scoreboards/{id}/settings", handler.UpdateSettingsHandler)
+	mux.HandleFunc("GET /api/scoreboards/{id}/entries", handler.LeaderboardHandler)
+	mux.HandleFunc("POST /api/scoreboards/{id}/entries", handler.CreateEntryHandler)
+	mux.HandleFunc("GET /api/scoreboards/{id}/entries/{userID}", handler.GetEntryHandler)
+	mux.HandleFunc("PUT /api/scoreboards/{id}/entries/{userID}", handler.UpdateEntryHandler)
+	mux.HandleFunc("DELETE /api/scoreboards/{id}/entries/{userID}", handler.DeleteEntryHandler)
+	mux.HandleFunc("GET /api/scoreboards/{id}/entries/{userID}/rank", handler.RankHandler)
+	mux.HandleFunc("POST /api/scoreboards/{id}/scores", handler.SubmitScoreHandler)
+	mux.HandleFunc("GET /api/scoreboards/{id}/events", handler.EventsHandler)
+	mux.HandleFunc("GET /api/scoreboards/{id}/stream", streamHandler.StreamHandler)
+	mux.HandleFunc("GET /api/socket", streamHandler.SocketHandler)
+	mux.HandleFunc("GET /api/scoreboards/{id}/handicaps", handler.ListHandicapsHandler)
+	mux.HandleFunc("PUT /api/scoreboards/{id}/handicaps/{userID}", handler.SetHandicapHandler)
+	mux.HandleFunc("DELETE /api/scoreboards/{id}/handicaps/{userID}", handler.DeleteHandicapHandler)
+
+	// GraphQL queries, mutations and subscriptions over scoreboards and users
+	mux.HandleFunc("POST /api/graphql", graphHandler.QueryHandler)
+
+	// Leaderboards for other sites to embed in an iframe
+	mux.HandleFunc("GET /embed/scoreboards/{id}", widgetHandler.LeaderboardHandler)
+	mux.HandleFunc("GET /embed/widget.js", widgetHandler.ScriptHandler)
+
+	// SVG images for READMEs and forum signatures
+	mux.HandleFunc("GET /api/scoreboards/{id}/entries/{userID}/badge.svg", badgeHandler.RankHandler)
+	mux.HandleFunc("GET /api/scoreboards/{id}/card.svg", badgeHandler.CardHandler)
+
+	// Background workers run until the server shuts down.
+	workers, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	if db != nil {
+		leagueService := league.NewService(logger, db)
+		leagueHandler := league.NewHandler(validator, logger, leagueService)
+		bracketService := bracket.NewService(logger, db)
+		bracketHandler := bracket.NewHandler(validator, logger, bracketService)
+		contestService := contest.NewService(logger, db)
+		contestHandler := contest.NewHandler(validator, logger, contestService, cfg.AdminToken)
+		achievementService := achievement.NewService(logger, db, service)
+		achievementHandler := achievement.NewHandler(validator, logger, achievementService)
+		service.Observe(achievementService)
+		stageService := stage.NewService(logger, db)
+		stageHandler := stage.NewHandler(validator, logger, stageService)
+		webhookService := webhook.NewService(logger, db, service)
+		webhookHandler := webhook.NewHandler(validator, logger, webhookService, cfg.AdminToken)
+		outbox.Register(webhookService)
+		go webhookService.Run(workers)
+
+		// League configuration, fixtures and the standings derived from them
+		mux.HandleFunc("GET /api/scoreboards/{id}/league", leagueHandler.GetRulesHandler)
+		mux.HandleFunc("PUT /api/scoreboards/{id}/league", leagueHandler.UpdateRulesHandler)
+		mux.HandleFunc("GET /api/scoreboards/{id}/fixtures", leagueHandler.ListFixturesHandler)
+		mux.HandleFunc("POST /api/scoreboards/{id}/fixtures", leagueHandler.CreateFixtureHandler)
+		mux.HandleFunc("PUT /api/scoreboards/{id}/fixtures/{fixtureID}", leagueHandler.RecordResultHandler)
+		mux.HandleFunc("DELETE /api/scoreboards/{id}/fixtures/{fixtureID}", leagueHandler.DeleteFixtureHandler)
+		mux.HandleFunc("GET /api/scoreboards/{id}/standings", leagueHandler.StandingsHandler)
+
+		// Single and double elimination brackets hosted by a scoreboard
+		mux.HandleFunc("GET /api/scoreboards/{id}/bracket", bracketHandler.GetHandler)
+		mux.HandleFunc("POST /api/scoreboards/{id}/bracket", bracketHandler.CreateHandler)
+		mux.HandleFunc("DELETE /api/scoreboards/{id}/bracket", bracketHandler.DeleteHandler)
+		mux.HandleFunc("POST /api/scoreboards/{id}/bracket/matches/{number}/result", bracketHandler.ReportResultHandler)
+		mux.HandleFunc("GET /api/scoreboards/{id}/bracket/standings", bracketHandler.PlacementsHandler)
+
+		// ICPC and CTF style contests with problems, teams and judged attempts
+		mux.HandleFunc("GET /api/scoreboards/{id}/contest", contestHandler.GetHandler)
+		mux.HandleFunc("PUT /api/scoreboards/{id}/contest", contestHandler.ConfigureHandler)
+		mux.HandleFunc("GET /api/scoreboards/{id}/contest/problems", contestHandler.ListProblemsHandler)
+		mux.HandleFunc("POST /api/scoreboards/{id}/contest/problems", contestHandler.CreateProblemHandler)
+		mux.HandleFunc("DELETE /api/scoreboards/{id}/contest/problems/{problemID}", contestHandler.DeleteProblemHandler)
+		mux.HandleFunc("GET /api/scoreboards/{id}/contest/teams", contestHandler.ListTeamsHandler)
+		mux.HandleFunc("POST /api/scoreboards/{id}/contest/teams", contestHandler.CreateTeamHandler)
+		mux.HandleFunc("DELETE /api/scoreboards/{id}/contest/teams/{teamID}", contestHandler.DeleteTeamHandler)
+		mux.HandleFunc("POST /api/scoreboards/{id}/contest/attempts", contestHandler.RecordAttemptHandler)
+		mux.HandleFunc("GET /api/scoreboards/{id}/contest/standings", contestHandler.StandingsHandler)
+		mux.HandleFunc("POST /api/scoreboards/{id}/contest/unfreeze", contestHandler.UnfreezeHandler)
+
+		// Achievement rules and the badges players earned with them
+		mux.HandleFunc("GET /api/scoreboards/{id}/achievements", achievementHandler.ListHandler)
+		mux.HandleFunc("POST /api/scoreboards/{id}/achievements", achievementHandler.CreateHandler)
+		mux.HandleFunc("DELETE /api/scoreboards/{id}/achievements/{achievementID}", achievementHandler.DeleteHandler)
+		mux.HandleFunc("GET /api/users/{userID}/badges", achievementHandler.BadgesHandler)
+
+		// Ordered stages of a season and the overall standings across them
+		mux.HandleFunc("GET /api/scoreboards/{id}/stages", stageHandler.ListStagesHandler)
+		mux.HandleFunc("POST /api/scoreboards/{id}/stages", stageHandler.CreateStageHandler)
+		mux.HandleFunc("GET /api/scoreboards/{id}/stages/aggregation", stageHandler.GetSettingsHandler)
+		mux.HandleFunc("PUT /api/scoreboards/{id}/stages/aggregation", stageHandler.UpdateSettingsHandler)
+		mux.HandleFunc("GET /api/scoreboards/{id}/stages/standings", stageHandler.StandingsHandler)
+		mux.HandleFunc("DELETE /api/scoreboards/{id}/stages/{stageID}", stageHandler.DeleteStageHandler)
+		mux.HandleFunc("PUT /api/scoreboards/{id}/stages/{stageID}/entries/{userID}", stageHandler.RecordEntryHandler)
+		mux.HandleFunc("DELETE /api/scoreboards/{id}/stages/{stageID}/entries/{userID}", stageHandler.DeleteEntryHandler)
+		mux.HandleFunc("GET /api/scoreboards/{id}/stages/{stageID}/standings", stageHandler.StageStandingsHandler)
+
+		// Signed webhook deliveries of board changes and their delivery log
+		mux.HandleFunc("GET /api/scoreboards/{id}/webhooks", webhookHandler.ListHandler)
+		mux.HandleFunc("POST /api/scoreboards/{id}/webhooks", webhookHandler.CreateHandler)
+		mux.HandleFunc("DELETE /api/scoreboards/{id}/webhooks/{webhookID}", webhookHandler.DeleteHandler)
+		mux.HandleFunc("GET /api/scoreboards/{id}/webhooks/{webhookID}/deliveries", webhookHandler.DeliveriesHandler)
+		mux.HandleFunc("POST /api/scoreboards/{id}/webhooks/{webhookID}/deliveries/{deliveryID}/redeliver", webhookHandler.RedeliverHandler)
+	}
+
+	// Replicas sharing the database see each other's writes through
+	// Postgres notifications.
+	if db != nil {
+		go scoreboard.NewListener(logger, db, service).Listen(workers)
+		go outbox.Run(workers)
+	}
+
 	server := &http.Server{
 		Addr:    "127.0.0.1:8080",
-		Handler: mux,
+		Handler: instruments.Middleware(mux),
 	}
 
 	go func() {
@@ -87,6 +300,22 @@ func main() {
 		}
 	}()
 
+	// The gRPC API serves scoreboards, entries and leaderboard streams from
+	// the same service as the REST API, on every storage backend.
+	grpcServer := grpc.NewServer()
+	rpc.RegisterScoreboardServiceServer(grpcServer, rpc.NewServer(validator, logger, service, broker))
+	go func() {
+		listener, err := net.Listen("tcp", cfg.GRPCAddress)
+		if err != nil {
+			logger.Error("Failed to start gRPC server", zap.Error(err))
+			return
+		}
+		logger.Info("Starting gRPC server", zap.String("address", cfg.GRPCAddress))
+		if err := grpcServer.Serve(listener); err != nil {
+			logger.Error("Failed to start gRPC server", zap.Error(err))
+		}
+	}()
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt)
 	<-quit
@@ -94,5 +323,8 @@ func main() {
 	if err := server.Shutdown(context.Background()); err != nil {
 		logger.Error("Failed to shutdown server", zap.Error(err))
 	}
+	// Watch streams only end with their client, so they are cut off rather
+	// than drained.
+	grpcServer.Stop()
 	logger.Info("Server shutdown successfully")
 }
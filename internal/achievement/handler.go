@@ -0,0 +1,200 @@
+package achievement
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"scoreboard-api/internal/scoreboard"
+)
+
+type Store interface {
+	List(ctx context.Context, scoreboardID uuid.UUID) ([]Achievement, error)
+	Create(ctx context.Context, arg CreateAchievementParams) (Achievement, error)
+	Delete(ctx context.Context, scoreboardID, achievementID uuid.UUID) error
+	Badges(ctx context.Context, userID uuid.UUID) ([]ListBadgesByUserRow, error)
+}
+
+// AchievementPayload defines the expected request body for defining an achievement.
+type AchievementPayload struct {
+	Name        string `json:"name" validate:"required,max=255"`
+	Description string `json:"description" validate:"max=1000"`
+	Rule        string `json:"rule" validate:"required,oneof=reach_score top_rank hold_first improvements"`
+	Threshold   *int64 `json:"threshold" validate:"omitempty,min=1"`
+}
+
+type Response struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Rule        string `json:"rule"`
+	Threshold   int64  `json:"threshold"`
+	CreatedAt   string `json:"createdAt"`
+}
+
+type BadgeResponse struct {
+	AchievementID string `json:"achievementId"`
+	ScoreboardID  string `json:"scoreboardId"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Rule          string `json:"rule"`
+	Threshold     int64  `json:"threshold"`
+	AwardedAt     string `json:"awardedAt"`
+}
+
+type Handler struct {
+	validator *validator.Validate
+	tracer    trace.Tracer
+	logger    *zap.Logger
+	store     Store
+}
+
+func NewHandler(v *validator.Validate, logger *zap.Logger, s Store) Handler {
+	return Handler{
+		validator: v,
+		tracer:    otel.Tracer("achievement/handler"),
+		logger:    logger,
+		store:     s,
+	}
+}
+
+func (h Handler) ListHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	achievements, err := h.store.List(ctx, scoreboardID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	response := make([]Response, len(achievements))
+	for index, achievement := range achievements {
+		response[index] = GenerateResponse(achievement)
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+func (h Handler) CreateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+
+	var payload AchievementPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validator.Struct(payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	threshold, ok := DefaultThreshold(Rule(payload.Rule))
+	if payload.Threshold != nil {
+		threshold, ok = *payload.Threshold, true
+	}
+	if !ok {
+		http.Error(w, "threshold is required for this rule", http.StatusBadRequest)
+		return
+	}
+
+	achievement, err := h.store.Create(ctx, CreateAchievementParams{
+		ScoreboardID: scoreboardID,
+		Name:         payload.Name,
+		Description:  payload.Description,
+		Rule:         payload.Rule,
+		Threshold:    threshold,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusCreated, GenerateResponse(achievement))
+}
+
+func (h Handler) DeleteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	achievementID, err := uuid.Parse(r.PathValue("achievementID"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	if err := h.store.Delete(ctx, scoreboardID, achievementID); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h Handler) BadgesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	badges, err := h.store.Badges(ctx, userID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	response := make([]BadgeResponse, len(badges))
+	for index, badge := range badges {
+		response[index] = GenerateBadgeResponse(badge)
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+func (h Handler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrScoreboardNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrDuplicate):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		h.logger.Error("Achievement request failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func GenerateResponse(achievement Achievement) Response {
+	return Response{
+		ID:          achievement.ID.String(),
+		Name:        achievement.Name,
+		Description: achievement.Description,
+		Rule:        achievement.Rule,
+		Threshold:   achievement.Threshold,
+		CreatedAt:   achievement.CreatedAt.Time.Format(time.RFC3339),
+	}
+}
+
+func GenerateBadgeResponse(badge ListBadgesByUserRow) BadgeResponse {
+	return BadgeResponse{
+		AchievementID: badge.ID.String(),
+		ScoreboardID:  badge.ScoreboardID.String(),
+		Name:          badge.Name,
+		Description:   badge.Description,
+		Rule:          badge.Rule,
+		Threshold:     badge.Threshold,
+		AwardedAt:     badge.AwardedAt.Time.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,68 @@
+-- name: ListAchievements :many
+SELECT * FROM achievements
+WHERE scoreboard_id = $1
+ORDER BY created_at, name;
+
+-- name: CreateAchievement :one
+INSERT INTO achievements (
+    id, scoreboard_id, name, description, rule, threshold, created_at
+) VALUES (
+    gen_random_uuid(),
+    $1, $2, $3, $4, $5,
+    CURRENT_TIMESTAMP
+)
+RETURNING *;
+
+-- name: DeleteAchievement :exec
+DELETE FROM achievements WHERE id = $1 AND scoreboard_id = $2;
+
+-- name: AddImprovements :one
+INSERT INTO achievement_progress (
+    scoreboard_id, user_id, improvements, updated_at
+) VALUES (
+    $1, $2, $3,
+    CURRENT_TIMESTAMP
+)
+ON CONFLICT (scoreboard_id, user_id) DO UPDATE SET
+    improvements = achievement_progress.improvements + EXCLUDED.improvements,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: MarkLeader :one
+INSERT INTO achievement_progress (
+    scoreboard_id, user_id, leading_since, updated_at
+) VALUES (
+    $1, $2, $3,
+    CURRENT_TIMESTAMP
+)
+ON CONFLICT (scoreboard_id, user_id) DO UPDATE SET
+    leading_since = COALESCE(achievement_progress.leading_since, EXCLUDED.leading_since),
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ClearLeaders :exec
+UPDATE achievement_progress SET
+    leading_since = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE scoreboard_id = $1 AND user_id <> $2 AND leading_since IS NOT NULL;
+
+-- name: ListLeadingByUser :many
+SELECT * FROM achievement_progress
+WHERE user_id = $1 AND leading_since IS NOT NULL;
+
+-- name: AwardBadge :execrows
+INSERT INTO badges (
+    achievement_id, user_id, awarded_at
+) VALUES (
+    $1, $2,
+    CURRENT_TIMESTAMP
+)
+ON CONFLICT (achievement_id, user_id) DO NOTHING;
+
+-- name: ListBadgesByUser :many
+SELECT achievements.id, achievements.scoreboard_id, achievements.name, achievements.description,
+    achievements.rule, achievements.threshold, badges.awarded_at
+FROM badges
+JOIN achievements ON achievements.id = badges.achievement_id
+WHERE badges.user_id = $1
+ORDER BY badges.awarded_at, achievements.name;
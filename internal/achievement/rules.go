@@ -0,0 +1,63 @@
+package achievement
+
+import (
+	"time"
+)
+
+type Rule string
+
+const (
+	// RuleReachScore is earned once the player's score reaches the threshold.
+	RuleReachScore Rule = "reach_score"
+	// RuleTopRank is earned by entering the top threshold places.
+	RuleTopRank Rule = "top_rank"
+	// RuleHoldFirst is earned by holding first place alone for threshold hours.
+	RuleHoldFirst Rule = "hold_first"
+	// RuleImprovements is earned by raising one's own score threshold times.
+	RuleImprovements Rule = "improvements"
+)
+
+// DefaultThreshold returns the threshold used when a rule is defined without
+// one. Score thresholds have no sensible default.
+func DefaultThreshold(rule Rule) (int64, bool) {
+	switch rule {
+	case RuleTopRank:
+		return 10, true
+	case RuleHoldFirst:
+		return 24, true
+	case RuleImprovements:
+		return 5, true
+	default:
+		return 0, false
+	}
+}
+
+// Progress is what the rules know about a player on one scoreboard.
+type Progress struct {
+	Score int64
+	// Rank is zero when the player has no entry on the board.
+	Rank         int64
+	Improvements int
+	// LeadingSince is zero unless the player is alone in first place.
+	LeadingSince time.Time
+}
+
+// Earned reports whether the progress satisfies the achievement at the
+// given time.
+func (a Achievement) Earned(progress Progress, now time.Time) bool {
+	switch Rule(a.Rule) {
+	case RuleReachScore:
+		return progress.Rank > 0 && progress.Score >= a.Threshold
+	case RuleTopRank:
+		return progress.Rank > 0 && progress.Rank <= a.Threshold
+	case RuleHoldFirst:
+		if progress.LeadingSince.IsZero() {
+			return false
+		}
+		return now.Sub(progress.LeadingSince) >= time.Duration(a.Threshold)*time.Hour
+	case RuleImprovements:
+		return int64(progress.Improvements) >= a.Threshold
+	default:
+		return false
+	}
+}
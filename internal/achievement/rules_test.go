@@ -0,0 +1,38 @@
+package achievement
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEarned(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		rule     Rule
+		progress Progress
+		want     bool
+	}{
+		{name: "Score reached", rule: RuleReachScore, progress: Progress{Score: 1000, Rank: 4}, want: true},
+		{name: "Score not reached", rule: RuleReachScore, progress: Progress{Score: 999, Rank: 4}, want: false},
+		{name: "Score without an entry", rule: RuleReachScore, progress: Progress{Score: 1000}, want: false},
+		{name: "Inside the top places", rule: RuleTopRank, progress: Progress{Rank: 10}, want: true},
+		{name: "Outside the top places", rule: RuleTopRank, progress: Progress{Rank: 11}, want: false},
+		{name: "Led long enough", rule: RuleHoldFirst, progress: Progress{Rank: 1, LeadingSince: now.Add(-24 * time.Hour)}, want: true},
+		{name: "Led too briefly", rule: RuleHoldFirst, progress: Progress{Rank: 1, LeadingSince: now.Add(-23 * time.Hour)}, want: false},
+		{name: "Not leading", rule: RuleHoldFirst, progress: Progress{Rank: 1}, want: false},
+		{name: "Improved often enough", rule: RuleImprovements, progress: Progress{Improvements: 5}, want: true},
+		{name: "Improved too rarely", rule: RuleImprovements, progress: Progress{Improvements: 4}, want: false},
+	}
+
+	thresholds := map[Rule]int64{RuleReachScore: 1000, RuleTopRank: 10, RuleHoldFirst: 24, RuleImprovements: 5}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			achievement := Achievement{Rule: string(tt.rule), Threshold: thresholds[tt.rule]}
+			if got := achievement.Earned(tt.progress, now); got != tt.want {
+				t.Errorf("Earned(%+v) = %v, want %v", tt.progress, got, tt.want)
+			}
+		})
+	}
+}
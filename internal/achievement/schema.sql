@@ -0,0 +1,26 @@
+CREATE TABLE achievements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scoreboard_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    rule VARCHAR(32) NOT NULL,
+    threshold BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (scoreboard_id, name)
+);
+
+CREATE TABLE achievement_progress (
+    scoreboard_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    improvements INT NOT NULL DEFAULT 0,
+    leading_since TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (scoreboard_id, user_id)
+);
+
+CREATE TABLE badges (
+    achievement_id UUID NOT NULL REFERENCES achievements (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    awarded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (achievement_id, user_id)
+);
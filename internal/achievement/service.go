@@ -0,0 +1,235 @@
+package achievement
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"scoreboard-api/internal/scoreboard"
+)
+
+var (
+	ErrScoreboardNotFound = errors.New("scoreboard not found")
+	ErrDuplicate          = errors.New("achievement name is already taken on this scoreboard")
+)
+
+// Postgres error codes raised by the achievement constraints.
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+)
+
+// Board gives access to the ranked entries of a scoreboard.
+type Board interface {
+	Top(ctx context.Context, scoreboardID uuid.UUID, limit int) ([]scoreboard.Standing, error)
+	Standing(ctx context.Context, scoreboardID, userID uuid.UUID) (scoreboard.Standing, error)
+}
+
+// Service keeps achievement definitions and awards badges as entries change.
+// It observes the scoreboard service.
+type Service struct {
+	queries *Queries
+	board   Board
+	logger  *zap.Logger
+	tracer  trace.Tracer
+}
+
+func NewService(logger *zap.Logger, db DBTX, board Board) *Service {
+	return &Service{
+		queries: New(db),
+		board:   board,
+		logger:  logger,
+		tracer:  otel.Tracer("achievement/service"),
+	}
+}
+
+func (s *Service) List(ctx context.Context, scoreboardID uuid.UUID) ([]Achievement, error) {
+	traceCtx, span := s.tracer.Start(ctx, "List")
+	defer span.End()
+	achievements, err := s.queries.ListAchievements(traceCtx, scoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	return achievements, nil
+}
+
+func (s *Service) Create(ctx context.Context, arg CreateAchievementParams) (Achievement, error) {
+	traceCtx, span := s.tracer.Start(ctx, "Create")
+	defer span.End()
+	achievement, err := s.queries.CreateAchievement(traceCtx, arg)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case foreignKeyViolation:
+				return Achievement{}, ErrScoreboardNotFound
+			case uniqueViolation:
+				return Achievement{}, ErrDuplicate
+			}
+		}
+		return Achievement{}, err
+	}
+	return achievement, nil
+}
+
+func (s *Service) Delete(ctx context.Context, scoreboardID, achievementID uuid.UUID) error {
+	traceCtx, span := s.tracer.Start(ctx, "Delete")
+	defer span.End()
+	return s.queries.DeleteAchievement(traceCtx, DeleteAchievementParams{
+		ID:           achievementID,
+		ScoreboardID: scoreboardID,
+	})
+}
+
+// Badges lists the badges a player has earned on every scoreboard. Leads
+// that have lasted long enough since the last write are awarded first.
+func (s *Service) Badges(ctx context.Context, userID uuid.UUID) ([]ListBadgesByUserRow, error) {
+	traceCtx, span := s.tracer.Start(ctx, "Badges")
+	defer span.End()
+
+	leads, err := s.queries.ListLeadingByUser(traceCtx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	for _, lead := range leads {
+		achievements, err := s.queries.ListAchievements(traceCtx, lead.ScoreboardID)
+		if err != nil {
+			return nil, err
+		}
+		progress := Progress{LeadingSince: lead.LeadingSince.Time}
+		for _, achievement := range achievements {
+			if Rule(achievement.Rule) != RuleHoldFirst || !achievement.Earned(progress, now) {
+				continue
+			}
+			if err := s.award(traceCtx, achievement, userID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	badges, err := s.queries.ListBadgesByUser(traceCtx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return badges, nil
+}
+
+// ObserveEntry updates the progress of the player whose entry changed and of
+// the board leader, then awards the badges they now qualify for.
+func (s *Service) ObserveEntry(ctx context.Context, change scoreboard.EntryChange) error {
+	// Another instance already awarded the badges of its own changes.
+	if change.Remote {
+		return nil
+	}
+	traceCtx, span := s.tracer.Start(ctx, "ObserveEntry")
+	defer span.End()
+
+	achievements, err := s.queries.ListAchievements(traceCtx, change.ScoreboardID)
+	if err != nil {
+		return err
+	}
+	if len(achievements) == 0 {
+		return nil
+	}
+
+	var player AchievementProgress
+	if change.After != nil {
+		var improvements int32
+		if change.Before != nil && change.After.Score > change.Before.Score {
+			improvements = 1
+		}
+		player, err = s.queries.AddImprovements(traceCtx, AddImprovementsParams{
+			ScoreboardID: change.ScoreboardID,
+			UserID:       change.UserID,
+			Improvements: improvements,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	rows, err := s.board.Top(traceCtx, change.ScoreboardID, 2)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+
+	// Only a player alone in first place is leading.
+	leaderID := uuid.Nil
+	if len(rows) == 1 || (len(rows) > 1 && rows[1].Rank > 1) {
+		leaderID = rows[0].UserID
+	}
+	if err := s.queries.ClearLeaders(traceCtx, ClearLeadersParams{ScoreboardID: change.ScoreboardID, UserID: leaderID}); err != nil {
+		return err
+	}
+	var leader AchievementProgress
+	if leaderID != uuid.Nil {
+		leader, err = s.queries.MarkLeader(traceCtx, MarkLeaderParams{
+			ScoreboardID: change.ScoreboardID,
+			UserID:       leaderID,
+			LeadingSince: pgtype.Timestamp{Time: now, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		if leaderID == change.UserID {
+			player = leader
+		}
+	}
+
+	candidates := map[uuid.UUID]Progress{}
+	if leaderID != uuid.Nil {
+		candidates[leaderID] = progressOf(rows[0], leader)
+	}
+	if change.After != nil && change.UserID != leaderID {
+		row, err := s.board.Standing(traceCtx, change.ScoreboardID, change.UserID)
+		switch {
+		case err == nil:
+			candidates[change.UserID] = progressOf(row, player)
+		case !errors.Is(err, scoreboard.ErrEntryNotFound):
+			return err
+		}
+	}
+	for userID, progress := range candidates {
+		for _, achievement := range achievements {
+			if !achievement.Earned(progress, now) {
+				continue
+			}
+			if err := s.award(traceCtx, achievement, userID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Service) award(ctx context.Context, achievement Achievement, userID uuid.UUID) error {
+	awarded, err := s.queries.AwardBadge(ctx, AwardBadgeParams{AchievementID: achievement.ID, UserID: userID})
+	if err != nil {
+		return err
+	}
+	if awarded > 0 {
+		s.logger.Info("Badge awarded",
+			zap.String("achievement", achievement.Name),
+			zap.String("scoreboardID", achievement.ScoreboardID.String()),
+			zap.String("userID", userID.String()))
+	}
+	return nil
+}
+
+func progressOf(row scoreboard.Standing, progress AchievementProgress) Progress {
+	return Progress{
+		Score:        row.Score,
+		Rank:         row.Rank,
+		Improvements: int(progress.Improvements),
+		LeadingSince: progress.LeadingSince.Time,
+	}
+}
@@ -0,0 +1,20 @@
+package internal
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// IsPrivileged reports whether the request carries the admin token as a
+// bearer token. An empty admin token never grants access.
+func IsPrivileged(r *http.Request, adminToken string) bool {
+	if adminToken == "" {
+		return false
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1
+}
@@ -0,0 +1,392 @@
+// Package badge renders scoreboards as SVG images that players put in
+// READMEs and forum signatures: a shields.io style badge with a player's
+// rank, and a card with the top of a scoreboard.
+package badge
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"scoreboard-api/internal/scoreboard"
+	"scoreboard-api/internal/user"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	// cardSize is the number of players on a card.
+	cardSize = 5
+	// maxAge is how long browsers and image proxies may cache an image.
+	maxAge = 300
+	// Longer labels and player names are cut off.
+	maxLabel  = 40
+	maxPlayer = 24
+)
+
+// Badge colors, as used by shields.io.
+const (
+	gold        = "#dfb317"
+	brightgreen = "#4c1"
+	green       = "#97ca00"
+	blue        = "#007ec6"
+	lightgrey   = "#9f9f9f"
+)
+
+// Store is the part of the scoreboard service the badges use.
+type Store interface {
+	Get(ctx context.Context, id uuid.UUID) (scoreboard.Scoreboard, error)
+	Top(ctx context.Context, scoreboardID uuid.UUID, limit int) ([]scoreboard.Standing, error)
+	Standing(ctx context.Context, scoreboardID, userID uuid.UUID) (scoreboard.Standing, error)
+	Count(ctx context.Context, scoreboardID uuid.UUID) (int, error)
+}
+
+// Users is the part of the user service the cards use to name players.
+type Users interface {
+	ListByIDs(ctx context.Context, ids []uuid.UUID) ([]user.User, error)
+}
+
+// Theme holds the colors of a card.
+type Theme struct {
+	Background string
+	Border     string
+	Color      string
+	Muted      string
+}
+
+var themes = map[string]Theme{
+	"light": {Background: "#ffffff", Border: "#d0d7de", Color: "#1f2328", Muted: "#656d76"},
+	"dark":  {Background: "#0d1117", Border: "#30363d", Color: "#e6edf3", Muted: "#8d96a0"},
+}
+
+type badgeView struct {
+	Label        string
+	Message      string
+	Color        string
+	Width        int
+	LabelWidth   int
+	MessageWidth int
+	LabelX       int
+	MessageX     int
+}
+
+var badge = template.Must(template.New("badge").Parse(`<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="20" role="img" aria-label="{{.Label}}: {{.Message}}">
+  <title>{{.Label}}: {{.Message}}</title>
+  <linearGradient id="s" x2="0" y2="100%">
+    <stop offset="0" stop-color="#bbb" stop-opacity=".1"/>
+    <stop offset="1" stop-opacity=".1"/>
+  </linearGradient>
+  <clipPath id="r"><rect width="{{.Width}}" height="20" rx="3" fill="#fff"/></clipPath>
+  <g clip-path="url(#r)">
+    <rect width="{{.LabelWidth}}" height="20" fill="#555"/>
+    <rect x="{{.LabelWidth}}" width="{{.MessageWidth}}" height="20" fill="{{.Color}}"/>
+    <rect width="{{.Width}}" height="20" fill="url(#s)"/>
+  </g>
+  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+    <text x="{{.LabelX}}" y="15" fill="#010101" fill-opacity=".3">{{.Label}}</text>
+    <text x="{{.LabelX}}" y="14">{{.Label}}</text>
+    <text x="{{.MessageX}}" y="15" fill="#010101" fill-opacity=".3">{{.Message}}</text>
+    <text x="{{.MessageX}}" y="14">{{.Message}}</text>
+  </g>
+</svg>
+`))
+
+type cardRow struct {
+	Y      int
+	Rank   string
+	Player string
+	Score  string
+	Color  string
+}
+
+type cardView struct {
+	Title  string
+	Theme  Theme
+	Height int
+	// Inner is the height of the border, which is drawn half a pixel in.
+	Inner int
+	Rows  []cardRow
+}
+
+var card = template.Must(template.New("card").Parse(`<svg xmlns="http://www.w3.org/2000/svg" width="360" height="{{.Height}}" viewBox="0 0 360 {{.Height}}" role="img" aria-label="{{.Title}}">
+  <title>{{.Title}}</title>
+  <rect x="0.5" y="0.5" width="359" height="{{.Inner}}" rx="6" fill="{{.Theme.Background}}" stroke="{{.Theme.Border}}"/>
+  <g font-family="-apple-system,Segoe UI,Helvetica,Arial,sans-serif" font-size="13" fill="{{.Theme.Color}}">
+    <text x="16" y="28" font-size="15" font-weight="bold">{{.Title}}</text>
+    {{- range .Rows}}
+    <text x="16" y="{{.Y}}" font-weight="bold" fill="{{.Color}}">{{.Rank}}</text>
+    <text x="60" y="{{.Y}}">{{.Player}}</text>
+    <text x="344" y="{{.Y}}" text-anchor="end">{{.Score}}</text>
+    {{- else}}
+    <text x="16" y="60" fill="{{.Theme.Muted}}">No scores yet</text>
+    {{- end}}
+  </g>
+</svg>
+`))
+
+type Handler struct {
+	logger *zap.Logger
+	store  Store
+	users  Users
+}
+
+func NewHandler(logger *zap.Logger, store Store, users Users) Handler {
+	return Handler{
+		logger: logger,
+		store:  store,
+		users:  users,
+	}
+}
+
+// RankHandler renders a badge with a player's rank, such as
+// "Speedrun Any% | #3 of 1,204". Players without an entry get an "unranked"
+// badge rather than an error, so that the image keeps working in a README
+// before their first score. The label query parameter replaces the
+// scoreboard name.
+func (h Handler) RankHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	userID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+
+	board, err := h.store.Get(ctx, scoreboardID)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	label := board.Name.String
+	if value := r.URL.Query().Get("label"); value != "" {
+		label = value
+	}
+
+	message, color := "unranked", lightgrey
+	standing, err := h.store.Standing(ctx, scoreboardID, userID)
+	switch {
+	case errors.Is(err, scoreboard.ErrEntryNotFound):
+	case err != nil:
+		h.writeError(w, r, err)
+		return
+	default:
+		count, err := h.store.Count(ctx, scoreboardID)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		message = "#" + thousands(standing.Rank) + " of " + thousands(int64(count))
+		color = rankColor(standing.Rank, count)
+	}
+	h.render(w, r, http.StatusOK, badge, newBadge(label, message, color))
+}
+
+// CardHandler renders a card with the top five players of a scoreboard. The
+// theme query parameter is light or dark.
+func (h Handler) CardHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	themeName := r.URL.Query().Get("theme")
+	if themeName == "" {
+		themeName = "light"
+	}
+	theme, ok := themes[themeName]
+	if !ok {
+		http.Error(w, "theme must be light or dark", http.StatusBadRequest)
+		return
+	}
+
+	board, err := h.store.Get(ctx, scoreboardID)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	standings, err := h.store.Top(ctx, scoreboardID, cardSize)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	names, err := h.names(ctx, standings)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	rows := make([]cardRow, len(standings))
+	for index, standing := range standings {
+		rows[index] = cardRow{
+			Y:      60 + index*28,
+			Rank:   "#" + thousands(standing.Rank),
+			Player: truncate(names[standing.UserID], maxPlayer),
+			Score:  thousands(standing.Score),
+			Color:  theme.Muted,
+		}
+		if standing.Rank == 1 {
+			rows[index].Color = gold
+		}
+	}
+	height := 44 + max(len(rows), 1)*28
+	h.render(w, r, http.StatusOK, card, cardView{
+		Title:  truncate(board.Name.String, maxLabel),
+		Theme:  theme,
+		Height: height,
+		Inner:  height - 1,
+		Rows:   rows,
+	})
+}
+
+// names names the players of the standings by their profiles. Players
+// without one are shown by the start of their ID.
+func (h Handler) names(ctx context.Context, standings []scoreboard.Standing) (map[uuid.UUID]string, error) {
+	ids := make([]uuid.UUID, len(standings))
+	names := make(map[uuid.UUID]string, len(standings))
+	for index, standing := range standings {
+		ids[index] = standing.UserID
+		names[standing.UserID] = standing.UserID.String()[:8]
+	}
+	profiles, err := h.users.ListByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, profile := range profiles {
+		if profile.Name.Valid && profile.Name.String != "" {
+			names[profile.ID] = profile.Name.String
+		}
+	}
+	return names, nil
+}
+
+// render writes an image with caching headers. The ETag is a hash of the
+// image, so proxies that revalidate only download it again after it changes.
+func (h Handler) render(w http.ResponseWriter, r *http.Request, status int, image *template.Template, data any) {
+	var body bytes.Buffer
+	if err := image.Execute(&body, data); err != nil {
+		h.logger.Error("Failed to render badge", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sum := sha256.Sum256(body.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(maxAge))
+	w.Header().Set("ETag", etag)
+	if status == http.StatusOK && r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "image/svg+xml; charset=utf-8")
+	// An image opened on its own must not run anything.
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+	w.WriteHeader(status)
+	_, _ = w.Write(body.Bytes())
+}
+
+// writeError renders a missing scoreboard as a badge too, so that a broken
+// link still shows up as an image.
+func (h Handler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, pgx.ErrNoRows) {
+		h.render(w, r, http.StatusNotFound, badge, newBadge("scoreboard", "not found", lightgrey))
+		return
+	}
+	h.logger.Error("Failed to read badge", zap.Error(err))
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+// newBadge lays out a badge. SVG text can't be measured without a font, so
+// the widths are estimated the way shields.io does for 11px Verdana.
+func newBadge(label, message, color string) badgeView {
+	label = truncate(label, maxLabel)
+	labelWidth := textWidth(label) + 10
+	messageWidth := textWidth(message) + 10
+	return badgeView{
+		Label:        label,
+		Message:      message,
+		Color:        color,
+		Width:        labelWidth + messageWidth,
+		LabelWidth:   labelWidth,
+		MessageWidth: messageWidth,
+		LabelX:       labelWidth / 2,
+		MessageX:     labelWidth + messageWidth/2,
+	}
+}
+
+// textWidth estimates the width in pixels of text in 11px Verdana.
+func textWidth(text string) int {
+	width := 0.0
+	for _, char := range text {
+		switch {
+		case strings.ContainsRune("il.,:;|!'` ", char):
+			width += 3.9
+		case strings.ContainsRune("fjrtI()[]-", char):
+			width += 4.9
+		case strings.ContainsRune("mwMW%@", char):
+			width += 10.5
+		case char >= 'A' && char <= 'Z', char == '#':
+			width += 7.6
+		case char > 0x2e7f:
+			// Wide scripts such as CJK.
+			width += 11
+		default:
+			width += 6.9
+		}
+	}
+	return int(width + 0.5)
+}
+
+// rankColor colors the leader gold, the top ten bright green and the top
+// tenth green.
+func rankColor(rank int64, count int) string {
+	switch {
+	case rank == 1:
+		return gold
+	case rank <= 10:
+		return brightgreen
+	case rank*10 <= int64(count):
+		return green
+	default:
+		return blue
+	}
+}
+
+// thousands formats n with comma separators, such as 1,204.
+func thousands(n int64) string {
+	digits := strconv.FormatInt(n, 10)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	var out strings.Builder
+	for index, digit := range digits {
+		if index > 0 && (len(digits)-index)%3 == 0 {
+			out.WriteByte(',')
+		}
+		out.WriteRune(digit)
+	}
+	return sign + out.String()
+}
+
+// truncate cuts text to at most limit characters, ending it with an
+// ellipsis when it was longer.
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-1]) + "…"
+}
@@ -0,0 +1,135 @@
+package badge
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"scoreboard-api/internal/scoreboard"
+	"scoreboard-api/internal/user"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// standings is a Store over one scoreboard with fixed standings.
+type standings struct {
+	board     scoreboard.Scoreboard
+	standings []scoreboard.Standing
+}
+
+func (s standings) Get(_ context.Context, id uuid.UUID) (scoreboard.Scoreboard, error) {
+	if id != s.board.ID {
+		return scoreboard.Scoreboard{}, pgx.ErrNoRows
+	}
+	return s.board, nil
+}
+
+func (s standings) Top(_ context.Context, _ uuid.UUID, limit int) ([]scoreboard.Standing, error) {
+	return s.standings[:min(limit, len(s.standings))], nil
+}
+
+func (s standings) Standing(_ context.Context, _, userID uuid.UUID) (scoreboard.Standing, error) {
+	for _, standing := range s.standings {
+		if standing.UserID == userID {
+			return standing, nil
+		}
+	}
+	return scoreboard.Standing{}, scoreboard.ErrEntryNotFound
+}
+
+func (s standings) Count(context.Context, uuid.UUID) (int, error) {
+	return len(s.standings), nil
+}
+
+// profiles is a Users that knows every player in it.
+type profiles []user.User
+
+func (p profiles) ListByIDs(context.Context, []uuid.UUID) ([]user.User, error) {
+	return p, nil
+}
+
+func TestHandler(t *testing.T) {
+	board := scoreboard.Scoreboard{ID: uuid.New(), Name: pgtype.Text{String: "Speedrun <Any%>", Valid: true}}
+	ada := user.User{ID: uuid.New(), Name: pgtype.Text{String: "Ada", Valid: true}}
+	third := uuid.New()
+	store := standings{board: board}
+	for index, userID := range []uuid.UUID{ada.ID, uuid.New(), third, uuid.New(), uuid.New(), uuid.New()} {
+		store.standings = append(store.standings, scoreboard.Standing{
+			ScoreboardEntry: scoreboard.ScoreboardEntry{ScoreboardID: board.ID, UserID: userID, Score: int64(6000 - index*1000)},
+			Rank:            int64(index + 1),
+		})
+	}
+
+	mux := http.NewServeMux()
+	handler := NewHandler(zap.NewNop(), store, profiles{ada})
+	mux.HandleFunc("GET /api/scoreboards/{id}/entries/{userID}/badge.svg", handler.RankHandler)
+	mux.HandleFunc("GET /api/scoreboards/{id}/card.svg", handler.CardHandler)
+	get := func(path, etag string) *httptest.ResponseRecorder {
+		t.Helper()
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		if etag != "" {
+			request.Header.Set("If-None-Match", etag)
+		}
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	path := "/api/scoreboards/" + board.ID.String() + "/entries/" + third.String() + "/badge.svg"
+	response := get(path, "")
+	if response.Code != http.StatusOK {
+		t.Fatalf("badge status = %d, want 200: %s", response.Code, response.Body)
+	}
+	for _, want := range []string{"Speedrun &lt;Any%&gt;", "#3 of 6", brightgreen} {
+		if !strings.Contains(response.Body.String(), want) {
+			t.Errorf("badge does not contain %q:\n%s", want, response.Body)
+		}
+	}
+	if got := response.Header().Get("Content-Type"); got != "image/svg+xml; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want SVG", got)
+	}
+	if got := response.Header().Get("Cache-Control"); got != "public, max-age=300" {
+		t.Errorf("Cache-Control = %q, want public caching", got)
+	}
+	if cached := get(path, response.Header().Get("ETag")); cached.Code != http.StatusNotModified || cached.Body.Len() != 0 {
+		t.Errorf("badge with matching ETag status = %d, want 304", cached.Code)
+	}
+
+	unranked := get("/api/scoreboards/"+board.ID.String()+"/entries/"+uuid.NewString()+"/badge.svg?label=PB", "")
+	if unranked.Code != http.StatusOK || !strings.Contains(unranked.Body.String(), "PB: unranked") {
+		t.Errorf("badge without entry = %d %s, want an unranked badge", unranked.Code, unranked.Body)
+	}
+	missing := get("/api/scoreboards/"+uuid.NewString()+"/entries/"+third.String()+"/badge.svg", "")
+	if missing.Code != http.StatusNotFound || !strings.Contains(missing.Body.String(), "not found") {
+		t.Errorf("badge of missing board = %d %s, want a not found badge", missing.Code, missing.Body)
+	}
+
+	card := get("/api/scoreboards/"+board.ID.String()+"/card.svg?theme=dark", "")
+	if card.Code != http.StatusOK {
+		t.Fatalf("card status = %d, want 200: %s", card.Code, card.Body)
+	}
+	for _, want := range []string{">Ada<", ">6,000<", "#0d1117", third.String()[:8]} {
+		if !strings.Contains(card.Body.String(), want) {
+			t.Errorf("card does not contain %q:\n%s", want, card.Body)
+		}
+	}
+	if strings.Contains(card.Body.String(), ">#6<") {
+		t.Errorf("card shows more than %d players:\n%s", cardSize, card.Body)
+	}
+	if response := get("/api/scoreboards/"+board.ID.String()+"/card.svg?theme=neon", ""); response.Code != http.StatusBadRequest {
+		t.Errorf("card with unknown theme status = %d, want 400", response.Code)
+	}
+}
+
+func TestThousands(t *testing.T) {
+	for n, want := range map[int64]string{0: "0", 999: "999", 1204: "1,204", 1234567: "1,234,567", -4500: "-4,500"} {
+		if got := thousands(n); got != want {
+			t.Errorf("thousands(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
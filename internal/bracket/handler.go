@@ -0,0 +1,293 @@
+package bracket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"scoreboard-api/internal/scoreboard"
+)
+
+type Store interface {
+	Create(ctx context.Context, scoreboardID uuid.UUID, format Format, seeding Seeding, participants []BracketParticipant) (*Tournament, error)
+	Get(ctx context.Context, scoreboardID uuid.UUID) (*Tournament, error)
+	ReportResult(ctx context.Context, scoreboardID uuid.UUID, matchNumber int, winnerID uuid.UUID, scoreA, scoreB pgtype.Int4) (*Tournament, error)
+	Delete(ctx context.Context, scoreboardID uuid.UUID) error
+}
+
+// CreateBracketPayload defines the expected request body for starting a bracket.
+type CreateBracketPayload struct {
+	Format       string               `json:"format" validate:"required,oneof=single_elimination double_elimination"`
+	Seeding      string               `json:"seeding" validate:"omitempty,oneof=rating manual"`
+	Participants []ParticipantPayload `json:"participants" validate:"required,min=2,dive"`
+}
+
+type ParticipantPayload struct {
+	Name   string `json:"name" validate:"required,max=255"`
+	Rating *int32 `json:"rating"`
+	Seed   *int32 `json:"seed" validate:"omitempty,min=1"`
+}
+
+// ResultPayload defines the expected request body for reporting a match result.
+type ResultPayload struct {
+	WinnerID string `json:"winnerId" validate:"required,uuid"`
+	ScoreA   *int32 `json:"scoreA" validate:"omitempty,min=0"`
+	ScoreB   *int32 `json:"scoreB" validate:"omitempty,min=0"`
+}
+
+type ParticipantResponse struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Seed   int32  `json:"seed"`
+	Rating *int32 `json:"rating"`
+}
+
+type MatchResponse struct {
+	Number       int     `json:"number"`
+	Section      string  `json:"section"`
+	Round        int     `json:"round"`
+	Position     int     `json:"position"`
+	Status       string  `json:"status"`
+	ParticipantA *string `json:"participantA"`
+	ParticipantB *string `json:"participantB"`
+	WinnerID     *string `json:"winnerId"`
+	ScoreA       *int32  `json:"scoreA"`
+	ScoreB       *int32  `json:"scoreB"`
+}
+
+type Response struct {
+	Format       string                `json:"format"`
+	Completed    bool                  `json:"completed"`
+	Participants []ParticipantResponse `json:"participants"`
+	Matches      []MatchResponse       `json:"matches"`
+}
+
+type PlacementResponse struct {
+	Place       *int                `json:"place"`
+	Participant ParticipantResponse `json:"participant"`
+}
+
+type Handler struct {
+	validator *validator.Validate
+	tracer    trace.Tracer
+	logger    *zap.Logger
+	store     Store
+}
+
+func NewHandler(v *validator.Validate, logger *zap.Logger, s Store) Handler {
+	return Handler{
+		validator: v,
+		tracer:    otel.Tracer("bracket/handler"),
+		logger:    logger,
+		store:     s,
+	}
+}
+
+func (h Handler) CreateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+
+	var payload CreateBracketPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validator.Struct(payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	seeding := Seeding(payload.Seeding)
+	if seeding == "" {
+		seeding = SeedingManual
+	}
+	participants := make([]BracketParticipant, len(payload.Participants))
+	for index, participant := range payload.Participants {
+		participants[index] = BracketParticipant{Name: participant.Name}
+		if participant.Rating != nil {
+			participants[index].Rating = pgtype.Int4{Int32: *participant.Rating, Valid: true}
+		}
+		if participant.Seed != nil {
+			participants[index].Seed = *participant.Seed
+		}
+	}
+
+	tournament, err := h.store.Create(ctx, scoreboardID, Format(payload.Format), seeding, participants)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, GenerateResponse(tournament))
+}
+
+func (h Handler) GetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	tournament, err := h.store.Get(ctx, scoreboardID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, GenerateResponse(tournament))
+}
+
+func (h Handler) DeleteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	err = h.store.Delete(ctx, scoreboardID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h Handler) ReportResultHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	matchNumber, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil {
+		http.Error(w, "Invalid match number", http.StatusBadRequest)
+		return
+	}
+
+	var payload ResultPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validator.Struct(payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tournament, err := h.store.ReportResult(ctx, scoreboardID, matchNumber, uuid.MustParse(payload.WinnerID), toInt4(payload.ScoreA), toInt4(payload.ScoreB))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, GenerateResponse(tournament))
+}
+
+func (h Handler) PlacementsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	tournament, err := h.store.Get(ctx, scoreboardID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	placements := tournament.Placements()
+	response := make([]PlacementResponse, len(placements))
+	for index, placement := range placements {
+		response[index] = PlacementResponse{
+			Place:       placement.Place,
+			Participant: GenerateParticipantResponse(placement.Participant),
+		}
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+func (h Handler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrBracketNotFound), errors.Is(err, ErrMatchNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrBracketExists), errors.Is(err, ErrMatchNotReady):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, ErrNotEnoughParticipants), errors.Is(err, ErrInvalidSeeds), errors.Is(err, ErrInvalidWinner):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		h.logger.Error("Bracket request failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func GenerateResponse(tournament *Tournament) Response {
+	response := Response{
+		Format:       string(tournament.Format),
+		Completed:    tournament.Completed(),
+		Participants: make([]ParticipantResponse, len(tournament.Participants)),
+		Matches:      make([]MatchResponse, len(tournament.Matches)),
+	}
+	for index, participant := range tournament.Participants {
+		response.Participants[index] = GenerateParticipantResponse(participant)
+	}
+	for index, match := range tournament.Matches {
+		matchResponse := MatchResponse{
+			Number:       match.Number,
+			Section:      string(match.Section),
+			Round:        match.Round,
+			Position:     match.Position,
+			Status:       string(match.Status),
+			ParticipantA: participantID(match.A.Participant),
+			ParticipantB: participantID(match.B.Participant),
+			WinnerID:     participantID(match.Winner),
+		}
+		if match.Result != nil {
+			if match.Result.ScoreA.Valid {
+				matchResponse.ScoreA = &match.Result.ScoreA.Int32
+			}
+			if match.Result.ScoreB.Valid {
+				matchResponse.ScoreB = &match.Result.ScoreB.Int32
+			}
+		}
+		response.Matches[index] = matchResponse
+	}
+	return response
+}
+
+func GenerateParticipantResponse(participant BracketParticipant) ParticipantResponse {
+	response := ParticipantResponse{
+		ID:   participant.ID.String(),
+		Name: participant.Name,
+		Seed: participant.Seed,
+	}
+	if participant.Rating.Valid {
+		response.Rating = &participant.Rating.Int32
+	}
+	return response
+}
+
+func participantID(participant *BracketParticipant) *string {
+	if participant == nil {
+		return nil
+	}
+	id := participant.ID.String()
+	return &id
+}
+
+func toInt4(value *int32) pgtype.Int4 {
+	if value == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: *value, Valid: true}
+}
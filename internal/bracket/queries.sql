@@ -0,0 +1,50 @@
+-- name: GetBracket :one
+SELECT * FROM brackets WHERE scoreboard_id = $1;
+
+-- name: LockBracket :one
+SELECT * FROM brackets WHERE scoreboard_id = $1 FOR UPDATE;
+
+-- name: CreateBracket :one
+INSERT INTO brackets (
+    scoreboard_id, format, created_at, updated_at
+) VALUES (
+    $1, $2,
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+)
+RETURNING *;
+
+-- name: TouchBracket :exec
+UPDATE brackets SET updated_at = CURRENT_TIMESTAMP WHERE scoreboard_id = $1;
+
+-- name: DeleteBracket :exec
+DELETE FROM brackets WHERE scoreboard_id = $1;
+
+-- name: CreateParticipant :one
+INSERT INTO bracket_participants (
+    id, scoreboard_id, name, rating, seed, created_at
+) VALUES (
+    gen_random_uuid(),
+    $1, $2, $3, $4,
+    CURRENT_TIMESTAMP
+)
+RETURNING *;
+
+-- name: ListParticipants :many
+SELECT * FROM bracket_participants
+WHERE scoreboard_id = $1
+ORDER BY seed;
+
+-- name: CreateResult :one
+INSERT INTO bracket_results (
+    scoreboard_id, match_number, winner_id, score_a, score_b, created_at
+) VALUES (
+    $1, $2, $3, $4, $5,
+    CURRENT_TIMESTAMP
+)
+RETURNING *;
+
+-- name: ListResults :many
+SELECT * FROM bracket_results
+WHERE scoreboard_id = $1
+ORDER BY match_number;
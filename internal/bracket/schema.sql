@@ -0,0 +1,26 @@
+CREATE TABLE brackets (
+    scoreboard_id UUID PRIMARY KEY,
+    format VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE bracket_participants (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scoreboard_id UUID NOT NULL REFERENCES brackets (scoreboard_id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    rating INT,
+    seed INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (scoreboard_id, seed)
+);
+
+CREATE TABLE bracket_results (
+    scoreboard_id UUID NOT NULL REFERENCES brackets (scoreboard_id) ON DELETE CASCADE,
+    match_number INT NOT NULL,
+    winner_id UUID NOT NULL REFERENCES bracket_participants (id) ON DELETE CASCADE,
+    score_a INT,
+    score_b INT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (scoreboard_id, match_number)
+);
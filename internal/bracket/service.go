@@ -0,0 +1,184 @@
+package bracket
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrBracketNotFound = errors.New("bracket not found")
+	ErrBracketExists   = errors.New("scoreboard already hosts a bracket")
+)
+
+// uniqueViolation is the Postgres error code raised by a duplicate key.
+const uniqueViolation = "23505"
+
+type Service struct {
+	db      *pgxpool.Pool
+	queries *Queries
+	logger  *zap.Logger
+	tracer  trace.Tracer
+}
+
+func NewService(logger *zap.Logger, db *pgxpool.Pool) *Service {
+	return &Service{
+		db:      db,
+		queries: New(db),
+		logger:  logger,
+		tracer:  otel.Tracer("bracket/service"),
+	}
+}
+
+// Create seeds the participants and stores a new bracket for the scoreboard.
+func (s *Service) Create(ctx context.Context, scoreboardID uuid.UUID, format Format, seeding Seeding, participants []BracketParticipant) (*Tournament, error) {
+	traceCtx, span := s.tracer.Start(ctx, "Create")
+	defer span.End()
+
+	ordered, err := SeedOrder(participants, seeding)
+	if err != nil {
+		return nil, err
+	}
+	if len(ordered) < 2 {
+		return nil, ErrNotEnoughParticipants
+	}
+
+	tx, err := s.db.Begin(traceCtx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback(traceCtx)
+	}()
+	queries := s.queries.WithTx(tx)
+
+	_, err = queries.CreateBracket(traceCtx, CreateBracketParams{
+		ScoreboardID: scoreboardID,
+		Format:       string(format),
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return nil, ErrBracketExists
+		}
+		return nil, err
+	}
+
+	created := make([]BracketParticipant, len(ordered))
+	for index, participant := range ordered {
+		created[index], err = queries.CreateParticipant(traceCtx, CreateParticipantParams{
+			ScoreboardID: scoreboardID,
+			Name:         participant.Name,
+			Rating:       participant.Rating,
+			Seed:         participant.Seed,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(traceCtx); err != nil {
+		return nil, err
+	}
+	return NewTournament(format, created)
+}
+
+func (s *Service) Get(ctx context.Context, scoreboardID uuid.UUID) (*Tournament, error) {
+	traceCtx, span := s.tracer.Start(ctx, "Get")
+	defer span.End()
+
+	bracket, err := s.queries.GetBracket(traceCtx, scoreboardID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBracketNotFound
+		}
+		return nil, err
+	}
+	return s.load(traceCtx, s.queries, bracket)
+}
+
+// ReportResult records the winner of a ready match and advances the bracket.
+// The bracket row is locked so that concurrent reports are applied in turn.
+func (s *Service) ReportResult(ctx context.Context, scoreboardID uuid.UUID, matchNumber int, winnerID uuid.UUID, scoreA, scoreB pgtype.Int4) (*Tournament, error) {
+	traceCtx, span := s.tracer.Start(ctx, "ReportResult")
+	defer span.End()
+
+	tx, err := s.db.Begin(traceCtx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback(traceCtx)
+	}()
+	queries := s.queries.WithTx(tx)
+
+	bracket, err := queries.LockBracket(traceCtx, scoreboardID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBracketNotFound
+		}
+		return nil, err
+	}
+	tournament, err := s.load(traceCtx, queries, bracket)
+	if err != nil {
+		return nil, err
+	}
+	if err := tournament.Validate(matchNumber, winnerID); err != nil {
+		return nil, err
+	}
+
+	_, err = queries.CreateResult(traceCtx, CreateResultParams{
+		ScoreboardID: scoreboardID,
+		MatchNumber:  int32(matchNumber),
+		WinnerID:     winnerID,
+		ScoreA:       scoreA,
+		ScoreB:       scoreB,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := queries.TouchBracket(traceCtx, scoreboardID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(traceCtx); err != nil {
+		return nil, err
+	}
+
+	results, err := s.queries.ListResults(traceCtx, scoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	tournament.Resolve(results)
+	return tournament, nil
+}
+
+func (s *Service) Delete(ctx context.Context, scoreboardID uuid.UUID) error {
+	traceCtx, span := s.tracer.Start(ctx, "Delete")
+	defer span.End()
+	return s.queries.DeleteBracket(traceCtx, scoreboardID)
+}
+
+func (s *Service) load(ctx context.Context, queries *Queries, bracket Bracket) (*Tournament, error) {
+	participants, err := queries.ListParticipants(ctx, bracket.ScoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	results, err := queries.ListResults(ctx, bracket.ScoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	tournament, err := NewTournament(Format(bracket.Format), participants)
+	if err != nil {
+		return nil, err
+	}
+	tournament.Resolve(results)
+	return tournament, nil
+}
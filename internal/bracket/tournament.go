@@ -0,0 +1,428 @@
+package bracket
+
+import (
+	"errors"
+	"math/bits"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+type Format string
+
+const (
+	FormatSingleElimination Format = "single_elimination"
+	FormatDoubleElimination Format = "double_elimination"
+)
+
+type Seeding string
+
+const (
+	SeedingRating Seeding = "rating"
+	SeedingManual Seeding = "manual"
+)
+
+type Section string
+
+const (
+	SectionWinners Section = "winners"
+	SectionLosers  Section = "losers"
+	SectionFinal   Section = "final"
+)
+
+type MatchStatus string
+
+const (
+	// MatchPending waits for at least one participant to be decided upstream.
+	MatchPending MatchStatus = "pending"
+	// MatchReady has both participants and waits for a result to be reported.
+	MatchReady MatchStatus = "ready"
+	// MatchCompleted has a reported result.
+	MatchCompleted MatchStatus = "completed"
+	// MatchBye was decided without being played because a slot stayed empty.
+	MatchBye MatchStatus = "bye"
+	// MatchSkipped is a grand final reset that turned out not to be needed.
+	MatchSkipped MatchStatus = "skipped"
+)
+
+var (
+	ErrNotEnoughParticipants = errors.New("a bracket needs at least two participants")
+	ErrInvalidSeeds          = errors.New("manual seeds must be unique and numbered from 1")
+	ErrMatchNotFound         = errors.New("match not found")
+	ErrMatchNotReady         = errors.New("match is not ready to be reported")
+	ErrInvalidWinner         = errors.New("winner is not a participant of the match")
+)
+
+// feed describes where the participant of a match slot comes from: either a
+// seed of the first round, or the winner or loser of an earlier match.
+type feed struct {
+	seed  int
+	match int
+	loser bool
+}
+
+type Slot struct {
+	Participant *BracketParticipant
+	// Bye is set when no participant will ever arrive in this slot.
+	Bye bool
+}
+
+type Match struct {
+	Number   int
+	Section  Section
+	Round    int
+	Position int
+	A        Slot
+	B        Slot
+	Status   MatchStatus
+	Winner   *BracketParticipant
+	Loser    *BracketParticipant
+	Result   *BracketResult
+
+	feedA feed
+	feedB feed
+}
+
+func (m Match) finished() bool {
+	return m.Status == MatchCompleted || m.Status == MatchBye || m.Status == MatchSkipped
+}
+
+// Tournament is the full set of matches of a bracket, derived from its seeded
+// participants and the results reported so far.
+type Tournament struct {
+	Format       Format
+	Participants []BracketParticipant
+	Matches      []Match
+	// size is the number of first round slots, a power of two.
+	size int
+}
+
+type Placement struct {
+	Participant BracketParticipant
+	// Place is nil while the participant is still in the running.
+	Place *int
+}
+
+// SeedOrder sorts the participants into seed order. Rating seeding puts the
+// highest rating first and unrated participants last; manual seeding keeps the
+// given seeds, or the given order when no seed is set.
+func SeedOrder(participants []BracketParticipant, seeding Seeding) ([]BracketParticipant, error) {
+	ordered := make([]BracketParticipant, len(participants))
+	copy(ordered, participants)
+
+	switch seeding {
+	case SeedingRating:
+		sort.SliceStable(ordered, func(i, j int) bool {
+			if ordered[i].Rating.Valid != ordered[j].Rating.Valid {
+				return ordered[i].Rating.Valid
+			}
+			return ordered[i].Rating.Int32 > ordered[j].Rating.Int32
+		})
+	case SeedingManual:
+		seeded := 0
+		for _, participant := range ordered {
+			if participant.Seed != 0 {
+				seeded++
+			}
+		}
+		if seeded != 0 {
+			if seeded != len(ordered) {
+				return nil, ErrInvalidSeeds
+			}
+			sort.SliceStable(ordered, func(i, j int) bool {
+				return ordered[i].Seed < ordered[j].Seed
+			})
+			for index, participant := range ordered {
+				if int(participant.Seed) != index+1 {
+					return nil, ErrInvalidSeeds
+				}
+			}
+		}
+	}
+
+	for index := range ordered {
+		ordered[index].Seed = int32(index + 1)
+	}
+	return ordered, nil
+}
+
+// NewTournament generates the matches of a bracket for participants that are
+// already in seed order. Top seeds receive the byes when the field is not a
+// power of two.
+func NewTournament(format Format, participants []BracketParticipant) (*Tournament, error) {
+	if len(participants) < 2 {
+		return nil, ErrNotEnoughParticipants
+	}
+
+	size := 1 << bits.Len(uint(len(participants)-1))
+	rounds := bits.Len(uint(size)) - 1
+	t := &Tournament{
+		Format:       format,
+		Participants: participants,
+		size:         size,
+	}
+
+	// Winners bracket, numbered round by round so that every match comes
+	// after the matches feeding it.
+	winners := make([][]int, rounds+1)
+	order := seedPositions(size)
+	for round := 1; round <= rounds; round++ {
+		count := size >> round
+		for position := 0; position < count; position++ {
+			var feedA, feedB feed
+			if round == 1 {
+				feedA = feed{seed: order[2*position]}
+				feedB = feed{seed: order[2*position+1]}
+			} else {
+				feedA = feed{match: winners[round-1][2*position]}
+				feedB = feed{match: winners[round-1][2*position+1]}
+			}
+			winners[round] = append(winners[round], t.add(SectionWinners, round, position, feedA, feedB))
+		}
+	}
+
+	if format != FormatDoubleElimination {
+		t.Resolve(nil)
+		return t, nil
+	}
+
+	// Losers bracket: odd rounds pair up the survivors of the previous
+	// losers round (or the first round losers), even rounds bring in the
+	// losers of the next winners round.
+	champion := winners[rounds][0]
+	challenger := feed{match: champion, loser: true}
+	var previous []int
+	for round := 1; round <= 2*(rounds-1); round++ {
+		count := size >> (round/2 + 2)
+		if round%2 == 0 {
+			count = size >> (round/2 + 1)
+		}
+		var current []int
+		for position := 0; position < count; position++ {
+			var feedA, feedB feed
+			switch {
+			case round == 1:
+				feedA = feed{match: winners[1][2*position], loser: true}
+				feedB = feed{match: winners[1][2*position+1], loser: true}
+			case round%2 == 0:
+				// Losers drop in mirrored to delay rematches.
+				dropping := winners[round/2+1]
+				feedA = feed{match: previous[position]}
+				feedB = feed{match: dropping[len(dropping)-1-position], loser: true}
+			default:
+				feedA = feed{match: previous[2*position]}
+				feedB = feed{match: previous[2*position+1]}
+			}
+			current = append(current, t.add(SectionLosers, round, position, feedA, feedB))
+		}
+		previous = current
+	}
+	if len(previous) == 1 {
+		challenger = feed{match: previous[0]}
+	}
+
+	final := t.add(SectionFinal, 1, 0, feed{match: champion}, challenger)
+	t.add(SectionFinal, 2, 0, feed{match: final}, feed{match: final, loser: true})
+	t.Resolve(nil)
+	return t, nil
+}
+
+func (t *Tournament) add(section Section, round, position int, feedA, feedB feed) int {
+	number := len(t.Matches) + 1
+	t.Matches = append(t.Matches, Match{
+		Number:   number,
+		Section:  section,
+		Round:    round,
+		Position: position,
+		Status:   MatchPending,
+		feedA:    feedA,
+		feedB:    feedB,
+	})
+	return number
+}
+
+// seedPositions returns the seeds of the first round slots in bracket order,
+// so that the top seeds can only meet in the later rounds.
+func seedPositions(size int) []int {
+	order := []int{1}
+	for len(order) < size {
+		next := make([]int, 0, 2*len(order))
+		for _, seed := range order {
+			next = append(next, seed, 2*len(order)+1-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+func (t *Tournament) Match(number int) (*Match, error) {
+	if number < 1 || number > len(t.Matches) {
+		return nil, ErrMatchNotFound
+	}
+	return &t.Matches[number-1], nil
+}
+
+// Resolve advances participants through the bracket according to the
+// reported results, handing out byes where a slot stays empty.
+func (t *Tournament) Resolve(results []BracketResult) {
+	reported := make(map[int]BracketResult, len(results))
+	for _, result := range results {
+		reported[int(result.MatchNumber)] = result
+	}
+
+	for index := range t.Matches {
+		match := &t.Matches[index]
+		match.A = t.slot(match.feedA)
+		match.B = t.slot(match.feedB)
+		match.Status = MatchPending
+		match.Winner, match.Loser, match.Result = nil, nil, nil
+
+		if t.isReset(match) {
+			final := &t.Matches[match.feedA.match-1]
+			if final.finished() && final.Winner != nil && final.A.Participant != nil && final.Winner.ID == final.A.Participant.ID {
+				match.Status = MatchSkipped
+				continue
+			}
+		}
+
+		switch {
+		case match.A.Bye && match.B.Bye:
+			match.Status = MatchBye
+		case match.A.Bye && match.B.Participant != nil:
+			match.Status = MatchBye
+			match.Winner = match.B.Participant
+		case match.B.Bye && match.A.Participant != nil:
+			match.Status = MatchBye
+			match.Winner = match.A.Participant
+		case match.A.Participant != nil && match.B.Participant != nil:
+			match.Status = MatchReady
+			result, ok := reported[match.Number]
+			if !ok {
+				continue
+			}
+			switch result.WinnerID {
+			case match.A.Participant.ID:
+				match.Winner, match.Loser = match.A.Participant, match.B.Participant
+			case match.B.Participant.ID:
+				match.Winner, match.Loser = match.B.Participant, match.A.Participant
+			default:
+				continue
+			}
+			match.Status = MatchCompleted
+			match.Result = &result
+		}
+	}
+}
+
+func (t *Tournament) slot(f feed) Slot {
+	if f.seed != 0 {
+		if f.seed > len(t.Participants) {
+			return Slot{Bye: true}
+		}
+		return Slot{Participant: &t.Participants[f.seed-1]}
+	}
+
+	source := t.Matches[f.match-1]
+	if !source.finished() {
+		return Slot{}
+	}
+	participant := source.Winner
+	if f.loser {
+		participant = source.Loser
+	}
+	if participant == nil {
+		return Slot{Bye: true}
+	}
+	return Slot{Participant: participant}
+}
+
+// isReset reports whether the match is the second grand final that is only
+// played when the losers bracket champion wins the first one.
+func (t *Tournament) isReset(match *Match) bool {
+	return match.Section == SectionFinal && match.Round == 2
+}
+
+// Validate checks that a result can be reported for the match.
+func (t *Tournament) Validate(number int, winnerID uuid.UUID) error {
+	match, err := t.Match(number)
+	if err != nil {
+		return err
+	}
+	if match.Status != MatchReady {
+		return ErrMatchNotReady
+	}
+	if match.A.Participant.ID != winnerID && match.B.Participant.ID != winnerID {
+		return ErrInvalidWinner
+	}
+	return nil
+}
+
+// Completed reports whether the champion of the bracket has been decided.
+func (t *Tournament) Completed() bool {
+	for _, match := range t.Matches {
+		if !match.finished() {
+			return false
+		}
+	}
+	return true
+}
+
+// Placements ranks the participants by how far they got. Participants that
+// went out in the same round share a place.
+func (t *Tournament) Placements() []Placement {
+	places := make(map[uuid.UUID]int)
+	rounds := bits.Len(uint(t.size)) - 1
+
+	// Places below the podium are shared by everyone knocked out in the same
+	// round, so count how many participants outlast each round.
+	losersMatches := make(map[int]int)
+	lastLosersRound := 0
+	for _, match := range t.Matches {
+		if match.Section == SectionLosers {
+			losersMatches[match.Round]++
+			lastLosersRound = max(lastLosersRound, match.Round)
+		}
+	}
+
+	for _, match := range t.Matches {
+		if match.Status != MatchCompleted || match.Loser == nil {
+			continue
+		}
+		switch {
+		case t.Format == FormatSingleElimination:
+			if match.Round == rounds {
+				places[match.Winner.ID] = 1
+			}
+			places[match.Loser.ID] = t.size>>match.Round + 1
+		case match.Section == SectionLosers:
+			place := 3
+			for round := match.Round + 1; round <= lastLosersRound; round++ {
+				place += losersMatches[round]
+			}
+			places[match.Loser.ID] = place
+		case match.Section == SectionFinal:
+			// A played reset is numbered after the first grand final and
+			// overrides its outcome.
+			places[match.Winner.ID] = 1
+			places[match.Loser.ID] = 2
+		}
+	}
+
+	placements := make([]Placement, len(t.Participants))
+	for index, participant := range t.Participants {
+		placements[index] = Placement{Participant: participant}
+		if place, ok := places[participant.ID]; ok {
+			placements[index].Place = &place
+		}
+	}
+	sort.SliceStable(placements, func(i, j int) bool {
+		if (placements[i].Place == nil) != (placements[j].Place == nil) {
+			return placements[i].Place != nil
+		}
+		if placements[i].Place == nil {
+			return false
+		}
+		return *placements[i].Place < *placements[j].Place
+	})
+	return placements
+}
@@ -0,0 +1,138 @@
+package bracket
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func participants(count int) []BracketParticipant {
+	field := make([]BracketParticipant, count)
+	for index := range field {
+		field[index] = BracketParticipant{
+			ID:   uuid.New(),
+			Name: fmt.Sprintf("Seed %d", index+1),
+			Seed: int32(index + 1),
+		}
+	}
+	return field
+}
+
+// playOut reports every ready match in favour of the better seed until the
+// bracket is complete.
+func playOut(t *testing.T, tournament *Tournament) {
+	t.Helper()
+	var results []BracketResult
+	for !tournament.Completed() {
+		progressed := false
+		for _, match := range tournament.Matches {
+			if match.Status != MatchReady {
+				continue
+			}
+			winner := match.A.Participant
+			if match.B.Participant.Seed < winner.Seed {
+				winner = match.B.Participant
+			}
+			results = append(results, BracketResult{MatchNumber: int32(match.Number), WinnerID: winner.ID})
+			progressed = true
+		}
+		if !progressed {
+			t.Fatalf("bracket is stuck with no ready match")
+		}
+		tournament.Resolve(results)
+	}
+}
+
+func TestSingleElimination(t *testing.T) {
+	tests := []struct {
+		name        string
+		count       int
+		wantMatches int
+		wantByes    int
+		wantPlaces  []int
+	}{
+		{name: "Power of two", count: 4, wantMatches: 3, wantByes: 0, wantPlaces: []int{1, 2, 3, 3}},
+		{name: "Top seeds get byes", count: 5, wantMatches: 7, wantByes: 3, wantPlaces: []int{1, 2, 3, 3, 5}},
+		{name: "Two participants", count: 2, wantMatches: 1, wantByes: 0, wantPlaces: []int{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tournament, err := NewTournament(FormatSingleElimination, participants(tt.count))
+			if err != nil {
+				t.Fatalf("NewTournament() unexpected error: %v", err)
+			}
+			if len(tournament.Matches) != tt.wantMatches {
+				t.Fatalf("NewTournament() generated %d matches, want %d", len(tournament.Matches), tt.wantMatches)
+			}
+			byes := 0
+			for _, match := range tournament.Matches {
+				if match.Status == MatchBye {
+					byes++
+				}
+			}
+			if byes != tt.wantByes {
+				t.Errorf("NewTournament() gave %d byes, want %d", byes, tt.wantByes)
+			}
+
+			playOut(t, tournament)
+			for index, placement := range tournament.Placements() {
+				if placement.Place == nil {
+					t.Fatalf("Placements()[%d] has no place", index)
+				}
+				if *placement.Place != tt.wantPlaces[index] {
+					t.Errorf("Placements()[%d].Place = %d, want %d", index, *placement.Place, tt.wantPlaces[index])
+				}
+				if int(placement.Participant.Seed) != index+1 {
+					t.Errorf("Placements()[%d] is seed %d, want seed %d", index, placement.Participant.Seed, index+1)
+				}
+			}
+		})
+	}
+}
+
+func TestDoubleElimination(t *testing.T) {
+	field := participants(4)
+	tournament, err := NewTournament(FormatDoubleElimination, field)
+	if err != nil {
+		t.Fatalf("NewTournament() unexpected error: %v", err)
+	}
+	// Three winners bracket matches, two losers bracket matches, the grand
+	// final and its reset.
+	if len(tournament.Matches) != 7 {
+		t.Fatalf("NewTournament() generated %d matches, want 7", len(tournament.Matches))
+	}
+
+	playOut(t, tournament)
+	reset, _ := tournament.Match(7)
+	if reset.Status != MatchSkipped {
+		t.Errorf("grand final reset status = %s, want %s", reset.Status, MatchSkipped)
+	}
+	wantPlaces := []int{1, 2, 3, 4}
+	for index, placement := range tournament.Placements() {
+		if placement.Place == nil || *placement.Place != wantPlaces[index] {
+			t.Errorf("Placements()[%d].Place = %v, want %d", index, placement.Place, wantPlaces[index])
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tournament, err := NewTournament(FormatSingleElimination, participants(4))
+	if err != nil {
+		t.Fatalf("NewTournament() unexpected error: %v", err)
+	}
+
+	if err := tournament.Validate(3, tournament.Participants[0].ID); err != ErrMatchNotReady {
+		t.Errorf("Validate() on the final = %v, want %v", err, ErrMatchNotReady)
+	}
+	if err := tournament.Validate(1, tournament.Participants[1].ID); err != ErrInvalidWinner {
+		t.Errorf("Validate() with an outsider = %v, want %v", err, ErrInvalidWinner)
+	}
+	if err := tournament.Validate(1, tournament.Participants[0].ID); err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+	if err := tournament.Validate(9, tournament.Participants[0].ID); err != ErrMatchNotFound {
+		t.Errorf("Validate() on a missing match = %v, want %v", err, ErrMatchNotFound)
+	}
+}
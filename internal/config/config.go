@@ -12,6 +12,11 @@ type Config struct {
 	BaseURL                  string `yaml:"base_url"                   envconfig:"BASE_URL"`
 	GoogleOauthClientID      string `yaml:"google_oauth_client_id"     envconfig:"GOOGLE_OAUTH_CLIENT_ID"`
 	GoogleOauthClientSecret  string `yaml:"google_oauth_client_secret" envconfig:"GOOGLE_OAUTH_CLIENT_SECRET"`
+	AdminToken               string `yaml:"admin_token"                envconfig:"ADMIN_TOKEN"`
+	Storage                  string `yaml:"storage"                    envconfig:"STORAGE"`
+	SQLitePath               string `yaml:"sqlite_path"                envconfig:"SQLITE_PATH"`
+	SQLiteMigrationSource    string `yaml:"sqlite_migration_source"    envconfig:"SQLITE_MIGRATION_SOURCE"`
+	GRPCAddress              string `yaml:"grpc_address"               envconfig:"GRPC_ADDRESS"`
 }
 
 func Load() Config {
@@ -23,6 +28,11 @@ func Load() Config {
 		BaseURL:                  getEnv("BASE_URL", "http://localhost:8080"),
 		GoogleOauthClientID:      getEnv("GOOGLE_OAUTH_CLIENT_ID", ""),
 		GoogleOauthClientSecret:  getEnv("GOOGLE_OAUTH_CLIENT_SECRET", ""),
+		AdminToken:               getEnv("ADMIN_TOKEN", ""),
+		Storage:                  getEnv("STORAGE", "postgres"),
+		SQLitePath:               getEnv("SQLITE_PATH", "scoreboard.db"),
+		SQLiteMigrationSource:    getEnv("SQLITE_MIGRATION_SOURCE", "file://internal/database/sqlite_migrations"),
+		GRPCAddress:              getEnv("GRPC_ADDRESS", "127.0.0.1:9090"),
 	}
 	return *config
 }
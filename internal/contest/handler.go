@@ -0,0 +1,564 @@
+package contest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"scoreboard-api/internal"
+	"scoreboard-api/internal/scoreboard"
+)
+
+type Store interface {
+	Get(ctx context.Context, scoreboardID uuid.UUID) (Contest, error)
+	Configure(ctx context.Context, arg UpsertContestParams) (Contest, error)
+	ListProblems(ctx context.Context, scoreboardID uuid.UUID) ([]ContestProblem, error)
+	CreateProblem(ctx context.Context, arg CreateProblemParams) (ContestProblem, error)
+	DeleteProblem(ctx context.Context, scoreboardID, problemID uuid.UUID) error
+	ListTeams(ctx context.Context, scoreboardID uuid.UUID) ([]ContestTeam, error)
+	CreateTeam(ctx context.Context, arg CreateTeamParams) (ContestTeam, error)
+	DeleteTeam(ctx context.Context, scoreboardID, teamID uuid.UUID) error
+	RecordAttempt(ctx context.Context, arg CreateAttemptParams) (ContestAttempt, error)
+	Standings(ctx context.Context, scoreboardID uuid.UUID, privileged bool) (Board, error)
+	Unfreeze(ctx context.Context, scoreboardID uuid.UUID) (Contest, error)
+}
+
+// ContestPayload defines the expected request body for configuring a contest.
+type ContestPayload struct {
+	Mode           string     `json:"mode" validate:"required,oneof=icpc ctf"`
+	StartsAt       time.Time  `json:"startsAt" validate:"required"`
+	PenaltyMinutes *int32     `json:"penaltyMinutes" validate:"omitempty,min=0"`
+	FreezeAt       *time.Time `json:"freezeAt"`
+}
+
+// ProblemPayload defines the expected request body for adding a problem.
+type ProblemPayload struct {
+	Label  string `json:"label" validate:"required,max=16"`
+	Name   string `json:"name" validate:"required,max=255"`
+	Points *int32 `json:"points" validate:"omitempty,min=0"`
+	// Scoring, MinimumPoints and Decay make the value drop as more teams
+	// solve the problem, starting from Points.
+	Scoring       *string `json:"scoring" validate:"omitempty,oneof=static linear logarithmic parabolic"`
+	MinimumPoints *int32  `json:"minimumPoints" validate:"omitempty,min=0"`
+	Decay         *int32  `json:"decay" validate:"omitempty,min=0"`
+}
+
+// TeamPayload defines the expected request body for registering a team.
+type TeamPayload struct {
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+// AttemptPayload defines the expected request body for a judged submission.
+type AttemptPayload struct {
+	TeamID      string     `json:"teamId" validate:"required,uuid"`
+	ProblemID   string     `json:"problemId" validate:"required,uuid"`
+	Accepted    bool       `json:"accepted"`
+	SubmittedAt *time.Time `json:"submittedAt"`
+}
+
+type Response struct {
+	Mode           string  `json:"mode"`
+	StartsAt       string  `json:"startsAt"`
+	PenaltyMinutes int32   `json:"penaltyMinutes"`
+	FreezeAt       *string `json:"freezeAt"`
+	UnfrozenAt     *string `json:"unfrozenAt"`
+	CreatedAt      string  `json:"createdAt"`
+	UpdatedAt      string  `json:"updatedAt"`
+}
+
+type ProblemResponse struct {
+	ID            string `json:"id"`
+	Label         string `json:"label"`
+	Name          string `json:"name"`
+	Points        int32  `json:"points"`
+	Scoring       string `json:"scoring"`
+	MinimumPoints int32  `json:"minimumPoints"`
+	Decay         int32  `json:"decay"`
+	// Value is what a solve is currently worth, only set on standings.
+	Value *int `json:"value,omitempty"`
+}
+
+type TeamResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type AttemptResponse struct {
+	ID          string `json:"id"`
+	TeamID      string `json:"teamId"`
+	ProblemID   string `json:"problemId"`
+	Accepted    bool   `json:"accepted"`
+	SubmittedAt string `json:"submittedAt"`
+}
+
+type ProblemResultResponse struct {
+	ProblemID      string `json:"problemId"`
+	Label          string `json:"label"`
+	Attempts       int    `json:"attempts"`
+	Solved         bool   `json:"solved"`
+	SolvedAtMinute *int   `json:"solvedAtMinute"`
+	Penalty        int    `json:"penalty"`
+	Points         int    `json:"points"`
+	FirstToSolve   bool   `json:"firstToSolve"`
+	Pending        int    `json:"pending"`
+}
+
+type TeamStandingResponse struct {
+	Rank     int                     `json:"rank"`
+	TeamID   string                  `json:"teamId"`
+	Team     string                  `json:"team"`
+	Solved   int                     `json:"solved"`
+	Penalty  int                     `json:"penalty"`
+	Score    int                     `json:"score"`
+	Problems []ProblemResultResponse `json:"problems"`
+}
+
+type StandingsResponse struct {
+	Frozen   bool                   `json:"frozen"`
+	Problems []ProblemResponse      `json:"problems"`
+	Teams    []TeamStandingResponse `json:"teams"`
+}
+
+type Handler struct {
+	validator  *validator.Validate
+	tracer     trace.Tracer
+	logger     *zap.Logger
+	store      Store
+	adminToken string
+}
+
+// NewHandler creates the contest handlers. Only requests bearing the admin
+// token may change a contest, its problems and teams or record attempts; they
+// also see live standings during a freeze and may reveal the final results.
+func NewHandler(v *validator.Validate, logger *zap.Logger, s Store, adminToken string) Handler {
+	return Handler{
+		validator:  v,
+		tracer:     otel.Tracer("contest/handler"),
+		logger:     logger,
+		store:      s,
+		adminToken: adminToken,
+	}
+}
+
+func (h Handler) GetHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	contest, err := h.store.Get(ctx, scoreboardID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, GenerateResponse(contest))
+}
+
+func (h Handler) ConfigureHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	if !internal.IsPrivileged(r, h.adminToken) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	var payload ContestPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validator.Struct(payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if payload.FreezeAt != nil && payload.FreezeAt.Before(payload.StartsAt) {
+		http.Error(w, "freezeAt must not be before startsAt", http.StatusBadRequest)
+		return
+	}
+
+	params := UpsertContestParams{
+		ScoreboardID:   scoreboardID,
+		Mode:           payload.Mode,
+		StartsAt:       pgtype.Timestamp{Time: payload.StartsAt.UTC(), Valid: true},
+		PenaltyMinutes: 20,
+	}
+	if payload.PenaltyMinutes != nil {
+		params.PenaltyMinutes = *payload.PenaltyMinutes
+	}
+	if payload.FreezeAt != nil {
+		params.FreezeAt = pgtype.Timestamp{Time: payload.FreezeAt.UTC(), Valid: true}
+	}
+	contest, err := h.store.Configure(ctx, params)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, GenerateResponse(contest))
+}
+
+func (h Handler) ListProblemsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	problems, err := h.store.ListProblems(ctx, scoreboardID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	response := make([]ProblemResponse, len(problems))
+	for index, problem := range problems {
+		response[index] = GenerateProblemResponse(problem)
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+func (h Handler) CreateProblemHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	if !internal.IsPrivileged(r, h.adminToken) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	var payload ProblemPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validator.Struct(payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	params := CreateProblemParams{
+		ScoreboardID: scoreboardID,
+		Label:        payload.Label,
+		Name:         payload.Name,
+		Points:       1,
+		Scoring:      string(ScoringStatic),
+	}
+	if payload.Points != nil {
+		params.Points = *payload.Points
+	}
+	if payload.Scoring != nil {
+		params.Scoring = *payload.Scoring
+	}
+	if payload.MinimumPoints != nil {
+		params.MinimumPoints = *payload.MinimumPoints
+	}
+	if payload.Decay != nil {
+		params.Decay = *payload.Decay
+	}
+	if params.MinimumPoints > params.Points {
+		http.Error(w, "minimumPoints must not exceed points", http.StatusBadRequest)
+		return
+	}
+	if (Scoring(params.Scoring) == ScoringLogarithmic || Scoring(params.Scoring) == ScoringParabolic) && params.Decay == 0 {
+		http.Error(w, "decay must be positive for logarithmic and parabolic scoring", http.StatusBadRequest)
+		return
+	}
+	problem, err := h.store.CreateProblem(ctx, params)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, GenerateProblemResponse(problem))
+}
+
+func (h Handler) DeleteProblemHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	if !internal.IsPrivileged(r, h.adminToken) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+	problemID, err := uuid.Parse(r.PathValue("problemID"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	err = h.store.DeleteProblem(ctx, scoreboardID, problemID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h Handler) ListTeamsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	teams, err := h.store.ListTeams(ctx, scoreboardID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	response := make([]TeamResponse, len(teams))
+	for index, team := range teams {
+		response[index] = GenerateTeamResponse(team)
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+func (h Handler) CreateTeamHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	if !internal.IsPrivileged(r, h.adminToken) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	var payload TeamPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validator.Struct(payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	team, err := h.store.CreateTeam(ctx, CreateTeamParams{
+		ScoreboardID: scoreboardID,
+		Name:         payload.Name,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, GenerateTeamResponse(team))
+}
+
+func (h Handler) DeleteTeamHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	if !internal.IsPrivileged(r, h.adminToken) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+	teamID, err := uuid.Parse(r.PathValue("teamID"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	err = h.store.DeleteTeam(ctx, scoreboardID, teamID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h Handler) RecordAttemptHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	if !internal.IsPrivileged(r, h.adminToken) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	var payload AttemptPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validator.Struct(payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	params := CreateAttemptParams{
+		ScoreboardID: scoreboardID,
+		TeamID:       uuid.MustParse(payload.TeamID),
+		ProblemID:    uuid.MustParse(payload.ProblemID),
+		Accepted:     payload.Accepted,
+	}
+	if payload.SubmittedAt != nil {
+		params.SubmittedAt = pgtype.Timestamp{Time: payload.SubmittedAt.UTC(), Valid: true}
+	}
+	attempt, err := h.store.RecordAttempt(ctx, params)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, GenerateAttemptResponse(attempt))
+}
+
+func (h Handler) StandingsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	board, err := h.store.Standings(ctx, scoreboardID, internal.IsPrivileged(r, h.adminToken))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, GenerateStandingsResponse(board))
+}
+
+func (h Handler) UnfreezeHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	if !internal.IsPrivileged(r, h.adminToken) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+	contest, err := h.store.Unfreeze(ctx, scoreboardID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, GenerateResponse(contest))
+}
+
+func (h Handler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrContestNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrUnknownEntity):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, ErrDuplicate):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		h.logger.Error("Contest request failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func GenerateResponse(contest Contest) Response {
+	response := Response{
+		Mode:           contest.Mode,
+		StartsAt:       contest.StartsAt.Time.Format(time.RFC3339),
+		PenaltyMinutes: contest.PenaltyMinutes,
+		CreatedAt:      contest.CreatedAt.Time.Format(time.RFC3339),
+		UpdatedAt:      contest.UpdatedAt.Time.Format(time.RFC3339),
+	}
+	if contest.FreezeAt.Valid {
+		freezeAt := contest.FreezeAt.Time.Format(time.RFC3339)
+		response.FreezeAt = &freezeAt
+	}
+	if contest.UnfrozenAt.Valid {
+		unfrozenAt := contest.UnfrozenAt.Time.Format(time.RFC3339)
+		response.UnfrozenAt = &unfrozenAt
+	}
+	return response
+}
+
+func GenerateProblemResponse(problem ContestProblem) ProblemResponse {
+	return ProblemResponse{
+		ID:            problem.ID.String(),
+		Label:         problem.Label,
+		Name:          problem.Name,
+		Points:        problem.Points,
+		Scoring:       problem.Scoring,
+		MinimumPoints: problem.MinimumPoints,
+		Decay:         problem.Decay,
+	}
+}
+
+func GenerateTeamResponse(team ContestTeam) TeamResponse {
+	return TeamResponse{
+		ID:   team.ID.String(),
+		Name: team.Name,
+	}
+}
+
+func GenerateAttemptResponse(attempt ContestAttempt) AttemptResponse {
+	return AttemptResponse{
+		ID:          attempt.ID.String(),
+		TeamID:      attempt.TeamID.String(),
+		ProblemID:   attempt.ProblemID.String(),
+		Accepted:    attempt.Accepted,
+		SubmittedAt: attempt.SubmittedAt.Time.Format(time.RFC3339),
+	}
+}
+
+func GenerateStandingsResponse(board Board) StandingsResponse {
+	response := StandingsResponse{
+		Frozen:   board.Frozen,
+		Problems: make([]ProblemResponse, len(board.Problems)),
+		Teams:    make([]TeamStandingResponse, len(board.Teams)),
+	}
+	for index, problem := range board.Problems {
+		response.Problems[index] = GenerateProblemResponse(problem)
+		value := board.Values[index]
+		response.Problems[index].Value = &value
+	}
+	for index, standing := range board.Teams {
+		team := TeamStandingResponse{
+			Rank:     standing.Rank,
+			TeamID:   standing.TeamID.String(),
+			Team:     standing.Team,
+			Solved:   standing.Solved,
+			Penalty:  standing.Penalty,
+			Score:    standing.Score,
+			Problems: make([]ProblemResultResponse, len(standing.Problems)),
+		}
+		for problem, result := range standing.Problems {
+			team.Problems[problem] = ProblemResultResponse{
+				ProblemID:    result.ProblemID.String(),
+				Label:        result.Label,
+				Attempts:     result.Attempts,
+				Solved:       result.Solved,
+				Penalty:      result.Penalty,
+				Points:       result.Points,
+				FirstToSolve: result.FirstToSolve,
+				Pending:      result.Pending,
+			}
+			if result.Solved {
+				solvedAt := result.SolvedAtMinute
+				team.Problems[problem].SolvedAtMinute = &solvedAt
+			}
+		}
+		response.Teams[index] = team
+	}
+	return response
+}
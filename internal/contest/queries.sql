@@ -0,0 +1,81 @@
+-- name: GetContest :one
+SELECT * FROM contests WHERE scoreboard_id = $1;
+
+-- name: UpsertContest :one
+INSERT INTO contests (
+    scoreboard_id, mode, starts_at, penalty_minutes, freeze_at, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5,
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+)
+ON CONFLICT (scoreboard_id) DO UPDATE SET
+    mode = EXCLUDED.mode,
+    starts_at = EXCLUDED.starts_at,
+    penalty_minutes = EXCLUDED.penalty_minutes,
+    freeze_at = EXCLUDED.freeze_at,
+    -- A revealed contest only freezes again when its freeze time moves.
+    unfrozen_at = CASE
+        WHEN contests.freeze_at IS DISTINCT FROM EXCLUDED.freeze_at THEN NULL
+        ELSE contests.unfrozen_at
+    END,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: Unfreeze :one
+UPDATE contests SET
+    unfrozen_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE scoreboard_id = $1
+RETURNING *;
+
+-- name: ListProblems :many
+SELECT * FROM contest_problems
+WHERE scoreboard_id = $1
+ORDER BY label;
+
+-- name: CreateProblem :one
+INSERT INTO contest_problems (
+    id, scoreboard_id, label, name, points, scoring, minimum_points, decay, created_at
+) VALUES (
+    gen_random_uuid(),
+    $1, $2, $3, $4, $5, $6, $7,
+    CURRENT_TIMESTAMP
+)
+RETURNING *;
+
+-- name: DeleteProblem :exec
+DELETE FROM contest_problems WHERE id = $1 AND scoreboard_id = $2;
+
+-- name: ListTeams :many
+SELECT * FROM contest_teams
+WHERE scoreboard_id = $1
+ORDER BY name;
+
+-- name: CreateTeam :one
+INSERT INTO contest_teams (
+    id, scoreboard_id, name, created_at
+) VALUES (
+    gen_random_uuid(),
+    $1, $2,
+    CURRENT_TIMESTAMP
+)
+RETURNING *;
+
+-- name: DeleteTeam :exec
+DELETE FROM contest_teams WHERE id = $1 AND scoreboard_id = $2;
+
+-- name: ListAttempts :many
+SELECT * FROM contest_attempts
+WHERE scoreboard_id = $1
+ORDER BY submitted_at, created_at;
+
+-- name: CreateAttempt :one
+INSERT INTO contest_attempts (
+    id, scoreboard_id, team_id, problem_id, accepted, submitted_at, created_at
+) VALUES (
+    gen_random_uuid(),
+    $1, $2, $3, $4, $5,
+    CURRENT_TIMESTAMP
+)
+RETURNING *;
@@ -0,0 +1,166 @@
+package contest
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Mode string
+
+const (
+	// ModeICPC ranks by problems solved, then by total penalty time.
+	ModeICPC Mode = "icpc"
+	// ModeCTF ranks by the points of the solved problems, then by who got
+	// there first.
+	ModeCTF Mode = "ctf"
+)
+
+type ProblemResult struct {
+	ProblemID uuid.UUID
+	Label     string
+	// Attempts counts the submissions up to and including the first accepted one.
+	Attempts int
+	Solved   bool
+	// SolvedAtMinute is the contest minute of the first accepted submission.
+	SolvedAtMinute int
+	Penalty        int
+	Points         int
+	FirstToSolve   bool
+	// Pending counts the submissions hidden by the freeze.
+	Pending int
+}
+
+type TeamStanding struct {
+	Rank      int
+	TeamID    uuid.UUID
+	Team      string
+	Solved    int
+	Penalty   int
+	Score     int
+	LastSolve time.Time
+	Problems  []ProblemResult
+}
+
+// FrozenAt reports whether public standings are frozen at the given time: the
+// freeze time has passed and the final results have not been revealed yet.
+func (c Contest) FrozenAt(now time.Time) bool {
+	return c.FreezeAt.Valid && !now.Before(c.FreezeAt.Time) && !c.UnfrozenAt.Valid
+}
+
+// Rank computes the contest scoreboard from the attempts submitted so far.
+// Submissions made after a team solved a problem do not count towards it.
+// Every solver of a problem gets its current value, so a new solve also
+// lowers the score of the teams that solved a dynamic problem before.
+// When frozen, submissions from the freeze time on are only counted as
+// pending, so the standings look as they did when the board froze.
+func Rank(contest Contest, problems []ContestProblem, teams []ContestTeam, attempts []ContestAttempt, frozen bool) []TeamStanding {
+	problemIndex := make(map[uuid.UUID]int, len(problems))
+	for index, problem := range problems {
+		problemIndex[problem.ID] = index
+	}
+
+	standings := make([]TeamStanding, len(teams))
+	teamIndex := make(map[uuid.UUID]int, len(teams))
+	for index, team := range teams {
+		teamIndex[team.ID] = index
+		standings[index] = TeamStanding{
+			TeamID:   team.ID,
+			Team:     team.Name,
+			Problems: make([]ProblemResult, len(problems)),
+		}
+		for problem, detail := range problems {
+			standings[index].Problems[problem] = ProblemResult{ProblemID: detail.ID, Label: detail.Label}
+		}
+	}
+
+	ordered := make([]ContestAttempt, len(attempts))
+	copy(ordered, attempts)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].SubmittedAt.Time.Before(ordered[j].SubmittedAt.Time)
+	})
+
+	firstSolved := make(map[uuid.UUID]bool, len(problems))
+	for _, attempt := range ordered {
+		team, ok := teamIndex[attempt.TeamID]
+		if !ok {
+			continue
+		}
+		problem, ok := problemIndex[attempt.ProblemID]
+		if !ok {
+			continue
+		}
+		standing := &standings[team]
+		result := &standing.Problems[problem]
+		if result.Solved {
+			continue
+		}
+		if frozen && !attempt.SubmittedAt.Time.Before(contest.FreezeAt.Time) {
+			result.Pending++
+			continue
+		}
+		result.Attempts++
+		if !attempt.Accepted {
+			continue
+		}
+
+		minute := max(int(attempt.SubmittedAt.Time.Sub(contest.StartsAt.Time)/time.Minute), 0)
+		result.Solved = true
+		result.SolvedAtMinute = minute
+		result.Penalty = minute + (result.Attempts-1)*int(contest.PenaltyMinutes)
+		if !firstSolved[attempt.ProblemID] {
+			firstSolved[attempt.ProblemID] = true
+			result.FirstToSolve = true
+		}
+
+		standing.Solved++
+		standing.Penalty += result.Penalty
+		standing.LastSolve = attempt.SubmittedAt.Time
+	}
+
+	// Problem values depend on how many teams solved them, so points are
+	// handed out once every solve is known.
+	values := Values(problems, standings)
+	for index := range standings {
+		standing := &standings[index]
+		for problem := range standing.Problems {
+			result := &standing.Problems[problem]
+			if result.Solved {
+				result.Points = values[problem]
+				standing.Score += result.Points
+			}
+		}
+	}
+
+	compare := func(a, b TeamStanding) int {
+		if Mode(contest.Mode) == ModeCTF {
+			if a.Score != b.Score {
+				return b.Score - a.Score
+			}
+		} else {
+			if a.Solved != b.Solved {
+				return b.Solved - a.Solved
+			}
+			if a.Penalty != b.Penalty {
+				return a.Penalty - b.Penalty
+			}
+		}
+		return a.LastSolve.Compare(b.LastSolve)
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		if order := compare(standings[i], standings[j]); order != 0 {
+			return order < 0
+		}
+		return standings[i].Team < standings[j].Team
+	})
+
+	for index := range standings {
+		if index > 0 && compare(standings[index-1], standings[index]) == 0 {
+			standings[index].Rank = standings[index-1].Rank
+			continue
+		}
+		standings[index].Rank = index + 1
+	}
+	return standings
+}
@@ -0,0 +1,140 @@
+package contest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var start = time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+
+func attempt(team ContestTeam, problem ContestProblem, minute int, accepted bool) ContestAttempt {
+	return ContestAttempt{
+		TeamID:      team.ID,
+		ProblemID:   problem.ID,
+		Accepted:    accepted,
+		SubmittedAt: pgtype.Timestamp{Time: start.Add(time.Duration(minute) * time.Minute), Valid: true},
+	}
+}
+
+func TestRank(t *testing.T) {
+	problemA := ContestProblem{ID: uuid.New(), Label: "A", Points: 100}
+	problemB := ContestProblem{ID: uuid.New(), Label: "B", Points: 300}
+	problems := []ContestProblem{problemA, problemB}
+	alpha := ContestTeam{ID: uuid.New(), Name: "Alpha"}
+	beta := ContestTeam{ID: uuid.New(), Name: "Beta"}
+	gamma := ContestTeam{ID: uuid.New(), Name: "Gamma"}
+	teams := []ContestTeam{alpha, beta, gamma}
+
+	attempts := []ContestAttempt{
+		attempt(alpha, problemA, 10, false),
+		attempt(alpha, problemA, 15, true),
+		attempt(alpha, problemA, 20, false),
+		attempt(beta, problemA, 12, true),
+		attempt(beta, problemB, 50, true),
+		attempt(gamma, problemB, 30, true),
+		attempt(alpha, problemB, 40, true),
+	}
+
+	tests := []struct {
+		name      string
+		mode      Mode
+		wantTeams []string
+		wantRanks []int
+	}{
+		{
+			// Beta: 2 solved, 12 + 50 = 62. Alpha: 2 solved, 15 + 20 + 40 = 75.
+			name:      "ICPC ranks by solved then penalty",
+			mode:      ModeICPC,
+			wantTeams: []string{"Beta", "Alpha", "Gamma"},
+			wantRanks: []int{1, 2, 3},
+		},
+		{
+			// Alpha and Beta both have 400 points, Alpha got there first.
+			name:      "CTF ranks by points then last solve",
+			mode:      ModeCTF,
+			wantTeams: []string{"Alpha", "Beta", "Gamma"},
+			wantRanks: []int{1, 2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contest := Contest{
+				Mode:           string(tt.mode),
+				StartsAt:       pgtype.Timestamp{Time: start, Valid: true},
+				PenaltyMinutes: 20,
+			}
+			standings := Rank(contest, problems, teams, attempts, false)
+			for index, standing := range standings {
+				if standing.Team != tt.wantTeams[index] {
+					t.Errorf("Rank()[%d].Team = %s, want %s", index, standing.Team, tt.wantTeams[index])
+				}
+				if standing.Rank != tt.wantRanks[index] {
+					t.Errorf("Rank()[%d].Rank = %d, want %d", index, standing.Rank, tt.wantRanks[index])
+				}
+			}
+		})
+	}
+
+	contest := Contest{Mode: string(ModeICPC), StartsAt: pgtype.Timestamp{Time: start, Valid: true}, PenaltyMinutes: 20}
+	for _, standing := range Rank(contest, problems, teams, attempts, false) {
+		if standing.Team != "Alpha" {
+			continue
+		}
+		result := standing.Problems[0]
+		if result.Attempts != 2 || result.Penalty != 35 || result.FirstToSolve {
+			t.Errorf("Alpha on A = %+v, want 2 attempts, 35 penalty and not first to solve", result)
+		}
+		if !standing.Problems[1].Solved || standing.Problems[1].FirstToSolve {
+			t.Errorf("Alpha on B = %+v, want solved after Gamma", standing.Problems[1])
+		}
+	}
+}
+
+func TestRankFrozen(t *testing.T) {
+	problemA := ContestProblem{ID: uuid.New(), Label: "A", Points: 1}
+	problemB := ContestProblem{ID: uuid.New(), Label: "B", Points: 1}
+	alpha := ContestTeam{ID: uuid.New(), Name: "Alpha"}
+	beta := ContestTeam{ID: uuid.New(), Name: "Beta"}
+
+	attempts := []ContestAttempt{
+		attempt(alpha, problemA, 30, true),
+		attempt(beta, problemA, 10, true),
+		attempt(alpha, problemB, 40, false),
+		attempt(alpha, problemB, 45, true),
+	}
+	contest := Contest{
+		Mode:           string(ModeICPC),
+		StartsAt:       pgtype.Timestamp{Time: start, Valid: true},
+		PenaltyMinutes: 20,
+		FreezeAt:       pgtype.Timestamp{Time: start.Add(35 * time.Minute), Valid: true},
+	}
+
+	if !contest.FrozenAt(start.Add(time.Hour)) {
+		t.Fatalf("FrozenAt() = false after the freeze time, want true")
+	}
+	if contest.FrozenAt(start) {
+		t.Errorf("FrozenAt() = true before the freeze time, want false")
+	}
+
+	frozen := Rank(contest, []ContestProblem{problemA, problemB}, []ContestTeam{alpha, beta}, attempts, true)
+	if frozen[0].Team != "Beta" {
+		t.Errorf("frozen Rank()[0].Team = %s, want Beta", frozen[0].Team)
+	}
+	if pending := frozen[1].Problems[1].Pending; pending != 2 {
+		t.Errorf("frozen Rank() pending attempts for Alpha on B = %d, want 2", pending)
+	}
+
+	live := Rank(contest, []ContestProblem{problemA, problemB}, []ContestTeam{alpha, beta}, attempts, false)
+	if live[0].Team != "Alpha" || live[0].Solved != 2 {
+		t.Errorf("live Rank()[0] = %s with %d solved, want Alpha with 2", live[0].Team, live[0].Solved)
+	}
+
+	contest.UnfrozenAt = pgtype.Timestamp{Time: start.Add(2 * time.Hour), Valid: true}
+	if contest.FrozenAt(start.Add(3 * time.Hour)) {
+		t.Errorf("FrozenAt() = true after unfreezing, want false")
+	}
+}
@@ -0,0 +1,45 @@
+CREATE TABLE contests (
+    scoreboard_id UUID PRIMARY KEY,
+    mode VARCHAR(16) NOT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    penalty_minutes INT NOT NULL DEFAULT 20,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    freeze_at TIMESTAMP,
+    unfrozen_at TIMESTAMP
+);
+
+CREATE TABLE contest_problems (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scoreboard_id UUID NOT NULL REFERENCES contests (scoreboard_id) ON DELETE CASCADE,
+    label VARCHAR(16) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    points INT NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    scoring VARCHAR(16) NOT NULL DEFAULT 'static',
+    minimum_points INT NOT NULL DEFAULT 0,
+    decay INT NOT NULL DEFAULT 0,
+    UNIQUE (scoreboard_id, label),
+    UNIQUE (scoreboard_id, id)
+);
+
+CREATE TABLE contest_teams (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scoreboard_id UUID NOT NULL REFERENCES contests (scoreboard_id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (scoreboard_id, name),
+    UNIQUE (scoreboard_id, id)
+);
+
+CREATE TABLE contest_attempts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scoreboard_id UUID NOT NULL,
+    team_id UUID NOT NULL,
+    problem_id UUID NOT NULL,
+    accepted BOOLEAN NOT NULL,
+    submitted_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (scoreboard_id, team_id) REFERENCES contest_teams (scoreboard_id, id) ON DELETE CASCADE,
+    FOREIGN KEY (scoreboard_id, problem_id) REFERENCES contest_problems (scoreboard_id, id) ON DELETE CASCADE
+);
@@ -0,0 +1,68 @@
+package contest
+
+import (
+	"math"
+)
+
+type Scoring string
+
+const (
+	// ScoringStatic always awards the problem's points.
+	ScoringStatic Scoring = "static"
+	// ScoringLinear takes decay points off for every solve after the first.
+	ScoringLinear Scoring = "linear"
+	// ScoringLogarithmic drops quickly over the first solves and then levels
+	// out, reaching the minimum after decay solves.
+	ScoringLogarithmic Scoring = "logarithmic"
+	// ScoringParabolic is the CTFd dynamic value: it drops slowly at first and
+	// reaches the minimum after decay solves.
+	ScoringParabolic Scoring = "parabolic"
+)
+
+// Value returns what the problem is worth to every team that solved it, given
+// the number of teams that did. Points is the initial value, and the result
+// never drops below MinimumPoints.
+func (p ContestProblem) Value(solves int) int {
+	initial := float64(p.Points)
+	minimum := float64(min(p.MinimumPoints, p.Points))
+	decay := float64(p.Decay)
+	// The first solve is worth the full initial value.
+	n := float64(max(solves-1, 0))
+
+	var value float64
+	switch Scoring(p.Scoring) {
+	case ScoringLinear:
+		value = initial - decay*n
+	case ScoringLogarithmic:
+		if decay <= 0 {
+			return int(p.Points)
+		}
+		value = initial - (initial-minimum)*math.Log1p(n)/math.Log1p(decay)
+	case ScoringParabolic:
+		if decay <= 0 {
+			return int(p.Points)
+		}
+		value = (minimum-initial)/(decay*decay)*n*n + initial
+	default:
+		return int(p.Points)
+	}
+	return int(math.Max(math.Ceil(value), minimum))
+}
+
+// Values returns the current value of each problem from the solves in the
+// given standings.
+func Values(problems []ContestProblem, standings []TeamStanding) []int {
+	solves := make([]int, len(problems))
+	for _, standing := range standings {
+		for problem, result := range standing.Problems {
+			if result.Solved {
+				solves[problem]++
+			}
+		}
+	}
+	values := make([]int, len(problems))
+	for problem, detail := range problems {
+		values[problem] = detail.Value(solves[problem])
+	}
+	return values
+}
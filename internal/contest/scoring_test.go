@@ -0,0 +1,59 @@
+package contest
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		problem ContestProblem
+		solves  int
+		want    int
+	}{
+		{name: "Static", problem: ContestProblem{Points: 100, Scoring: "static"}, solves: 10, want: 100},
+		{name: "Unsolved", problem: ContestProblem{Points: 500, MinimumPoints: 100, Decay: 10, Scoring: "parabolic"}, solves: 0, want: 500},
+		{name: "First solve keeps the initial value", problem: ContestProblem{Points: 500, MinimumPoints: 100, Decay: 50, Scoring: "linear"}, solves: 1, want: 500},
+		{name: "Linear", problem: ContestProblem{Points: 500, MinimumPoints: 100, Decay: 50, Scoring: "linear"}, solves: 4, want: 350},
+		{name: "Linear floors at the minimum", problem: ContestProblem{Points: 500, MinimumPoints: 100, Decay: 50, Scoring: "linear"}, solves: 20, want: 100},
+		{name: "Logarithmic", problem: ContestProblem{Points: 500, MinimumPoints: 100, Decay: 15, Scoring: "logarithmic"}, solves: 4, want: 300},
+		{name: "Logarithmic reaches the minimum", problem: ContestProblem{Points: 500, MinimumPoints: 100, Decay: 15, Scoring: "logarithmic"}, solves: 16, want: 100},
+		{name: "Parabolic", problem: ContestProblem{Points: 500, MinimumPoints: 100, Decay: 10, Scoring: "parabolic"}, solves: 6, want: 400},
+		{name: "Parabolic floors at the minimum", problem: ContestProblem{Points: 500, MinimumPoints: 100, Decay: 10, Scoring: "parabolic"}, solves: 30, want: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.problem.Value(tt.solves); got != tt.want {
+				t.Errorf("Value(%d) = %d, want %d", tt.solves, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankDynamicScoring(t *testing.T) {
+	problem := ContestProblem{ID: uuid.New(), Label: "A", Points: 500, MinimumPoints: 100, Decay: 100, Scoring: "linear"}
+	alpha := ContestTeam{ID: uuid.New(), Name: "Alpha"}
+	beta := ContestTeam{ID: uuid.New(), Name: "Beta"}
+	contest := Contest{Mode: string(ModeCTF), StartsAt: pgtype.Timestamp{Time: start, Valid: true}}
+
+	attempts := []ContestAttempt{attempt(alpha, problem, 10, true)}
+	standings := Rank(contest, []ContestProblem{problem}, []ContestTeam{alpha, beta}, attempts, false)
+	if standings[0].Score != 500 {
+		t.Fatalf("Rank() with one solve gave Alpha %d points, want 500", standings[0].Score)
+	}
+
+	attempts = append(attempts, attempt(beta, problem, 20, true))
+	standings = Rank(contest, []ContestProblem{problem}, []ContestTeam{alpha, beta}, attempts, false)
+	for _, standing := range standings {
+		if standing.Score != 400 {
+			t.Errorf("Rank() with two solves gave %s %d points, want 400", standing.Team, standing.Score)
+		}
+	}
+	if standings[0].Team != "Alpha" {
+		t.Errorf("Rank()[0] = %s, want Alpha who solved first", standings[0].Team)
+	}
+}
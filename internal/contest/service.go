@@ -0,0 +1,211 @@
+package contest
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrContestNotFound = errors.New("contest not found")
+	ErrUnknownEntity   = errors.New("team or problem does not belong to this contest")
+	ErrDuplicate       = errors.New("label or name is already taken in this contest")
+)
+
+// Postgres error codes raised by the contest constraints.
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+)
+
+type Service struct {
+	queries *Queries
+	logger  *zap.Logger
+	tracer  trace.Tracer
+}
+
+func NewService(logger *zap.Logger, db DBTX) *Service {
+	return &Service{
+		queries: New(db),
+		logger:  logger,
+		tracer:  otel.Tracer("contest/service"),
+	}
+}
+
+func (s *Service) Get(ctx context.Context, scoreboardID uuid.UUID) (Contest, error) {
+	traceCtx, span := s.tracer.Start(ctx, "Get")
+	defer span.End()
+	contest, err := s.queries.GetContest(traceCtx, scoreboardID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Contest{}, ErrContestNotFound
+		}
+		return Contest{}, err
+	}
+	return contest, nil
+}
+
+// Configure turns the scoreboard into a contest board, or updates its settings.
+func (s *Service) Configure(ctx context.Context, arg UpsertContestParams) (Contest, error) {
+	traceCtx, span := s.tracer.Start(ctx, "Configure")
+	defer span.End()
+	contest, err := s.queries.UpsertContest(traceCtx, arg)
+	if err != nil {
+		return Contest{}, err
+	}
+	return contest, nil
+}
+
+func (s *Service) ListProblems(ctx context.Context, scoreboardID uuid.UUID) ([]ContestProblem, error) {
+	traceCtx, span := s.tracer.Start(ctx, "ListProblems")
+	defer span.End()
+	problems, err := s.queries.ListProblems(traceCtx, scoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	return problems, nil
+}
+
+func (s *Service) CreateProblem(ctx context.Context, arg CreateProblemParams) (ContestProblem, error) {
+	traceCtx, span := s.tracer.Start(ctx, "CreateProblem")
+	defer span.End()
+	problem, err := s.queries.CreateProblem(traceCtx, arg)
+	if err != nil {
+		return ContestProblem{}, translate(err, ErrContestNotFound)
+	}
+	return problem, nil
+}
+
+func (s *Service) DeleteProblem(ctx context.Context, scoreboardID, problemID uuid.UUID) error {
+	traceCtx, span := s.tracer.Start(ctx, "DeleteProblem")
+	defer span.End()
+	return s.queries.DeleteProblem(traceCtx, DeleteProblemParams{
+		ID:           problemID,
+		ScoreboardID: scoreboardID,
+	})
+}
+
+func (s *Service) ListTeams(ctx context.Context, scoreboardID uuid.UUID) ([]ContestTeam, error) {
+	traceCtx, span := s.tracer.Start(ctx, "ListTeams")
+	defer span.End()
+	teams, err := s.queries.ListTeams(traceCtx, scoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+func (s *Service) CreateTeam(ctx context.Context, arg CreateTeamParams) (ContestTeam, error) {
+	traceCtx, span := s.tracer.Start(ctx, "CreateTeam")
+	defer span.End()
+	team, err := s.queries.CreateTeam(traceCtx, arg)
+	if err != nil {
+		return ContestTeam{}, translate(err, ErrContestNotFound)
+	}
+	return team, nil
+}
+
+func (s *Service) DeleteTeam(ctx context.Context, scoreboardID, teamID uuid.UUID) error {
+	traceCtx, span := s.tracer.Start(ctx, "DeleteTeam")
+	defer span.End()
+	return s.queries.DeleteTeam(traceCtx, DeleteTeamParams{
+		ID:           teamID,
+		ScoreboardID: scoreboardID,
+	})
+}
+
+// RecordAttempt stores a judged submission. The submission time defaults to
+// now when the judge did not provide one.
+func (s *Service) RecordAttempt(ctx context.Context, arg CreateAttemptParams) (ContestAttempt, error) {
+	traceCtx, span := s.tracer.Start(ctx, "RecordAttempt")
+	defer span.End()
+	if !arg.SubmittedAt.Valid {
+		arg.SubmittedAt = pgtype.Timestamp{Time: time.Now().UTC(), Valid: true}
+	}
+	attempt, err := s.queries.CreateAttempt(traceCtx, arg)
+	if err != nil {
+		return ContestAttempt{}, translate(err, ErrUnknownEntity)
+	}
+	return attempt, nil
+}
+
+// Board is a ranked view of a contest.
+type Board struct {
+	Contest  Contest
+	Problems []ContestProblem
+	Teams    []TeamStanding
+	// Values holds the current value of each problem.
+	Values []int
+	// Frozen is set when the standings are shown as of the freeze time.
+	Frozen bool
+}
+
+// Standings ranks the teams of the contest from every recorded attempt.
+// While the contest is frozen only privileged viewers see the live state.
+func (s *Service) Standings(ctx context.Context, scoreboardID uuid.UUID, privileged bool) (Board, error) {
+	traceCtx, span := s.tracer.Start(ctx, "Standings")
+	defer span.End()
+
+	contest, err := s.Get(traceCtx, scoreboardID)
+	if err != nil {
+		return Board{}, err
+	}
+	problems, err := s.queries.ListProblems(traceCtx, scoreboardID)
+	if err != nil {
+		return Board{}, err
+	}
+	teams, err := s.queries.ListTeams(traceCtx, scoreboardID)
+	if err != nil {
+		return Board{}, err
+	}
+	attempts, err := s.queries.ListAttempts(traceCtx, scoreboardID)
+	if err != nil {
+		return Board{}, err
+	}
+	frozen := !privileged && contest.FrozenAt(time.Now().UTC())
+	standings := Rank(contest, problems, teams, attempts, frozen)
+	return Board{
+		Contest:  contest,
+		Problems: problems,
+		Teams:    standings,
+		Values:   Values(problems, standings),
+		Frozen:   frozen,
+	}, nil
+}
+
+// Unfreeze reveals the final results to everyone.
+func (s *Service) Unfreeze(ctx context.Context, scoreboardID uuid.UUID) (Contest, error) {
+	traceCtx, span := s.tracer.Start(ctx, "Unfreeze")
+	defer span.End()
+	contest, err := s.queries.Unfreeze(traceCtx, scoreboardID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Contest{}, ErrContestNotFound
+		}
+		return Contest{}, err
+	}
+	return contest, nil
+}
+
+// translate maps constraint violations onto the errors of this package, with
+// missing references reported as the given error.
+func translate(err error, missing error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case foreignKeyViolation:
+			return missing
+		case uniqueViolation:
+			return ErrDuplicate
+		}
+	}
+	return err
+}
@@ -15,4 +15,54 @@ CREATE TABLE IF NOT EXISTS scoreboards (
 --     score int DEFAULT 0,
 --     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
 --     updated_at TIMESTAMP NOT NULL DEFAULT NOW()
--- );
\ No newline at end of file
+-- );
+
+CREATE TABLE IF NOT EXISTS scoreboard_entries (
+    scoreboard_id UUID NOT NULL REFERENCES scoreboards (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    score BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (scoreboard_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS scoreboard_settings (
+    scoreboard_id UUID PRIMARY KEY REFERENCES scoreboards (id) ON DELETE CASCADE,
+    decay VARCHAR(16) NOT NULL DEFAULT 'none',
+    half_life_hours DOUBLE PRECISION NOT NULL DEFAULT 0,
+    decay_per_day DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    submission_rule VARCHAR(16) NOT NULL DEFAULT 'keep_best'
+);
+
+CREATE TABLE IF NOT EXISTS scoreboard_handicaps (
+    scoreboard_id UUID NOT NULL REFERENCES scoreboards (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    class VARCHAR(64),
+    handicap DOUBLE PRECISION NOT NULL DEFAULT 0,
+    multiplier DOUBLE PRECISION NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (scoreboard_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS scoreboard_events (
+    id BIGSERIAL PRIMARY KEY,
+    scoreboard_id UUID NOT NULL,
+    user_id UUID,
+    type VARCHAR(32) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS scoreboard_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    instance UUID NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    dispatched_at TIMESTAMP
+);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS scoreboard_settings (
+    scoreboard_id UUID PRIMARY KEY REFERENCES scoreboards (id) ON DELETE CASCADE,
+    decay VARCHAR(16) NOT NULL DEFAULT 'none',
+    half_life_hours DOUBLE PRECISION NOT NULL DEFAULT 0,
+    decay_per_day DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS stage_entries;
+DROP TABLE IF EXISTS stages;
+DROP TABLE IF EXISTS stage_settings;
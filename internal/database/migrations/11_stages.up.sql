@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS stage_settings (
+    scoreboard_id UUID PRIMARY KEY REFERENCES scoreboards (id) ON DELETE CASCADE,
+    aggregation VARCHAR(16) NOT NULL DEFAULT 'weighted_sum',
+    count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS stages (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scoreboard_id UUID NOT NULL REFERENCES scoreboards (id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    position INT NOT NULL,
+    weight DOUBLE PRECISION NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (scoreboard_id, position),
+    UNIQUE (scoreboard_id, id)
+);
+
+CREATE TABLE IF NOT EXISTS stage_entries (
+    scoreboard_id UUID NOT NULL,
+    stage_id UUID NOT NULL,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    score BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (stage_id, user_id),
+    FOREIGN KEY (scoreboard_id, stage_id) REFERENCES stages (scoreboard_id, id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS stage_entries_scoreboard_id_idx ON stage_entries (scoreboard_id);
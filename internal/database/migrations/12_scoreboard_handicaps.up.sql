@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS scoreboard_handicaps (
+    scoreboard_id UUID NOT NULL REFERENCES scoreboards (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    class VARCHAR(64),
+    handicap DOUBLE PRECISION NOT NULL DEFAULT 0,
+    multiplier DOUBLE PRECISION NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (scoreboard_id, user_id)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE scoreboard_settings
+    DROP COLUMN IF EXISTS submission_rule;
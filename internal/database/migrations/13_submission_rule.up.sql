@@ -0,0 +1,2 @@
+ALTER TABLE scoreboard_settings
+    ADD COLUMN IF NOT EXISTS submission_rule VARCHAR(16) NOT NULL DEFAULT 'keep_best';
@@ -0,0 +1,15 @@
+-- Changes waiting to be handed to the outbox sinks. Rows are written in the
+-- transaction that makes the change, and instance is the process that made
+-- it, which dispatches them first.
+CREATE TABLE IF NOT EXISTS scoreboard_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    instance UUID NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    dispatched_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS scoreboard_outbox_pending_idx ON scoreboard_outbox (next_attempt_at) WHERE dispatched_at IS NULL;
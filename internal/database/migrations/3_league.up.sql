@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS league_settings (
+    scoreboard_id UUID PRIMARY KEY REFERENCES scoreboards (id) ON DELETE CASCADE,
+    points_win INT NOT NULL DEFAULT 3,
+    points_draw INT NOT NULL DEFAULT 1,
+    points_loss INT NOT NULL DEFAULT 0,
+    tie_breakers TEXT[] NOT NULL DEFAULT '{goal_difference,goals_for,head_to_head}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS fixtures (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scoreboard_id UUID NOT NULL REFERENCES scoreboards (id) ON DELETE CASCADE,
+    home_team VARCHAR(255) NOT NULL,
+    away_team VARCHAR(255) NOT NULL,
+    home_score INT,
+    away_score INT,
+    scheduled_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS fixtures_scoreboard_id_idx ON fixtures (scoreboard_id);
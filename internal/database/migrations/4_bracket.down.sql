@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS bracket_results;
+
+DROP TABLE IF EXISTS bracket_participants;
+
+DROP TABLE IF EXISTS brackets;
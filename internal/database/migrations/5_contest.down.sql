@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS contest_attempts;
+
+DROP TABLE IF EXISTS contest_teams;
+
+DROP TABLE IF EXISTS contest_problems;
+
+DROP TABLE IF EXISTS contests;
@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS contests (
+    scoreboard_id UUID PRIMARY KEY REFERENCES scoreboards (id) ON DELETE CASCADE,
+    mode VARCHAR(16) NOT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    penalty_minutes INT NOT NULL DEFAULT 20,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS contest_problems (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scoreboard_id UUID NOT NULL REFERENCES contests (scoreboard_id) ON DELETE CASCADE,
+    label VARCHAR(16) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    points INT NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (scoreboard_id, label),
+    UNIQUE (scoreboard_id, id)
+);
+
+CREATE TABLE IF NOT EXISTS contest_teams (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scoreboard_id UUID NOT NULL REFERENCES contests (scoreboard_id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (scoreboard_id, name),
+    UNIQUE (scoreboard_id, id)
+);
+
+CREATE TABLE IF NOT EXISTS contest_attempts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scoreboard_id UUID NOT NULL,
+    team_id UUID NOT NULL,
+    problem_id UUID NOT NULL,
+    accepted BOOLEAN NOT NULL,
+    submitted_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (scoreboard_id, team_id) REFERENCES contest_teams (scoreboard_id, id) ON DELETE CASCADE,
+    FOREIGN KEY (scoreboard_id, problem_id) REFERENCES contest_problems (scoreboard_id, id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS contest_attempts_scoreboard_id_idx ON contest_attempts (scoreboard_id, submitted_at);
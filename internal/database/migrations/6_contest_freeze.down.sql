@@ -0,0 +1,3 @@
+ALTER TABLE contests
+    DROP COLUMN IF EXISTS unfrozen_at,
+    DROP COLUMN IF EXISTS freeze_at;
@@ -0,0 +1,3 @@
+ALTER TABLE contests
+    ADD COLUMN IF NOT EXISTS freeze_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS unfrozen_at TIMESTAMP;
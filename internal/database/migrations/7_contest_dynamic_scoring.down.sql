@@ -0,0 +1,4 @@
+ALTER TABLE contest_problems
+    DROP COLUMN IF EXISTS decay,
+    DROP COLUMN IF EXISTS minimum_points,
+    DROP COLUMN IF EXISTS scoring;
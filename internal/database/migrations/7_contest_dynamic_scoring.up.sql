@@ -0,0 +1,4 @@
+ALTER TABLE contest_problems
+    ADD COLUMN IF NOT EXISTS scoring VARCHAR(16) NOT NULL DEFAULT 'static',
+    ADD COLUMN IF NOT EXISTS minimum_points INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS decay INT NOT NULL DEFAULT 0;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS scoreboard_entries (
+    scoreboard_id UUID NOT NULL REFERENCES scoreboards (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    score BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (scoreboard_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS scoreboard_entries_score_idx ON scoreboard_entries (scoreboard_id, score DESC);
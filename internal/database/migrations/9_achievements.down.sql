@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS badges;
+DROP TABLE IF EXISTS achievement_progress;
+DROP TABLE IF EXISTS achievements;
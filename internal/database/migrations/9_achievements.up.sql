@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS achievements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scoreboard_id UUID NOT NULL REFERENCES scoreboards (id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    rule VARCHAR(32) NOT NULL,
+    threshold BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (scoreboard_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS achievement_progress (
+    scoreboard_id UUID NOT NULL REFERENCES scoreboards (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    improvements INT NOT NULL DEFAULT 0,
+    leading_since TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (scoreboard_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS badges (
+    achievement_id UUID NOT NULL REFERENCES achievements (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    awarded_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (achievement_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS badges_user_id_idx ON badges (user_id);
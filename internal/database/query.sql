@@ -25,3 +25,181 @@ RETURNING *;
 -- name: Delete :exec
 DELETE FROM scoreboards
 WHERE id = $1;
+
+-- name: GetEntry :one
+SELECT * FROM scoreboard_entries
+WHERE scoreboard_id = $1 AND user_id = $2;
+
+-- name: Leaderboard :many
+SELECT *, RANK() OVER (ORDER BY score DESC) AS rank
+FROM scoreboard_entries
+WHERE scoreboard_id = $1
+ORDER BY score DESC, updated_at;
+
+-- name: CreateEntry :one
+INSERT INTO scoreboard_entries (
+    scoreboard_id, user_id, score, created_at, updated_at
+) VALUES (
+    $1, $2, $3,
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+) RETURNING *;
+
+-- name: UpdateEntry :one
+UPDATE scoreboard_entries
+SET score = $3, updated_at = CURRENT_TIMESTAMP
+WHERE scoreboard_id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeleteEntry :exec
+DELETE FROM scoreboard_entries
+WHERE scoreboard_id = $1 AND user_id = $2;
+
+-- name: ListEntries :many
+SELECT * FROM scoreboard_entries
+WHERE scoreboard_id = $1;
+
+-- name: GetSettings :one
+SELECT * FROM scoreboard_settings
+WHERE scoreboard_id = $1;
+
+-- name: UpsertSettings :one
+INSERT INTO scoreboard_settings (
+    scoreboard_id, decay, half_life_hours, decay_per_day, submission_rule, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5,
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+)
+ON CONFLICT (scoreboard_id) DO UPDATE SET
+    decay = EXCLUDED.decay,
+    half_life_hours = EXCLUDED.half_life_hours,
+    decay_per_day = EXCLUDED.decay_per_day,
+    submission_rule = EXCLUDED.submission_rule,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ListHandicaps :many
+SELECT * FROM scoreboard_handicaps
+WHERE scoreboard_id = $1;
+
+-- name: UpsertHandicap :one
+INSERT INTO scoreboard_handicaps (
+    scoreboard_id, user_id, class, handicap, multiplier, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5,
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+)
+ON CONFLICT (scoreboard_id, user_id) DO UPDATE SET
+    class = EXCLUDED.class,
+    handicap = EXCLUDED.handicap,
+    multiplier = EXCLUDED.multiplier,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: DeleteHandicap :exec
+DELETE FROM scoreboard_handicaps
+WHERE scoreboard_id = $1 AND user_id = $2;
+
+-- name: LockEntry :one
+SELECT * FROM scoreboard_entries
+WHERE scoreboard_id = $1 AND user_id = $2
+FOR UPDATE;
+
+-- name: SubmitScore :one
+INSERT INTO scoreboard_entries (
+    scoreboard_id, user_id, score, created_at, updated_at
+) VALUES (
+    sqlc.arg(scoreboard_id), sqlc.arg(user_id), sqlc.arg(score),
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+)
+ON CONFLICT (scoreboard_id, user_id) DO UPDATE SET
+    score = CASE sqlc.arg(rule)::TEXT
+        WHEN 'add' THEN scoreboard_entries.score + EXCLUDED.score
+        WHEN 'keep_best' THEN GREATEST(scoreboard_entries.score, EXCLUDED.score)
+        ELSE EXCLUDED.score
+    END,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: AppendEvent :one
+INSERT INTO scoreboard_events (
+    scoreboard_id, user_id, type, payload, created_at
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListEvents :many
+SELECT * FROM scoreboard_events
+WHERE id > $1
+ORDER BY id
+LIMIT $2;
+
+-- name: ListScoreboardEvents :many
+SELECT * FROM scoreboard_events
+WHERE scoreboard_id = $1 AND id > $2
+ORDER BY id
+LIMIT $3;
+
+-- name: LatestEventID :one
+SELECT COALESCE(MAX(id), 0)::BIGINT FROM scoreboard_events;
+
+-- name: NotifyChange :exec
+SELECT pg_notify('scoreboard_changes', sqlc.arg(payload)::TEXT);
+
+-- name: ClearEntries :exec
+DELETE FROM scoreboard_entries;
+
+-- name: RestoreScoreboard :exec
+INSERT INTO scoreboards (
+    id, name, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (id) DO UPDATE SET
+    name = EXCLUDED.name,
+    created_at = EXCLUDED.created_at,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: RestoreEntry :execrows
+INSERT INTO scoreboard_entries (
+    scoreboard_id, user_id, score, created_at, updated_at
+)
+SELECT $1, $2, $3, $4, $5
+WHERE EXISTS (SELECT 1 FROM users WHERE id = $2);
+
+-- name: AppendOutbox :exec
+INSERT INTO scoreboard_outbox (
+    instance, payload, next_attempt_at, created_at
+) VALUES (
+    $1, $2, $3, $3
+);
+
+-- name: ClaimOutbox :many
+UPDATE scoreboard_outbox
+SET next_attempt_at = sqlc.arg(lease_until)
+WHERE id IN (
+    SELECT id FROM scoreboard_outbox
+    WHERE dispatched_at IS NULL
+        AND next_attempt_at <= sqlc.arg(now)
+        AND (instance = sqlc.arg(instance) OR created_at <= sqlc.arg(orphaned_before))
+    ORDER BY id
+    LIMIT sqlc.arg(batch)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkOutboxDispatched :exec
+UPDATE scoreboard_outbox SET dispatched_at = $2 WHERE id = $1;
+
+-- name: RetryOutbox :exec
+UPDATE scoreboard_outbox
+SET attempts = attempts + 1,
+    next_attempt_at = $2,
+    last_error = $3
+WHERE id = $1;
+
+-- name: PruneOutbox :exec
+DELETE FROM scoreboard_outbox WHERE dispatched_at < $1;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS scoreboard_handicaps;
+DROP TABLE IF EXISTS scoreboard_settings;
+DROP TABLE IF EXISTS scoreboard_entries;
+DROP TABLE IF EXISTS scoreboards;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,51 @@
+CREATE TABLE IF NOT EXISTS users (
+    id TEXT PRIMARY KEY,
+    email TEXT NOT NULL UNIQUE,
+    name TEXT,
+    given_name TEXT,
+    family_name TEXT,
+    picture TEXT,
+    email_verified INTEGER NOT NULL DEFAULT 0,
+    locale TEXT,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS scoreboards (
+    id TEXT PRIMARY KEY,
+    name TEXT,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS scoreboard_entries (
+    scoreboard_id TEXT NOT NULL REFERENCES scoreboards (id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    score INTEGER NOT NULL DEFAULT 0,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    PRIMARY KEY (scoreboard_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS scoreboard_entries_score_idx ON scoreboard_entries (scoreboard_id, score DESC);
+
+CREATE TABLE IF NOT EXISTS scoreboard_settings (
+    scoreboard_id TEXT PRIMARY KEY REFERENCES scoreboards (id) ON DELETE CASCADE,
+    decay TEXT NOT NULL DEFAULT 'none',
+    half_life_hours REAL NOT NULL DEFAULT 0,
+    decay_per_day REAL NOT NULL DEFAULT 0,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    submission_rule TEXT NOT NULL DEFAULT 'keep_best'
+);
+
+CREATE TABLE IF NOT EXISTS scoreboard_handicaps (
+    scoreboard_id TEXT NOT NULL REFERENCES scoreboards (id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    class TEXT,
+    handicap REAL NOT NULL DEFAULT 0,
+    multiplier REAL NOT NULL DEFAULT 1,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    PRIMARY KEY (scoreboard_id, user_id)
+);
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS scoreboard_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    scoreboard_id TEXT NOT NULL,
+    user_id TEXT,
+    type TEXT NOT NULL,
+    payload TEXT NOT NULL DEFAULT '{}',
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS scoreboard_events_scoreboard_idx ON scoreboard_events (scoreboard_id, id);
+
+-- Seed the log with the current tables, so a rebuild reproduces them.
+INSERT INTO scoreboard_events (scoreboard_id, type, payload, created_at)
+SELECT id, 'scoreboard_created', json_object('name', name), created_at
+FROM scoreboards
+ORDER BY created_at;
+
+INSERT INTO scoreboard_events (scoreboard_id, type, payload, created_at)
+SELECT id, 'scoreboard_renamed', json_object('name', name), updated_at
+FROM scoreboards
+WHERE updated_at > created_at
+ORDER BY updated_at;
+
+INSERT INTO scoreboard_events (scoreboard_id, user_id, type, payload, created_at)
+SELECT scoreboard_id, user_id, 'entry_created', json_object('score', score), created_at
+FROM scoreboard_entries
+ORDER BY created_at;
+
+INSERT INTO scoreboard_events (scoreboard_id, user_id, type, payload, created_at)
+SELECT scoreboard_id, user_id, 'score_set', json_object('score', score), updated_at
+FROM scoreboard_entries
+WHERE updated_at > created_at
+ORDER BY updated_at;
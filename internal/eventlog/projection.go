@@ -0,0 +1,104 @@
+// Package eventlog replays the scoreboard event log into the scoreboard and
+// entry tables.
+package eventlog
+
+import (
+	"fmt"
+	"sort"
+
+	"scoreboard-api/internal/scoreboard"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Projection is the state of the scoreboard and entry tables folded from the
+// event log.
+type Projection struct {
+	Scoreboards map[uuid.UUID]scoreboard.Scoreboard
+	// Entries holds the entries of every scoreboard by player.
+	Entries map[uuid.UUID]map[uuid.UUID]scoreboard.ScoreboardEntry
+	// Applied counts the events folded into the projection.
+	Applied int
+	// Skipped counts the entries Rebuild did not restore because their
+	// player no longer exists.
+	Skipped int
+}
+
+func NewProjection() *Projection {
+	return &Projection{
+		Scoreboards: make(map[uuid.UUID]scoreboard.Scoreboard),
+		Entries:     make(map[uuid.UUID]map[uuid.UUID]scoreboard.ScoreboardEntry),
+	}
+}
+
+// Apply folds one event into the projection. Events must be applied in the
+// order they were appended. Events about a scoreboard that does not exist at
+// that point are skipped.
+func (p *Projection) Apply(event scoreboard.ScoreboardEvent) error {
+	data, err := event.Data()
+	if err != nil {
+		return err
+	}
+	boardID, userID := event.ScoreboardID, uuid.UUID(event.UserID.Bytes)
+	p.Applied++
+
+	switch scoreboard.EventType(event.Type) {
+	case scoreboard.EventScoreboardCreated:
+		p.Scoreboards[boardID] = scoreboard.Scoreboard{
+			ID:        boardID,
+			Name:      pgtype.Text{String: data.Name, Valid: true},
+			CreatedAt: event.CreatedAt,
+			UpdatedAt: event.CreatedAt,
+		}
+		p.Entries[boardID] = make(map[uuid.UUID]scoreboard.ScoreboardEntry)
+	case scoreboard.EventScoreboardRenamed:
+		board, ok := p.Scoreboards[boardID]
+		if !ok {
+			return nil
+		}
+		board.Name = pgtype.Text{String: data.Name, Valid: true}
+		board.UpdatedAt = event.CreatedAt
+		p.Scoreboards[boardID] = board
+	case scoreboard.EventScoreboardDeleted:
+		delete(p.Scoreboards, boardID)
+		delete(p.Entries, boardID)
+	case scoreboard.EventEntryCreated, scoreboard.EventScoreSet, scoreboard.EventScoreSubmitted:
+		entries, ok := p.Entries[boardID]
+		if !ok {
+			return nil
+		}
+		entry, ok := entries[userID]
+		if !ok {
+			entry = scoreboard.ScoreboardEntry{ScoreboardID: boardID, UserID: userID, CreatedAt: event.CreatedAt}
+		}
+		entry.Score = data.Score
+		entry.UpdatedAt = event.CreatedAt
+		entries[userID] = entry
+	case scoreboard.EventEntryRemoved:
+		if entries, ok := p.Entries[boardID]; ok {
+			delete(entries, userID)
+		}
+	default:
+		return fmt.Errorf("event %d: unknown type %q", event.ID, event.Type)
+	}
+	return nil
+}
+
+// SortedEntries returns the entries of every scoreboard ordered by board and
+// player, so restoring them is deterministic.
+func (p *Projection) SortedEntries() []scoreboard.ScoreboardEntry {
+	var entries []scoreboard.ScoreboardEntry
+	for _, board := range p.Entries {
+		for _, entry := range board {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].ScoreboardID != entries[j].ScoreboardID {
+			return entries[i].ScoreboardID.String() < entries[j].ScoreboardID.String()
+		}
+		return entries[i].UserID.String() < entries[j].UserID.String()
+	})
+	return entries
+}
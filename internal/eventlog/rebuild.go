@@ -0,0 +1,105 @@
+package eventlog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"scoreboard-api/internal/scoreboard"
+
+	"github.com/google/uuid"
+)
+
+// pageSize is the number of events read from the log at a time.
+const pageSize = 1000
+
+// ErrUnlogged is returned by Rebuild when scoreboards exist that the event
+// log knows nothing about and pruning them was not asked for.
+var ErrUnlogged = errors.New("scoreboards missing from the event log")
+
+// Rebuild replays the whole event log and replaces the scoreboard and entry
+// tables with the result, in one transaction.
+//
+// Scoreboards missing from the log would be deleted along with everything
+// that hangs off them, such as leagues, brackets and webhooks, so Rebuild
+// fails with ErrUnlogged unless prune is set. Entries of players whose user
+// has since been deleted are skipped, since deleting a user removes their
+// entries without logging it.
+//
+// Processes serving the API keep their ranking caches, so they should be
+// restarted after a rebuild.
+func Rebuild(ctx context.Context, transactor scoreboard.Transactor, prune bool) (*Projection, error) {
+	projection := NewProjection()
+	err := transactor.InTx(ctx, func(queries scoreboard.Querier) error {
+		projection = NewProjection()
+		if err := replay(ctx, queries, projection); err != nil {
+			return err
+		}
+
+		existing, err := queries.List(ctx)
+		if err != nil {
+			return err
+		}
+		var unlogged []uuid.UUID
+		for _, board := range existing {
+			if _, ok := projection.Scoreboards[board.ID]; !ok {
+				unlogged = append(unlogged, board.ID)
+			}
+		}
+		if len(unlogged) > 0 && !prune {
+			return fmt.Errorf("%w: %d, such as %v", ErrUnlogged, len(unlogged), unlogged[0])
+		}
+		for _, id := range unlogged {
+			if err := queries.Delete(ctx, id); err != nil {
+				return err
+			}
+		}
+		for _, board := range projection.Scoreboards {
+			if err := queries.RestoreScoreboard(ctx, scoreboard.RestoreScoreboardParams{
+				ID:        board.ID,
+				Name:      board.Name,
+				CreatedAt: board.CreatedAt,
+				UpdatedAt: board.UpdatedAt,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if err := queries.ClearEntries(ctx); err != nil {
+			return err
+		}
+		for _, entry := range projection.SortedEntries() {
+			restored, err := queries.RestoreEntry(ctx, scoreboard.RestoreEntryParams(entry))
+			if err != nil {
+				return err
+			}
+			if restored == 0 {
+				projection.Skipped++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return projection, nil
+}
+
+func replay(ctx context.Context, queries scoreboard.Querier, projection *Projection) error {
+	var after int64
+	for {
+		events, err := queries.ListEvents(ctx, scoreboard.ListEventsParams{ID: after, Limit: pageSize})
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := projection.Apply(event); err != nil {
+				return err
+			}
+			after = event.ID
+		}
+		if len(events) < pageSize {
+			return nil
+		}
+	}
+}
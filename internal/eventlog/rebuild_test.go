@@ -0,0 +1,112 @@
+package eventlog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"scoreboard-api/internal/memory"
+	"scoreboard-api/internal/scoreboard"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+func TestRebuild(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New().Scoreboards()
+	service := scoreboard.NewServiceWithQuerier(zap.NewNop(), store, store)
+
+	board, err := service.Create(ctx, pgtype.Text{String: "Arcade", Valid: true})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := service.Update(ctx, scoreboard.UpdateParams{ID: board.ID, Name: pgtype.Text{String: "Pinball", Valid: true}}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	gone, err := service.Create(ctx, pgtype.Text{String: "Darts", Valid: true})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	alice, bob, carol := uuid.New(), uuid.New(), uuid.New()
+	if _, err := service.CreateEntry(ctx, scoreboard.CreateEntryParams{ScoreboardID: board.ID, UserID: alice, Score: 10}); err != nil {
+		t.Fatalf("CreateEntry() error = %v", err)
+	}
+	for _, score := range []int64{30, 20} {
+		if _, err := service.Submit(ctx, scoreboard.SubmitScoreParams{ScoreboardID: board.ID, UserID: bob, Score: score}); err != nil {
+			t.Fatalf("Submit() error = %v", err)
+		}
+	}
+	if _, err := service.CreateEntry(ctx, scoreboard.CreateEntryParams{ScoreboardID: board.ID, UserID: carol, Score: 5}); err != nil {
+		t.Fatalf("CreateEntry() error = %v", err)
+	}
+	if err := service.DeleteEntry(ctx, board.ID, carol); err != nil {
+		t.Fatalf("DeleteEntry() error = %v", err)
+	}
+	if _, err := service.CreateEntry(ctx, scoreboard.CreateEntryParams{ScoreboardID: gone.ID, UserID: alice, Score: 1}); err != nil {
+		t.Fatalf("CreateEntry() error = %v", err)
+	}
+	if err := service.Delete(ctx, gone.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	// Writes that bypass the service are not in the log and must be undone.
+	if _, err := store.UpdateEntry(ctx, scoreboard.UpdateEntryParams{ScoreboardID: board.ID, UserID: alice, Score: 999}); err != nil {
+		t.Fatalf("UpdateEntry() error = %v", err)
+	}
+	stray, err := store.Create(ctx, pgtype.Text{String: "Stray", Valid: true})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if _, err := Rebuild(ctx, store, false); !errors.Is(err, ErrUnlogged) {
+		t.Fatalf("Rebuild() without prune error = %v, want ErrUnlogged", err)
+	}
+	if _, err := store.Get(ctx, stray.ID); err != nil {
+		t.Errorf("Rebuild() without prune deleted a scoreboard: %v", err)
+	}
+
+	projection, err := Rebuild(ctx, store, true)
+	if err != nil {
+		t.Fatalf("Rebuild() error = %v", err)
+	}
+	if projection.Applied != 10 {
+		t.Errorf("Rebuild() applied %d events, want 10", projection.Applied)
+	}
+
+	restored, err := store.Get(ctx, board.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if restored.Name.String != "Pinball" {
+		t.Errorf("Get() name = %q, want Pinball", restored.Name.String)
+	}
+	for _, id := range []uuid.UUID{gone.ID, stray.ID} {
+		if _, err := store.Get(ctx, id); err == nil {
+			t.Errorf("Get(%v) found a scoreboard missing from the log", id)
+		}
+	}
+
+	entries, err := store.ListEntries(ctx, board.ID)
+	if err != nil {
+		t.Fatalf("ListEntries() error = %v", err)
+	}
+	scores := make(map[uuid.UUID]int64)
+	for _, entry := range entries {
+		scores[entry.UserID] = entry.Score
+	}
+	want := map[uuid.UUID]int64{alice: 10, bob: 30}
+	if len(scores) != len(want) || scores[alice] != want[alice] || scores[bob] != want[bob] {
+		t.Errorf("ListEntries() scores = %v, want %v", scores, want)
+	}
+}
+
+func TestProjectionApplyUnknownType(t *testing.T) {
+	projection := NewProjection()
+	err := projection.Apply(scoreboard.ScoreboardEvent{ID: 1, ScoreboardID: uuid.New(), Type: "score_doubled", Payload: []byte(`{}`)})
+	if err == nil {
+		t.Error("Apply() of an unknown event type error = nil")
+	}
+}
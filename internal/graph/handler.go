@@ -0,0 +1,128 @@
+// Package graph serves scoreboards, their leaderboards and the profiles of
+// their players over GraphQL, so a dashboard can read nested data in one
+// round trip. Subscriptions are streamed as Server-Sent Events.
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"scoreboard-api/internal/scoreboard"
+	"scoreboard-api/internal/stream"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/graph-gophers/graphql-go"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxDepth bounds how deeply a query may nest.
+	maxDepth = 10
+	// keepaliveInterval keeps idle subscriptions open through proxies.
+	keepaliveInterval = 15 * time.Second
+)
+
+//go:embed schema.graphql
+var schema string
+
+// Request is the body of a GraphQL request.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+type Handler struct {
+	logger *zap.Logger
+	schema *graphql.Schema
+	users  Users
+}
+
+func NewHandler(v *validator.Validate, logger *zap.Logger, store scoreboard.Store, users Users, broker *stream.Broker) Handler {
+	resolver := &Resolver{validator: v, logger: logger, store: store, broker: broker}
+	return Handler{
+		logger: logger,
+		schema: graphql.MustParseSchema(schema, resolver, graphql.MaxDepth(maxDepth)),
+		users:  users,
+	}
+}
+
+// QueryHandler executes a GraphQL operation. Clients accepting
+// text/event-stream may also run subscriptions: each result is sent as a
+// "next" event, followed by a "complete" event when the subscription ends.
+func (h Handler) QueryHandler(w http.ResponseWriter, r *http.Request) {
+	var request Request
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if request.Query == "" {
+		http.Error(w, "query is required", http.StatusBadRequest)
+		return
+	}
+
+	if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		h.subscribe(w, r, request)
+		return
+	}
+	ctx := withLoaders(r.Context(), h.users, true)
+	response := h.schema.Exec(ctx, request.Query, request.OperationName, request.Variables)
+	scoreboard.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+func (h Handler) subscribe(w http.ResponseWriter, r *http.Request, request Request) {
+	ctx, cancel := context.WithCancel(withLoaders(r.Context(), h.users, false))
+	defer cancel()
+	responses, err := h.schema.Subscribe(ctx, request.Query, request.OperationName, request.Variables)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	controller := http.NewResponseController(w)
+	// Subscriptions outlive any write timeout of the server.
+	_ = controller.SetWriteDeadline(time.Time{})
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := controller.Flush(); err != nil {
+		return
+	}
+
+	keepalive := time.NewTicker(keepaliveInterval)
+	defer keepalive.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case response, ok := <-responses:
+			if !ok {
+				_, _ = fmt.Fprint(w, "event: complete\ndata:\n\n")
+				_ = controller.Flush()
+				return
+			}
+			data, err := json.Marshal(response)
+			if err != nil {
+				h.logger.Error("Failed to encode a subscription result", zap.Error(err))
+				return
+			}
+			if _, err := fmt.Fprintf(w, "event: next\ndata: %s\n\n", data); err != nil {
+				return
+			}
+		case <-keepalive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		}
+		if err := controller.Flush(); err != nil {
+			return
+		}
+	}
+}
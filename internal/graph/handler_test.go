@@ -0,0 +1,263 @@
+package graph
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"scoreboard-api/internal"
+	"scoreboard-api/internal/memory"
+	"scoreboard-api/internal/scoreboard"
+	"scoreboard-api/internal/stream"
+	"scoreboard-api/internal/user"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// countingUsers counts the batches read from the users table.
+type countingUsers struct {
+	Users
+	batches atomic.Int32
+}
+
+func (c *countingUsers) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]user.User, error) {
+	c.batches.Add(1)
+	return c.Users.ListByIDs(ctx, ids)
+}
+
+type fixture struct {
+	server  *httptest.Server
+	service *scoreboard.Service
+	users   *countingUsers
+	players []user.User
+}
+
+func setup(t *testing.T) fixture {
+	t.Helper()
+	ctx := context.Background()
+	db := memory.New()
+	store := db.Scoreboards()
+	service := scoreboard.NewServiceWithQuerier(zap.NewNop(), store, store)
+	broker := stream.NewBroker(zap.NewNop(), service)
+	service.Observe(broker)
+	users := &countingUsers{Users: db.Users()}
+
+	profiles := user.NewServiceWithQuerier(zap.NewNop(), db.Users())
+	var players []user.User
+	for _, name := range []string{"Ada", "Grace", "Linus"} {
+		player, err := profiles.CreateWithProfile(ctx, strings.ToLower(name)+"@example.com", name, name, "", "https://example.com/"+name+".png", "en", true)
+		if err != nil {
+			t.Fatalf("CreateWithProfile() error = %v", err)
+		}
+		players = append(players, player)
+	}
+
+	mux := http.NewServeMux()
+	validator := internal.NewValidator()
+	internal.RegisterCustomValidations(validator)
+	mux.HandleFunc("POST /api/graphql", NewHandler(validator, zap.NewNop(), service, users, broker).QueryHandler)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return fixture{server: server, service: service, users: users, players: players}
+}
+
+func (f fixture) query(t *testing.T, query string, variables map[string]any, data any) {
+	t.Helper()
+	result, errs := f.post(t, query, variables)
+	if len(errs) > 0 {
+		t.Fatalf("query errors = %v", errs)
+	}
+	if err := json.Unmarshal(result, data); err != nil {
+		t.Fatalf("decoding data error = %v", err)
+	}
+}
+
+// post runs an operation and returns its data and errors.
+func (f fixture) post(t *testing.T, query string, variables map[string]any) (json.RawMessage, []any) {
+	t.Helper()
+	body, _ := json.Marshal(Request{Query: query, Variables: variables})
+	response, err := http.Post(f.server.URL+"/api/graphql", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST /api/graphql error = %v", err)
+	}
+	defer response.Body.Close()
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []any           `json:"errors"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		t.Fatalf("decoding response error = %v", err)
+	}
+	return result.Data, result.Errors
+}
+
+func TestQueryBatchesUsers(t *testing.T) {
+	ctx := context.Background()
+	f := setup(t)
+	for i, name := range []string{"Arcade", "Pinball"} {
+		board, err := f.service.Create(ctx, pgtype.Text{String: name, Valid: true})
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		for j, player := range f.players {
+			score := int64(10*(j+1) + i)
+			if _, err := f.service.Submit(ctx, scoreboard.SubmitScoreParams{ScoreboardID: board.ID, UserID: player.ID, Score: score}); err != nil {
+				t.Fatalf("Submit() error = %v", err)
+			}
+		}
+	}
+
+	var data struct {
+		Scoreboards []struct {
+			Name        string
+			Leaderboard []struct {
+				Rank  int
+				Entry struct {
+					Score int64
+					User  *struct{ Name, Picture string }
+				}
+			}
+		}
+	}
+	f.query(t, `{ scoreboards { name leaderboard(first: 2) { rank entry { score user { name picture } } } } }`, nil, &data)
+
+	if len(data.Scoreboards) != 2 {
+		t.Fatalf("scoreboards = %+v, want both boards", data.Scoreboards)
+	}
+	for _, board := range data.Scoreboards {
+		top := board.Leaderboard
+		if len(top) != 2 || top[0].Rank != 1 || top[0].Entry.User == nil || top[0].Entry.User.Name != "Linus" {
+			t.Errorf("%s leaderboard = %+v, want Linus first", board.Name, top)
+		}
+	}
+	if got := f.users.batches.Load(); got != 1 {
+		t.Errorf("users read in %d batches, want 1", got)
+	}
+}
+
+func TestSubmitScoreMutation(t *testing.T) {
+	ctx := context.Background()
+	f := setup(t)
+	board, err := f.service.Create(ctx, pgtype.Text{String: "Arcade", Valid: true})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	var data struct {
+		SubmitScore struct{ Score int64 }
+	}
+	f.query(t, `mutation($board: ID!, $user: ID!, $score: Long!) { submitScore(scoreboardId: $board, userId: $user, score: $score) { score } }`,
+		map[string]any{"board": board.ID.String(), "user": f.players[0].ID.String(), "score": "5000000000"}, &data)
+	if data.SubmitScore.Score != 5000000000 {
+		t.Errorf("submitScore score = %d, want 5000000000", data.SubmitScore.Score)
+	}
+}
+
+func TestRankChangedSubscription(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	f := setup(t)
+	board, err := f.service.Create(ctx, pgtype.Text{String: "Arcade", Valid: true})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	followed, rival := f.players[0].ID, f.players[1].ID
+	if _, err := f.service.Submit(ctx, scoreboard.SubmitScoreParams{ScoreboardID: board.ID, UserID: followed, Score: 20}); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	body, _ := json.Marshal(Request{
+		Query:     `subscription($board: ID!, $user: ID) { rankChanged(scoreboardId: $board, userId: $user) { userId standing { rank entry { user { name } } } } }`,
+		Variables: map[string]any{"board": board.ID.String(), "user": followed.String()},
+	})
+	request, _ := http.NewRequestWithContext(ctx, http.MethodPost, f.server.URL+"/api/graphql", bytes.NewReader(body))
+	request.Header.Set("Accept", "text/event-stream")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("POST /api/graphql error = %v", err)
+	}
+	defer response.Body.Close()
+	reader := bufio.NewReader(response.Body)
+	next := func() (string, string) {
+		t.Helper()
+		var event, data string
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				t.Fatalf("reading stream error = %v", err)
+			}
+			line = strings.TrimSuffix(line, "\n")
+			switch {
+			case line == "" && event != "":
+				return event, data
+			case strings.HasPrefix(line, "event: "):
+				event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}
+
+	// The rival joining below the followed player leaves their rank alone,
+	// so only overtaking them is sent.
+	if _, err := f.service.Submit(ctx, scoreboard.SubmitScoreParams{ScoreboardID: board.ID, UserID: rival, Score: 10}); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if _, err := f.service.Submit(ctx, scoreboard.SubmitScoreParams{ScoreboardID: board.ID, UserID: rival, Score: 30}); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	event, data := next()
+	var result struct {
+		Data struct {
+			RankChanged struct {
+				UserID   string
+				Standing struct {
+					Rank  int
+					Entry struct{ User struct{ Name string } }
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		t.Fatalf("decoding %q error = %v", data, err)
+	}
+	change := result.Data.RankChanged
+	if event != "next" || change.UserID != followed.String() || change.Standing.Rank != 2 || change.Standing.Entry.User.Name != "Ada" {
+		t.Fatalf("event %s = %s, want Ada overtaken", event, data)
+	}
+
+	if err := f.service.Delete(ctx, board.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if event, _ := next(); event != "complete" {
+		t.Errorf("event after the board was deleted = %s, want complete", event)
+	}
+}
+
+func TestScoreboardNameValidation(t *testing.T) {
+	f := setup(t)
+	var data struct {
+		CreateScoreboard struct{ ID string } `json:"createScoreboard"`
+	}
+	f.query(t, `mutation { createScoreboard(name: "Speedrun Any") { id } }`, nil, &data)
+
+	for _, operation := range []string{
+		`mutation($name: String!) { createScoreboard(name: $name) { id } }`,
+		`mutation($id: ID!, $name: String!) { renameScoreboard(id: $id, name: $name) { id } }`,
+	} {
+		for _, name := range []string{"", "<img src=x onerror=alert(1)>"} {
+			if _, errs := f.post(t, operation, map[string]any{"id": data.CreateScoreboard.ID, "name": name}); len(errs) == 0 {
+				t.Errorf("%s with name %q succeeded, want a validation error", operation, name)
+			}
+		}
+	}
+}
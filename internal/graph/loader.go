@@ -0,0 +1,68 @@
+package graph
+
+import (
+	"context"
+
+	"scoreboard-api/internal/user"
+
+	"github.com/google/uuid"
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+// Users is the part of the user service the schema uses.
+type Users interface {
+	ListByIDs(ctx context.Context, ids []uuid.UUID) ([]user.User, error)
+}
+
+type loadersKey struct{}
+
+// loaders batch the lookups made while resolving one operation, so a
+// leaderboard of many players reads their profiles in one query.
+type loaders struct {
+	users *dataloader.Loader[uuid.UUID, *user.User]
+}
+
+// withLoaders attaches new loaders to the context of an operation. Queries
+// cache what they load for the rest of the operation. Subscriptions do not,
+// since each of their events must see the current profiles.
+func withLoaders(ctx context.Context, users Users, cache bool) context.Context {
+	var options []dataloader.Option[uuid.UUID, *user.User]
+	if !cache {
+		options = append(options, dataloader.WithCache[uuid.UUID, *user.User](&dataloader.NoCache[uuid.UUID, *user.User]{}))
+	}
+	return context.WithValue(ctx, loadersKey{}, &loaders{
+		users: dataloader.NewBatchedLoader(batchUsers(users), options...),
+	})
+}
+
+// batchUsers reads a batch of users in one query. IDs without a user load
+// as nil.
+func batchUsers(users Users) dataloader.BatchFunc[uuid.UUID, *user.User] {
+	return func(ctx context.Context, ids []uuid.UUID) []*dataloader.Result[*user.User] {
+		results := make([]*dataloader.Result[*user.User], len(ids))
+		found, err := users.ListByIDs(ctx, ids)
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result[*user.User]{Error: err}
+			}
+			return results
+		}
+		byID := make(map[uuid.UUID]*user.User, len(found))
+		for i := range found {
+			byID[found[i].ID] = &found[i]
+		}
+		for i, id := range ids {
+			results[i] = &dataloader.Result[*user.User]{Data: byID[id]}
+		}
+		return results
+	}
+}
+
+// loadUser returns the profile of a user, or nil when there is none.
+func loadUser(ctx context.Context, id uuid.UUID) (*userResolver, error) {
+	found, err := ctx.Value(loadersKey{}).(*loaders).users.Load(ctx, id)()
+	if err != nil || found == nil {
+		return nil, err
+	}
+	return &userResolver{user: *found}, nil
+}
@@ -0,0 +1,475 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"scoreboard-api/internal/scoreboard"
+	"scoreboard-api/internal/stream"
+	"scoreboard-api/internal/user"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// maxFirst caps the standings of one leaderboard field, which bounds the
+// cost of nesting many boards in one query.
+const maxFirst = 100
+
+var errInvalidID = errors.New("invalid UUID format")
+
+// Resolver is the root of the schema.
+type Resolver struct {
+	validator *validator.Validate
+	logger    *zap.Logger
+	store     scoreboard.Store
+	broker    *stream.Broker
+}
+
+func (r *Resolver) Scoreboards(ctx context.Context) ([]*scoreboardResolver, error) {
+	scoreboards, err := r.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*scoreboardResolver, len(scoreboards))
+	for index, board := range scoreboards {
+		resolvers[index] = &scoreboardResolver{root: r, scoreboard: board}
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) Scoreboard(ctx context.Context, args struct{ ID graphql.ID }) (*scoreboardResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	board, err := r.store.Get(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &scoreboardResolver{root: r, scoreboard: board}, nil
+}
+
+func (r *Resolver) User(ctx context.Context, args struct{ ID graphql.ID }) (*userResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	return loadUser(ctx, id)
+}
+
+func (r *Resolver) CreateScoreboard(ctx context.Context, args struct{ Name string }) (*scoreboardResolver, error) {
+	if err := scoreboard.ValidateName(r.validator, args.Name); err != nil {
+		return nil, err
+	}
+	board, err := r.store.Create(ctx, pgtype.Text{String: args.Name, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	return &scoreboardResolver{root: r, scoreboard: board}, nil
+}
+
+func (r *Resolver) RenameScoreboard(ctx context.Context, args struct {
+	ID   graphql.ID
+	Name string
+}) (*scoreboardResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := scoreboard.ValidateName(r.validator, args.Name); err != nil {
+		return nil, err
+	}
+	board, err := r.store.Update(ctx, scoreboard.UpdateParams{ID: id, Name: pgtype.Text{String: args.Name, Valid: true}})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errors.New("scoreboard not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &scoreboardResolver{root: r, scoreboard: board}, nil
+}
+
+func (r *Resolver) DeleteScoreboard(ctx context.Context, args struct{ ID graphql.ID }) (bool, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+	if err := r.store.Delete(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *Resolver) SubmitScore(ctx context.Context, args struct {
+	ScoreboardID graphql.ID
+	UserID       graphql.ID
+	Score        Long
+}) (*entryResolver, error) {
+	scoreboardID, err := parseID(args.ScoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := parseID(args.UserID)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := r.store.Submit(ctx, scoreboard.SubmitScoreParams{
+		ScoreboardID: scoreboardID,
+		UserID:       userID,
+		Score:        int64(args.Score),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entryResolver{entry: entry}, nil
+}
+
+// RankChanged follows the changes of a board through the broker. The broker
+// only signals which player changed; the standing sent is read from the
+// store, so it is never older than the change.
+func (r *Resolver) RankChanged(ctx context.Context, args struct {
+	ScoreboardID graphql.ID
+	UserID       *graphql.ID
+}) (<-chan *rankChangeResolver, error) {
+	scoreboardID, err := parseID(args.ScoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	var followed uuid.UUID
+	if args.UserID != nil {
+		if followed, err = parseID(*args.UserID); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := r.store.Get(ctx, scoreboardID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("scoreboard not found")
+		}
+		return nil, err
+	}
+
+	// Subscribe before reading the followed standing, so no change falls in
+	// between.
+	subscription := r.broker.Subscribe(scoreboardID)
+	var last *scoreboard.Standing
+	if followed != uuid.Nil {
+		if last, err = r.standing(ctx, scoreboardID, followed); err != nil {
+			r.broker.Unsubscribe(subscription)
+			return nil, err
+		}
+	}
+
+	changes := make(chan *rankChangeResolver)
+	go func() {
+		defer close(changes)
+		defer r.broker.Unsubscribe(subscription)
+		for {
+			var update stream.Update
+			select {
+			case <-ctx.Done():
+				return
+			case next, ok := <-subscription.Updates:
+				if !ok {
+					// Dropped for falling behind; the client subscribes again.
+					return
+				}
+				update = next
+			}
+
+			change := &rankChangeResolver{eventID: update.ID, scoreboardID: scoreboardID, userID: update.UserID}
+			switch {
+			case update.Event == stream.EventDeleted:
+				return
+			case update.Event != stream.EventEntry && update.Event != stream.EventRemoved:
+				continue
+			case followed != uuid.Nil:
+				change.userID = followed
+				current, err := r.standing(ctx, scoreboardID, followed)
+				if err != nil {
+					r.logger.Error("Failed to read a followed standing", zap.Error(err))
+					return
+				}
+				if sameRank(last, current) {
+					continue
+				}
+				last, change.standing = current, current
+			case update.Event == stream.EventEntry:
+				current, err := r.standing(ctx, scoreboardID, update.UserID)
+				if err != nil {
+					r.logger.Error("Failed to read a changed standing", zap.Error(err))
+					return
+				}
+				change.standing = current
+			}
+
+			select {
+			case changes <- change:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return changes, nil
+}
+
+// standing reads a player's standing, or nil when they have no entry.
+func (r *Resolver) standing(ctx context.Context, scoreboardID, userID uuid.UUID) (*scoreboard.Standing, error) {
+	standing, err := r.store.Standing(ctx, scoreboardID, userID)
+	if errors.Is(err, scoreboard.ErrEntryNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &standing, nil
+}
+
+func sameRank(a, b *scoreboard.Standing) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Rank == b.Rank && a.Score == b.Score
+}
+
+type scoreboardResolver struct {
+	root       *Resolver
+	scoreboard scoreboard.Scoreboard
+}
+
+func (s *scoreboardResolver) ID() graphql.ID {
+	return graphql.ID(s.scoreboard.ID.String())
+}
+
+func (s *scoreboardResolver) Name() string {
+	return s.scoreboard.Name.String
+}
+
+func (s *scoreboardResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: s.scoreboard.CreatedAt.Time}
+}
+
+func (s *scoreboardResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: s.scoreboard.UpdatedAt.Time}
+}
+
+// Leaderboard ranks the board like the REST leaderboard. Raw ranking only
+// reads the top of the board, without handicaps.
+func (s *scoreboardResolver) Leaderboard(ctx context.Context, args struct {
+	First  int32
+	RankBy string
+}) ([]*standingResolver, error) {
+	if args.First <= 0 || args.First > maxFirst {
+		return nil, fmt.Errorf("first must be between 1 and %d", maxFirst)
+	}
+	limit := int(args.First)
+	var standings []scoreboard.Standing
+	var err error
+	switch args.RankBy {
+	case "RAW":
+		standings, err = s.root.store.Top(ctx, s.scoreboard.ID, limit)
+	case "ADJUSTED":
+		standings, err = s.root.store.Leaderboard(ctx, s.scoreboard.ID)
+		if err == nil {
+			standings = scoreboard.ByAdjusted(standings)
+			if limit < len(standings) {
+				standings = standings[:limit]
+			}
+		}
+	default:
+		return nil, errors.New("rankBy must be RAW or ADJUSTED")
+	}
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*standingResolver, len(standings))
+	for index, standing := range standings {
+		resolvers[index] = &standingResolver{standing: standing}
+	}
+	return resolvers, nil
+}
+
+func (s *scoreboardResolver) Standing(ctx context.Context, args struct{ UserID graphql.ID }) (*standingResolver, error) {
+	userID, err := parseID(args.UserID)
+	if err != nil {
+		return nil, err
+	}
+	standing, err := s.root.standing(ctx, s.scoreboard.ID, userID)
+	if err != nil || standing == nil {
+		return nil, err
+	}
+	return &standingResolver{standing: *standing}, nil
+}
+
+type entryResolver struct {
+	entry scoreboard.ScoreboardEntry
+}
+
+func (e *entryResolver) ScoreboardID() graphql.ID {
+	return graphql.ID(e.entry.ScoreboardID.String())
+}
+
+func (e *entryResolver) UserID() graphql.ID {
+	return graphql.ID(e.entry.UserID.String())
+}
+
+func (e *entryResolver) User(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, e.entry.UserID)
+}
+
+func (e *entryResolver) Score() Long {
+	return Long(e.entry.Score)
+}
+
+func (e *entryResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: e.entry.CreatedAt.Time}
+}
+
+func (e *entryResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: e.entry.UpdatedAt.Time}
+}
+
+type standingResolver struct {
+	standing scoreboard.Standing
+}
+
+func (s *standingResolver) Entry() *entryResolver {
+	return &entryResolver{entry: s.standing.ScoreboardEntry}
+}
+
+func (s *standingResolver) Rank() int32 {
+	return int32(s.standing.Rank)
+}
+
+func (s *standingResolver) EffectiveScore() float64 {
+	return s.standing.Effective
+}
+
+// AdjustedRank is nil for standings read without handicaps.
+func (s *standingResolver) AdjustedRank() *int32 {
+	if s.standing.AdjustedRank == 0 {
+		return nil
+	}
+	rank := int32(s.standing.AdjustedRank)
+	return &rank
+}
+
+func (s *standingResolver) AdjustedScore() *float64 {
+	if s.standing.AdjustedRank == 0 {
+		return nil
+	}
+	return &s.standing.Adjusted
+}
+
+type userResolver struct {
+	user user.User
+}
+
+func (u *userResolver) ID() graphql.ID {
+	return graphql.ID(u.user.ID.String())
+}
+
+func (u *userResolver) Name() *string {
+	return text(u.user.Name)
+}
+
+func (u *userResolver) GivenName() *string {
+	return text(u.user.GivenName)
+}
+
+func (u *userResolver) FamilyName() *string {
+	return text(u.user.FamilyName)
+}
+
+func (u *userResolver) Picture() *string {
+	return text(u.user.Picture)
+}
+
+func (u *userResolver) Locale() *string {
+	return text(u.user.Locale)
+}
+
+type rankChangeResolver struct {
+	eventID      int64
+	scoreboardID uuid.UUID
+	userID       uuid.UUID
+	standing     *scoreboard.Standing
+}
+
+func (c *rankChangeResolver) EventID() Long {
+	return Long(c.eventID)
+}
+
+func (c *rankChangeResolver) ScoreboardID() graphql.ID {
+	return graphql.ID(c.scoreboardID.String())
+}
+
+func (c *rankChangeResolver) UserID() graphql.ID {
+	return graphql.ID(c.userID.String())
+}
+
+func (c *rankChangeResolver) Standing() *standingResolver {
+	if c.standing == nil {
+		return nil
+	}
+	return &standingResolver{standing: *c.standing}
+}
+
+// Long is the 64-bit integer scalar of the schema. It is written as a JSON
+// number and read from numbers or strings.
+type Long int64
+
+func (Long) ImplementsGraphQLType(name string) bool {
+	return name == "Long"
+}
+
+func (l *Long) UnmarshalGraphQL(input any) error {
+	switch input := input.(type) {
+	case int32:
+		*l = Long(input)
+	case int64:
+		*l = Long(input)
+	case float64:
+		if input != float64(int64(input)) {
+			return fmt.Errorf("%v is not an integer", input)
+		}
+		*l = Long(input)
+	case string:
+		value, err := strconv.ParseInt(input, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", input)
+		}
+		*l = Long(value)
+	default:
+		return fmt.Errorf("wrong type for Long: %T", input)
+	}
+	return nil
+}
+
+func (l Long) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(l), 10), nil
+}
+
+func parseID(id graphql.ID) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(string(id))
+	if err != nil {
+		return uuid.Nil, errInvalidID
+	}
+	return parsed, nil
+}
+
+func text(value pgtype.Text) *string {
+	if !value.Valid {
+		return nil
+	}
+	return &value.String
+}
@@ -0,0 +1,330 @@
+package league
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"scoreboard-api/internal/scoreboard"
+)
+
+type Store interface {
+	GetRules(ctx context.Context, scoreboardID uuid.UUID) (Rules, error)
+	UpdateRules(ctx context.Context, scoreboardID uuid.UUID, rules Rules) (Rules, error)
+	ListFixtures(ctx context.Context, scoreboardID uuid.UUID) ([]Fixture, error)
+	CreateFixture(ctx context.Context, arg CreateFixtureParams) (Fixture, error)
+	RecordResult(ctx context.Context, arg UpdateFixtureResultParams) (Fixture, error)
+	DeleteFixture(ctx context.Context, scoreboardID, fixtureID uuid.UUID) error
+	Standings(ctx context.Context, scoreboardID uuid.UUID) ([]Standing, error)
+}
+
+// RulesPayload defines the expected request body for configuring a league.
+type RulesPayload struct {
+	PointsWin   int      `json:"pointsWin"`
+	PointsDraw  int      `json:"pointsDraw"`
+	PointsLoss  int      `json:"pointsLoss"`
+	TieBreakers []string `json:"tieBreakers" validate:"dive,oneof=goal_difference goals_for head_to_head"`
+}
+
+// CreateFixturePayload defines the expected request body for scheduling a fixture.
+// Scores are optional, but when given both must be present.
+type CreateFixturePayload struct {
+	HomeTeam    string     `json:"homeTeam" validate:"required,max=255"`
+	AwayTeam    string     `json:"awayTeam" validate:"required,max=255,nefield=HomeTeam"`
+	HomeScore   *int32     `json:"homeScore" validate:"omitempty,min=0"`
+	AwayScore   *int32     `json:"awayScore" validate:"omitempty,min=0"`
+	ScheduledAt *time.Time `json:"scheduledAt"`
+}
+
+// ResultPayload defines the expected request body for recording a fixture result.
+type ResultPayload struct {
+	HomeScore *int32 `json:"homeScore" validate:"required,min=0"`
+	AwayScore *int32 `json:"awayScore" validate:"required,min=0"`
+}
+
+type RulesResponse struct {
+	PointsWin   int      `json:"pointsWin"`
+	PointsDraw  int      `json:"pointsDraw"`
+	PointsLoss  int      `json:"pointsLoss"`
+	TieBreakers []string `json:"tieBreakers"`
+}
+
+type FixtureResponse struct {
+	ID          string  `json:"id"`
+	HomeTeam    string  `json:"homeTeam"`
+	AwayTeam    string  `json:"awayTeam"`
+	HomeScore   *int32  `json:"homeScore"`
+	AwayScore   *int32  `json:"awayScore"`
+	ScheduledAt *string `json:"scheduledAt"`
+	CreatedAt   string  `json:"createdAt"`
+	UpdatedAt   string  `json:"updatedAt"`
+}
+
+type StandingResponse struct {
+	Position       int    `json:"position"`
+	Team           string `json:"team"`
+	Played         int    `json:"played"`
+	Won            int    `json:"won"`
+	Drawn          int    `json:"drawn"`
+	Lost           int    `json:"lost"`
+	GoalsFor       int    `json:"goalsFor"`
+	GoalsAgainst   int    `json:"goalsAgainst"`
+	GoalDifference int    `json:"goalDifference"`
+	Points         int    `json:"points"`
+}
+
+type Handler struct {
+	validator *validator.Validate
+	tracer    trace.Tracer
+	logger    *zap.Logger
+	store     Store
+}
+
+func NewHandler(v *validator.Validate, logger *zap.Logger, s Store) Handler {
+	return Handler{
+		validator: v,
+		tracer:    otel.Tracer("league/handler"),
+		logger:    logger,
+		store:     s,
+	}
+}
+
+func (h Handler) GetRulesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	rules, err := h.store.GetRules(ctx, scoreboardID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, GenerateRulesResponse(rules))
+}
+
+func (h Handler) UpdateRulesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+
+	var payload RulesPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validator.Struct(payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tieBreakers := make([]TieBreaker, len(payload.TieBreakers))
+	for index, tieBreaker := range payload.TieBreakers {
+		tieBreakers[index] = TieBreaker(tieBreaker)
+	}
+	rules, err := h.store.UpdateRules(ctx, scoreboardID, Rules{
+		PointsWin:   payload.PointsWin,
+		PointsDraw:  payload.PointsDraw,
+		PointsLoss:  payload.PointsLoss,
+		TieBreakers: tieBreakers,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, GenerateRulesResponse(rules))
+}
+
+func (h Handler) ListFixturesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	fixtures, err := h.store.ListFixtures(ctx, scoreboardID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	response := make([]FixtureResponse, len(fixtures))
+	for index, fixture := range fixtures {
+		response[index] = GenerateFixtureResponse(fixture)
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+func (h Handler) CreateFixtureHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+
+	var payload CreateFixturePayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validator.Struct(payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if (payload.HomeScore == nil) != (payload.AwayScore == nil) {
+		http.Error(w, "Both homeScore and awayScore are required to record a result", http.StatusBadRequest)
+		return
+	}
+
+	params := CreateFixtureParams{
+		ScoreboardID: scoreboardID,
+		HomeTeam:     payload.HomeTeam,
+		AwayTeam:     payload.AwayTeam,
+		HomeScore:    toInt4(payload.HomeScore),
+		AwayScore:    toInt4(payload.AwayScore),
+	}
+	if payload.ScheduledAt != nil {
+		params.ScheduledAt = pgtype.Timestamp{Time: payload.ScheduledAt.UTC(), Valid: true}
+	}
+	fixture, err := h.store.CreateFixture(ctx, params)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, GenerateFixtureResponse(fixture))
+}
+
+func (h Handler) RecordResultHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	fixtureID, err := uuid.Parse(r.PathValue("fixtureID"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+
+	var payload ResultPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validator.Struct(payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	fixture, err := h.store.RecordResult(ctx, UpdateFixtureResultParams{
+		ID:           fixtureID,
+		ScoreboardID: scoreboardID,
+		HomeScore:    toInt4(payload.HomeScore),
+		AwayScore:    toInt4(payload.AwayScore),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			http.Error(w, "Fixture not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, GenerateFixtureResponse(fixture))
+}
+
+func (h Handler) DeleteFixtureHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	fixtureID, err := uuid.Parse(r.PathValue("fixtureID"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+
+	err = h.store.DeleteFixture(ctx, scoreboardID, fixtureID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h Handler) StandingsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	standings, err := h.store.Standings(ctx, scoreboardID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	response := make([]StandingResponse, len(standings))
+	for index, standing := range standings {
+		response[index] = StandingResponse(standing)
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+func GenerateRulesResponse(rules Rules) RulesResponse {
+	tieBreakers := make([]string, len(rules.TieBreakers))
+	for index, tieBreaker := range rules.TieBreakers {
+		tieBreakers[index] = string(tieBreaker)
+	}
+	return RulesResponse{
+		PointsWin:   rules.PointsWin,
+		PointsDraw:  rules.PointsDraw,
+		PointsLoss:  rules.PointsLoss,
+		TieBreakers: tieBreakers,
+	}
+}
+
+func GenerateFixtureResponse(fixture Fixture) FixtureResponse {
+	response := FixtureResponse{
+		ID:        fixture.ID.String(),
+		HomeTeam:  fixture.HomeTeam,
+		AwayTeam:  fixture.AwayTeam,
+		CreatedAt: fixture.CreatedAt.Time.Format(time.RFC3339),
+		UpdatedAt: fixture.UpdatedAt.Time.Format(time.RFC3339),
+	}
+	if fixture.HomeScore.Valid {
+		response.HomeScore = &fixture.HomeScore.Int32
+	}
+	if fixture.AwayScore.Valid {
+		response.AwayScore = &fixture.AwayScore.Int32
+	}
+	if fixture.ScheduledAt.Valid {
+		scheduledAt := fixture.ScheduledAt.Time.Format(time.RFC3339)
+		response.ScheduledAt = &scheduledAt
+	}
+	return response
+}
+
+func toInt4(value *int32) pgtype.Int4 {
+	if value == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: *value, Valid: true}
+}
@@ -0,0 +1,48 @@
+-- name: GetSettings :one
+SELECT * FROM league_settings WHERE scoreboard_id = $1;
+
+-- name: UpsertSettings :one
+INSERT INTO league_settings (
+    scoreboard_id, points_win, points_draw, points_loss, tie_breakers, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5,
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+)
+ON CONFLICT (scoreboard_id) DO UPDATE SET
+    points_win = EXCLUDED.points_win,
+    points_draw = EXCLUDED.points_draw,
+    points_loss = EXCLUDED.points_loss,
+    tie_breakers = EXCLUDED.tie_breakers,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ListFixtures :many
+SELECT * FROM fixtures
+WHERE scoreboard_id = $1
+ORDER BY scheduled_at NULLS LAST, created_at;
+
+-- name: GetFixture :one
+SELECT * FROM fixtures WHERE id = $1 AND scoreboard_id = $2;
+
+-- name: CreateFixture :one
+INSERT INTO fixtures (
+    id, scoreboard_id, home_team, away_team, home_score, away_score, scheduled_at, created_at, updated_at
+) VALUES (
+    gen_random_uuid(),
+    $1, $2, $3, $4, $5, $6,
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+)
+RETURNING *;
+
+-- name: UpdateFixtureResult :one
+UPDATE fixtures SET
+    home_score = $3,
+    away_score = $4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND scoreboard_id = $2
+RETURNING *;
+
+-- name: DeleteFixture :exec
+DELETE FROM fixtures WHERE id = $1 AND scoreboard_id = $2;
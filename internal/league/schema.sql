@@ -0,0 +1,21 @@
+CREATE TABLE league_settings (
+    scoreboard_id UUID PRIMARY KEY,
+    points_win INT NOT NULL DEFAULT 3,
+    points_draw INT NOT NULL DEFAULT 1,
+    points_loss INT NOT NULL DEFAULT 0,
+    tie_breakers TEXT[] NOT NULL DEFAULT '{goal_difference,goals_for,head_to_head}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE fixtures (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scoreboard_id UUID NOT NULL,
+    home_team VARCHAR(255) NOT NULL,
+    away_team VARCHAR(255) NOT NULL,
+    home_score INT,
+    away_score INT,
+    scheduled_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,117 @@
+package league
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type Service struct {
+	queries *Queries
+	logger  *zap.Logger
+	tracer  trace.Tracer
+}
+
+func NewService(logger *zap.Logger, db DBTX) *Service {
+	return &Service{
+		queries: New(db),
+		logger:  logger,
+		tracer:  otel.Tracer("league/service"),
+	}
+}
+
+// GetRules returns the point and tie-breaker configuration of a league,
+// falling back to DefaultRules when none has been stored.
+func (s *Service) GetRules(ctx context.Context, scoreboardID uuid.UUID) (Rules, error) {
+	traceCtx, span := s.tracer.Start(ctx, "GetRules")
+	defer span.End()
+	settings, err := s.queries.GetSettings(traceCtx, scoreboardID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return DefaultRules(), nil
+		}
+		return Rules{}, err
+	}
+	return RulesFromSettings(settings), nil
+}
+
+func (s *Service) UpdateRules(ctx context.Context, scoreboardID uuid.UUID, rules Rules) (Rules, error) {
+	traceCtx, span := s.tracer.Start(ctx, "UpdateRules")
+	defer span.End()
+
+	tieBreakers := make([]string, len(rules.TieBreakers))
+	for index, tieBreaker := range rules.TieBreakers {
+		tieBreakers[index] = string(tieBreaker)
+	}
+	settings, err := s.queries.UpsertSettings(traceCtx, UpsertSettingsParams{
+		ScoreboardID: scoreboardID,
+		PointsWin:    int32(rules.PointsWin),
+		PointsDraw:   int32(rules.PointsDraw),
+		PointsLoss:   int32(rules.PointsLoss),
+		TieBreakers:  tieBreakers,
+	})
+	if err != nil {
+		return Rules{}, err
+	}
+	return RulesFromSettings(settings), nil
+}
+
+func (s *Service) ListFixtures(ctx context.Context, scoreboardID uuid.UUID) ([]Fixture, error) {
+	traceCtx, span := s.tracer.Start(ctx, "ListFixtures")
+	defer span.End()
+	fixtures, err := s.queries.ListFixtures(traceCtx, scoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	return fixtures, nil
+}
+
+func (s *Service) CreateFixture(ctx context.Context, arg CreateFixtureParams) (Fixture, error) {
+	traceCtx, span := s.tracer.Start(ctx, "CreateFixture")
+	defer span.End()
+	fixture, err := s.queries.CreateFixture(traceCtx, arg)
+	if err != nil {
+		return Fixture{}, err
+	}
+	return fixture, nil
+}
+
+func (s *Service) RecordResult(ctx context.Context, arg UpdateFixtureResultParams) (Fixture, error) {
+	traceCtx, span := s.tracer.Start(ctx, "RecordResult")
+	defer span.End()
+	fixture, err := s.queries.UpdateFixtureResult(traceCtx, arg)
+	if err != nil {
+		return Fixture{}, err
+	}
+	return fixture, nil
+}
+
+func (s *Service) DeleteFixture(ctx context.Context, scoreboardID, fixtureID uuid.UUID) error {
+	traceCtx, span := s.tracer.Start(ctx, "DeleteFixture")
+	defer span.End()
+	return s.queries.DeleteFixture(traceCtx, DeleteFixtureParams{
+		ID:           fixtureID,
+		ScoreboardID: scoreboardID,
+	})
+}
+
+// Standings derives the league table of a scoreboard from its recorded fixtures.
+func (s *Service) Standings(ctx context.Context, scoreboardID uuid.UUID) ([]Standing, error) {
+	traceCtx, span := s.tracer.Start(ctx, "Standings")
+	defer span.End()
+
+	rules, err := s.GetRules(traceCtx, scoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	fixtures, err := s.queries.ListFixtures(traceCtx, scoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	return ComputeStandings(fixtures, rules), nil
+}
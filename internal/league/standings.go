@@ -0,0 +1,200 @@
+package league
+
+import (
+	"sort"
+)
+
+type TieBreaker string
+
+const (
+	TieBreakerGoalDifference TieBreaker = "goal_difference"
+	TieBreakerGoalsFor       TieBreaker = "goals_for"
+	TieBreakerHeadToHead     TieBreaker = "head_to_head"
+)
+
+// DefaultTieBreakers is applied when a league has no settings stored yet.
+var DefaultTieBreakers = []TieBreaker{TieBreakerGoalDifference, TieBreakerGoalsFor, TieBreakerHeadToHead}
+
+// Rules controls how fixture results are turned into points and how teams
+// level on points are separated.
+type Rules struct {
+	PointsWin   int
+	PointsDraw  int
+	PointsLoss  int
+	TieBreakers []TieBreaker
+}
+
+func DefaultRules() Rules {
+	return Rules{
+		PointsWin:   3,
+		PointsDraw:  1,
+		PointsLoss:  0,
+		TieBreakers: DefaultTieBreakers,
+	}
+}
+
+func RulesFromSettings(settings LeagueSetting) Rules {
+	tieBreakers := make([]TieBreaker, len(settings.TieBreakers))
+	for index, tieBreaker := range settings.TieBreakers {
+		tieBreakers[index] = TieBreaker(tieBreaker)
+	}
+	return Rules{
+		PointsWin:   int(settings.PointsWin),
+		PointsDraw:  int(settings.PointsDraw),
+		PointsLoss:  int(settings.PointsLoss),
+		TieBreakers: tieBreakers,
+	}
+}
+
+type Standing struct {
+	Position       int
+	Team           string
+	Played         int
+	Won            int
+	Drawn          int
+	Lost           int
+	GoalsFor       int
+	GoalsAgainst   int
+	GoalDifference int
+	Points         int
+}
+
+// played reports whether both scores of the fixture have been recorded.
+func played(fixture Fixture) bool {
+	return fixture.HomeScore.Valid && fixture.AwayScore.Valid
+}
+
+// ComputeStandings builds the league table from the given fixtures. Fixtures
+// without a result only register their teams. Teams that are still level
+// after every tie-breaker share the same position.
+func ComputeStandings(fixtures []Fixture, rules Rules) []Standing {
+	table := tally(fixtures, rules, nil)
+
+	teams := make([]*Standing, 0, len(table))
+	for _, standing := range table {
+		teams = append(teams, standing)
+	}
+	sort.Slice(teams, func(i, j int) bool {
+		if teams[i].Points != teams[j].Points {
+			return teams[i].Points > teams[j].Points
+		}
+		return teams[i].Team < teams[j].Team
+	})
+
+	var groups [][]*Standing
+	for _, group := range splitBy(teams, func(s *Standing) int { return s.Points }) {
+		groups = append(groups, breakTies(group, rules.TieBreakers, fixtures, rules)...)
+	}
+
+	standings := make([]Standing, 0, len(teams))
+	for _, group := range groups {
+		position := len(standings) + 1
+		for _, standing := range group {
+			standing.Position = position
+			standings = append(standings, *standing)
+		}
+	}
+	return standings
+}
+
+// tally accumulates the results of the fixtures into a table. When only is
+// non-nil, fixtures involving a team outside the set are ignored.
+func tally(fixtures []Fixture, rules Rules, only map[string]bool) map[string]*Standing {
+	table := make(map[string]*Standing)
+	team := func(name string) *Standing {
+		standing, ok := table[name]
+		if !ok {
+			standing = &Standing{Team: name}
+			table[name] = standing
+		}
+		return standing
+	}
+
+	for _, fixture := range fixtures {
+		if only != nil && (!only[fixture.HomeTeam] || !only[fixture.AwayTeam]) {
+			continue
+		}
+		home := team(fixture.HomeTeam)
+		away := team(fixture.AwayTeam)
+		if !played(fixture) {
+			continue
+		}
+		homeGoals := int(fixture.HomeScore.Int32)
+		awayGoals := int(fixture.AwayScore.Int32)
+		record(home, homeGoals, awayGoals, rules)
+		record(away, awayGoals, homeGoals, rules)
+	}
+	return table
+}
+
+func record(standing *Standing, goalsFor, goalsAgainst int, rules Rules) {
+	standing.Played++
+	standing.GoalsFor += goalsFor
+	standing.GoalsAgainst += goalsAgainst
+	standing.GoalDifference = standing.GoalsFor - standing.GoalsAgainst
+	switch {
+	case goalsFor > goalsAgainst:
+		standing.Won++
+		standing.Points += rules.PointsWin
+	case goalsFor < goalsAgainst:
+		standing.Lost++
+		standing.Points += rules.PointsLoss
+	default:
+		standing.Drawn++
+		standing.Points += rules.PointsDraw
+	}
+}
+
+// breakTies orders a group of teams level on points by applying the
+// tie-breakers in turn, and returns the groups that remain level.
+func breakTies(group []*Standing, tieBreakers []TieBreaker, fixtures []Fixture, rules Rules) [][]*Standing {
+	if len(group) <= 1 || len(tieBreakers) == 0 {
+		return [][]*Standing{group}
+	}
+
+	var key func(s *Standing) int
+	switch tieBreakers[0] {
+	case TieBreakerGoalDifference:
+		key = func(s *Standing) int { return s.GoalDifference }
+	case TieBreakerGoalsFor:
+		key = func(s *Standing) int { return s.GoalsFor }
+	case TieBreakerHeadToHead:
+		members := make(map[string]bool, len(group))
+		for _, standing := range group {
+			members[standing.Team] = true
+		}
+		miniTable := tally(fixtures, rules, members)
+		key = func(s *Standing) int {
+			// Teams that have not met any of the others yet have no mini table entry.
+			if standing, ok := miniTable[s.Team]; ok {
+				return standing.Points
+			}
+			return 0
+		}
+	default:
+		return breakTies(group, tieBreakers[1:], fixtures, rules)
+	}
+
+	sort.SliceStable(group, func(i, j int) bool {
+		return key(group[i]) > key(group[j])
+	})
+
+	var groups [][]*Standing
+	for _, subgroup := range splitBy(group, key) {
+		groups = append(groups, breakTies(subgroup, tieBreakers[1:], fixtures, rules)...)
+	}
+	return groups
+}
+
+// splitBy cuts a sorted slice into runs that share the same key.
+func splitBy(teams []*Standing, key func(s *Standing) int) [][]*Standing {
+	var groups [][]*Standing
+	start := 0
+	for index := 1; index <= len(teams); index++ {
+		if index == len(teams) || key(teams[index]) != key(teams[start]) {
+			groups = append(groups, teams[start:index])
+			start = index
+		}
+	}
+	return groups
+}
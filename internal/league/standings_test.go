@@ -0,0 +1,99 @@
+package league
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func result(home, away string, homeScore, awayScore int32) Fixture {
+	return Fixture{
+		HomeTeam:  home,
+		AwayTeam:  away,
+		HomeScore: pgtype.Int4{Int32: homeScore, Valid: true},
+		AwayScore: pgtype.Int4{Int32: awayScore, Valid: true},
+	}
+}
+
+func TestComputeStandings(t *testing.T) {
+	tests := []struct {
+		name          string
+		fixtures      []Fixture
+		rules         Rules
+		wantTeams     []string
+		wantPositions []int
+		wantPoints    []int
+	}{
+		{
+			name: "Points decide the order",
+			fixtures: []Fixture{
+				result("Ajax", "Benfica", 2, 0),
+				result("Benfica", "Celtic", 1, 1),
+				result("Celtic", "Ajax", 0, 1),
+			},
+			rules:         DefaultRules(),
+			wantTeams:     []string{"Ajax", "Celtic", "Benfica"},
+			wantPositions: []int{1, 2, 3},
+			wantPoints:    []int{6, 1, 1},
+		},
+		{
+			name: "Goal difference breaks a tie on points",
+			fixtures: []Fixture{
+				result("Ajax", "Benfica", 1, 0),
+				result("Celtic", "Dortmund", 4, 0),
+			},
+			rules:         DefaultRules(),
+			wantTeams:     []string{"Celtic", "Ajax", "Benfica", "Dortmund"},
+			wantPositions: []int{1, 2, 3, 4},
+			wantPoints:    []int{3, 3, 0, 0},
+		},
+		{
+			name: "Head-to-head breaks a tie on points",
+			fixtures: []Fixture{
+				result("Ajax", "Benfica", 1, 0),
+				result("Benfica", "Celtic", 5, 0),
+				result("Celtic", "Ajax", 0, 0),
+				result("Benfica", "Dortmund", 0, 0),
+			},
+			rules: Rules{
+				PointsWin:   3,
+				PointsDraw:  1,
+				TieBreakers: []TieBreaker{TieBreakerHeadToHead, TieBreakerGoalDifference},
+			},
+			wantTeams:     []string{"Ajax", "Benfica", "Dortmund", "Celtic"},
+			wantPositions: []int{1, 2, 3, 4},
+			wantPoints:    []int{4, 4, 1, 1},
+		},
+		{
+			name: "Custom points and unplayed fixtures",
+			fixtures: []Fixture{
+				result("Ajax", "Benfica", 2, 2),
+				{HomeTeam: "Celtic", AwayTeam: "Ajax"},
+			},
+			rules:         Rules{PointsWin: 2, PointsDraw: 1, PointsLoss: 0},
+			wantTeams:     []string{"Ajax", "Benfica", "Celtic"},
+			wantPositions: []int{1, 1, 3},
+			wantPoints:    []int{1, 1, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standings := ComputeStandings(tt.fixtures, tt.rules)
+			if len(standings) != len(tt.wantTeams) {
+				t.Fatalf("ComputeStandings() returned %d teams, want %d", len(standings), len(tt.wantTeams))
+			}
+			for index, standing := range standings {
+				if standing.Team != tt.wantTeams[index] {
+					t.Errorf("ComputeStandings()[%d].Team = %s, want %s", index, standing.Team, tt.wantTeams[index])
+				}
+				if standing.Position != tt.wantPositions[index] {
+					t.Errorf("ComputeStandings()[%d].Position = %d, want %d", index, standing.Position, tt.wantPositions[index])
+				}
+				if standing.Points != tt.wantPoints[index] {
+					t.Errorf("ComputeStandings()[%d].Points = %d, want %d", index, standing.Points, tt.wantPoints[index])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Package memory keeps scoreboards and users in process memory, for demos and
+// local development without a database. Nothing is persisted.
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"scoreboard-api/internal/scoreboard"
+	"scoreboard-api/internal/user"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// DB holds every table of the in-memory backend behind one lock.
+type DB struct {
+	mu          sync.Mutex
+	scoreboards map[uuid.UUID]scoreboard.Scoreboard
+	entries     map[playerKey]scoreboard.ScoreboardEntry
+	settings    map[uuid.UUID]scoreboard.ScoreboardSetting
+	handicaps   map[playerKey]scoreboard.ScoreboardHandicap
+	events      []scoreboard.ScoreboardEvent
+	users       map[uuid.UUID]user.User
+}
+
+// playerKey identifies a player on a scoreboard.
+type playerKey struct {
+	scoreboardID uuid.UUID
+	userID       uuid.UUID
+}
+
+func New() *DB {
+	return &DB{
+		scoreboards: make(map[uuid.UUID]scoreboard.Scoreboard),
+		entries:     make(map[playerKey]scoreboard.ScoreboardEntry),
+		settings:    make(map[uuid.UUID]scoreboard.ScoreboardSetting),
+		handicaps:   make(map[playerKey]scoreboard.ScoreboardHandicap),
+		users:       make(map[uuid.UUID]user.User),
+	}
+}
+
+// Scoreboards returns the scoreboard queries of the database.
+func (db *DB) Scoreboards() Scoreboards {
+	return Scoreboards{db: db}
+}
+
+// Users returns the user queries of the database.
+func (db *DB) Users() Users {
+	return Users{db: db}
+}
+
+func now() pgtype.Timestamp {
+	return pgtype.Timestamp{Time: time.Now().UTC(), Valid: true}
+}
@@ -0,0 +1,209 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"scoreboard-api/internal/scoreboard"
+	"scoreboard-api/internal/user"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+func TestScoreboardService(t *testing.T) {
+	ctx := context.Background()
+	db := New()
+	service := scoreboard.NewServiceWithQuerier(zap.NewNop(), db.Scoreboards(), db.Scoreboards())
+
+	board, err := service.Create(ctx, pgtype.Text{String: "Arcade", Valid: true})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := service.UpdateSettings(ctx, scoreboard.UpsertSettingsParams{
+		ScoreboardID:   board.ID,
+		Decay:          string(scoreboard.DecayNone),
+		SubmissionRule: string(scoreboard.SubmitAdd),
+	}); err != nil {
+		t.Fatalf("UpdateSettings() error = %v", err)
+	}
+
+	// Concurrent submissions must all count towards the total.
+	grinder, casual := uuid.New(), uuid.New()
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := service.Submit(ctx, scoreboard.SubmitScoreParams{ScoreboardID: board.ID, UserID: grinder, Score: 2}); err != nil {
+				t.Errorf("Submit() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if _, err := service.CreateEntry(ctx, scoreboard.CreateEntryParams{ScoreboardID: board.ID, UserID: casual, Score: 40}); err != nil {
+		t.Fatalf("CreateEntry() error = %v", err)
+	}
+	if _, err := service.CreateEntry(ctx, scoreboard.CreateEntryParams{ScoreboardID: board.ID, UserID: casual}); !errors.Is(err, scoreboard.ErrEntryExists) {
+		t.Errorf("CreateEntry() twice error = %v, want ErrEntryExists", err)
+	}
+
+	standings, err := service.Leaderboard(ctx, board.ID)
+	if err != nil {
+		t.Fatalf("Leaderboard() error = %v", err)
+	}
+	if len(standings) != 2 || standings[0].UserID != grinder || standings[0].Score != 100 {
+		t.Fatalf("Leaderboard() = %+v, want the grinder first with 100", standings)
+	}
+
+	if _, err := service.UpdateEntry(ctx, scoreboard.UpdateEntryParams{ScoreboardID: board.ID, UserID: casual, Score: 150}); err != nil {
+		t.Fatalf("UpdateEntry() error = %v", err)
+	}
+	if standing, err := service.Standing(ctx, board.ID, casual); err != nil || standing.Rank != 1 {
+		t.Errorf("Standing() = %d, %v, want rank 1 after the update", standing.Rank, err)
+	}
+
+	if err := service.DeleteEntry(ctx, board.ID, uuid.New()); !errors.Is(err, scoreboard.ErrEntryNotFound) {
+		t.Errorf("DeleteEntry() of an absent player error = %v, want ErrEntryNotFound", err)
+	}
+	if _, err := service.CreateEntry(ctx, scoreboard.CreateEntryParams{ScoreboardID: uuid.New(), UserID: casual}); !errors.Is(err, scoreboard.ErrUnknownEntity) {
+		t.Errorf("CreateEntry() on an absent scoreboard error = %v, want ErrUnknownEntity", err)
+	}
+
+	if err := service.Delete(ctx, board.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if entries, _ := db.Scoreboards().ListEntries(ctx, board.ID); len(entries) != 0 {
+		t.Errorf("Delete() left %d entries behind", len(entries))
+	}
+}
+
+// changeRecorder is an observer that keeps the changes it sees.
+type changeRecorder struct {
+	changes []scoreboard.EntryChange
+}
+
+func (r *changeRecorder) ObserveEntry(_ context.Context, change scoreboard.EntryChange) error {
+	r.changes = append(r.changes, change)
+	return nil
+}
+
+func TestSubmissionRules(t *testing.T) {
+	tests := []struct {
+		rule   scoreboard.SubmissionRule
+		scores []int64
+		// want holds the entry's score after each submission.
+		want []int64
+	}{
+		{rule: scoreboard.SubmitKeepBest, scores: []int64{50, 30, 80, 80}, want: []int64{50, 50, 80, 80}},
+		{rule: scoreboard.SubmitReplace, scores: []int64{50, 30, 80, -10}, want: []int64{50, 30, 80, -10}},
+		{rule: scoreboard.SubmitAdd, scores: []int64{50, 30, 80, -10}, want: []int64{50, 80, 160, 150}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.rule), func(t *testing.T) {
+			ctx := context.Background()
+			store := New().Scoreboards()
+			service := scoreboard.NewServiceWithQuerier(zap.NewNop(), store, store)
+			board, err := service.Create(ctx, pgtype.Text{String: "Arcade", Valid: true})
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if _, err := service.UpdateSettings(ctx, scoreboard.UpsertSettingsParams{
+				ScoreboardID:   board.ID,
+				Decay:          string(scoreboard.DecayNone),
+				SubmissionRule: string(tt.rule),
+			}); err != nil {
+				t.Fatalf("UpdateSettings() error = %v", err)
+			}
+
+			// The first submission creates the entry.
+			player := uuid.New()
+			for index, score := range tt.scores {
+				entry, err := service.Submit(ctx, scoreboard.SubmitScoreParams{ScoreboardID: board.ID, UserID: player, Score: score})
+				if err != nil {
+					t.Fatalf("Submit(%d) error = %v", score, err)
+				}
+				if entry.Score != tt.want[index] {
+					t.Errorf("Submit(%d) score = %d, want %d", score, entry.Score, tt.want[index])
+				}
+			}
+			standing, err := service.Standing(ctx, board.ID, player)
+			if err != nil {
+				t.Fatalf("Standing() error = %v", err)
+			}
+			if want := tt.want[len(tt.want)-1]; standing.Score != want {
+				t.Errorf("Standing() score = %d, want %d", standing.Score, want)
+			}
+		})
+	}
+}
+
+// TestApplyNotification runs two services on one database, as two replicas
+// sharing Postgres would.
+func TestApplyNotification(t *testing.T) {
+	ctx := context.Background()
+	db := New()
+	writer := scoreboard.NewServiceWithQuerier(zap.NewNop(), db.Scoreboards(), db.Scoreboards())
+	reader := scoreboard.NewServiceWithQuerier(zap.NewNop(), db.Scoreboards(), db.Scoreboards())
+	recorder := &changeRecorder{}
+	reader.Observe(recorder)
+
+	board, err := writer.Create(ctx, pgtype.Text{String: "Arcade", Valid: true})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	leader, chaser := uuid.New(), uuid.New()
+	for userID, score := range map[uuid.UUID]int64{leader: 50, chaser: 10} {
+		if _, err := writer.CreateEntry(ctx, scoreboard.CreateEntryParams{ScoreboardID: board.ID, UserID: userID, Score: score}); err != nil {
+			t.Fatalf("CreateEntry() error = %v", err)
+		}
+	}
+	// Load the reader's ranking before the write it has to learn about.
+	if top, err := reader.Top(ctx, board.ID, 1); err != nil || top[0].UserID != leader {
+		t.Fatalf("Top() = %+v, %v, want the leader", top, err)
+	}
+	if _, err := writer.UpdateEntry(ctx, scoreboard.UpdateEntryParams{ScoreboardID: board.ID, UserID: chaser, Score: 80}); err != nil {
+		t.Fatalf("UpdateEntry() error = %v", err)
+	}
+
+	events, err := writer.Events(ctx, board.ID, 0, 100)
+	if err != nil {
+		t.Fatalf("Events() error = %v", err)
+	}
+	last := events[len(events)-1]
+	if err := reader.ApplyNotification(ctx, last.Notification()); err != nil {
+		t.Fatalf("ApplyNotification() error = %v", err)
+	}
+	if len(recorder.changes) != 1 {
+		t.Fatalf("observer saw %d changes, want 1", len(recorder.changes))
+	}
+	if change := recorder.changes[0]; !change.Remote || change.EventID != last.ID || change.After == nil || change.After.Score != 80 {
+		t.Errorf("observed change = %+v, want the remote update to 80", change)
+	}
+	if top, err := reader.Top(ctx, board.ID, 1); err != nil || top[0].UserID != chaser {
+		t.Errorf("Top() after the notification = %+v, %v, want the chaser", top, err)
+	}
+}
+
+func TestUserService(t *testing.T) {
+	ctx := context.Background()
+	service := user.NewServiceWithQuerier(zap.NewNop(), New().Users())
+
+	created, err := service.FindOrCreateWithProfile(ctx, "ada@example.com", "Ada", "Ada", "Lovelace", "", "en", true)
+	if err != nil {
+		t.Fatalf("FindOrCreateWithProfile() error = %v", err)
+	}
+	updated, err := service.FindOrCreateWithProfile(ctx, "ada@example.com", "Ada L.", "Ada", "Lovelace", "", "en", true)
+	if err != nil {
+		t.Fatalf("FindOrCreateWithProfile() again error = %v", err)
+	}
+	if updated.ID != created.ID || updated.Name.String != "Ada L." {
+		t.Errorf("FindOrCreateWithProfile() = %v named %q, want %v renamed", updated.ID, updated.Name.String, created.ID)
+	}
+	if _, err := service.Create(ctx, "ada@example.com"); !errors.Is(err, user.ErrDuplicateEmail) {
+		t.Errorf("Create() with a taken email error = %v, want ErrDuplicateEmail", err)
+	}
+}
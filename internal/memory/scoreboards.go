@@ -0,0 +1,326 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"scoreboard-api/internal/scoreboard"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Scoreboards implements scoreboard.Querier and scoreboard.Transactor.
+//
+// Players are not checked against the users table, since users can only be
+// created by signing in.
+type Scoreboards struct {
+	db *DB
+	// locked is set inside InTx, where the lock is already held.
+	locked bool
+}
+
+var (
+	_ scoreboard.Querier    = Scoreboards{}
+	_ scoreboard.Transactor = Scoreboards{}
+)
+
+// InTx runs fn while holding the database lock, so no other query runs in
+// between. Changes made before fn fails are kept.
+func (s Scoreboards) InTx(_ context.Context, fn func(queries scoreboard.Querier) error) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	return fn(Scoreboards{db: s.db, locked: true})
+}
+
+func (s Scoreboards) lock() (unlock func()) {
+	if s.locked {
+		return func() {}
+	}
+	s.db.mu.Lock()
+	return s.db.mu.Unlock
+}
+
+func (s Scoreboards) List(_ context.Context) ([]scoreboard.Scoreboard, error) {
+	defer s.lock()()
+	scoreboards := make([]scoreboard.Scoreboard, 0, len(s.db.scoreboards))
+	for _, board := range s.db.scoreboards {
+		scoreboards = append(scoreboards, board)
+	}
+	sort.Slice(scoreboards, func(i, j int) bool {
+		return scoreboards[i].CreatedAt.Time.After(scoreboards[j].CreatedAt.Time)
+	})
+	return scoreboards, nil
+}
+
+func (s Scoreboards) Get(_ context.Context, id uuid.UUID) (scoreboard.Scoreboard, error) {
+	defer s.lock()()
+	board, ok := s.db.scoreboards[id]
+	if !ok {
+		return scoreboard.Scoreboard{}, pgx.ErrNoRows
+	}
+	return board, nil
+}
+
+func (s Scoreboards) Create(_ context.Context, name pgtype.Text) (scoreboard.Scoreboard, error) {
+	defer s.lock()()
+	board := scoreboard.Scoreboard{
+		ID:        uuid.New(),
+		Name:      name,
+		CreatedAt: now(),
+		UpdatedAt: now(),
+	}
+	s.db.scoreboards[board.ID] = board
+	return board, nil
+}
+
+func (s Scoreboards) Update(_ context.Context, arg scoreboard.UpdateParams) (scoreboard.Scoreboard, error) {
+	defer s.lock()()
+	board, ok := s.db.scoreboards[arg.ID]
+	if !ok {
+		return scoreboard.Scoreboard{}, pgx.ErrNoRows
+	}
+	board.Name = arg.Name
+	board.UpdatedAt = now()
+	s.db.scoreboards[board.ID] = board
+	return board, nil
+}
+
+// Delete removes a scoreboard with its entries, settings and handicaps.
+func (s Scoreboards) Delete(_ context.Context, id uuid.UUID) error {
+	defer s.lock()()
+	delete(s.db.scoreboards, id)
+	delete(s.db.settings, id)
+	for key := range s.db.entries {
+		if key.scoreboardID == id {
+			delete(s.db.entries, key)
+		}
+	}
+	for key := range s.db.handicaps {
+		if key.scoreboardID == id {
+			delete(s.db.handicaps, key)
+		}
+	}
+	return nil
+}
+
+func (s Scoreboards) ListEntries(_ context.Context, scoreboardID uuid.UUID) ([]scoreboard.ScoreboardEntry, error) {
+	defer s.lock()()
+	var entries []scoreboard.ScoreboardEntry
+	for key, entry := range s.db.entries {
+		if key.scoreboardID == scoreboardID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func (s Scoreboards) GetEntry(_ context.Context, arg scoreboard.GetEntryParams) (scoreboard.ScoreboardEntry, error) {
+	defer s.lock()()
+	return s.entry(arg.ScoreboardID, arg.UserID)
+}
+
+// LockEntry reads an entry. Inside InTx the entry cannot change until the
+// transaction ends.
+func (s Scoreboards) LockEntry(_ context.Context, arg scoreboard.LockEntryParams) (scoreboard.ScoreboardEntry, error) {
+	defer s.lock()()
+	return s.entry(arg.ScoreboardID, arg.UserID)
+}
+
+func (s Scoreboards) CreateEntry(_ context.Context, arg scoreboard.CreateEntryParams) (scoreboard.ScoreboardEntry, error) {
+	defer s.lock()()
+	if _, ok := s.db.scoreboards[arg.ScoreboardID]; !ok {
+		return scoreboard.ScoreboardEntry{}, scoreboard.ErrUnknownEntity
+	}
+	key := playerKey{scoreboardID: arg.ScoreboardID, userID: arg.UserID}
+	if _, ok := s.db.entries[key]; ok {
+		return scoreboard.ScoreboardEntry{}, scoreboard.ErrEntryExists
+	}
+	entry := scoreboard.ScoreboardEntry{
+		ScoreboardID: arg.ScoreboardID,
+		UserID:       arg.UserID,
+		Score:        arg.Score,
+		CreatedAt:    now(),
+		UpdatedAt:    now(),
+	}
+	s.db.entries[key] = entry
+	return entry, nil
+}
+
+func (s Scoreboards) UpdateEntry(_ context.Context, arg scoreboard.UpdateEntryParams) (scoreboard.ScoreboardEntry, error) {
+	defer s.lock()()
+	entry, err := s.entry(arg.ScoreboardID, arg.UserID)
+	if err != nil {
+		return scoreboard.ScoreboardEntry{}, err
+	}
+	entry.Score = arg.Score
+	entry.UpdatedAt = now()
+	s.db.entries[playerKey{scoreboardID: arg.ScoreboardID, userID: arg.UserID}] = entry
+	return entry, nil
+}
+
+// SubmitScore creates an entry or applies the score to the existing one
+// following the submission rule.
+func (s Scoreboards) SubmitScore(_ context.Context, arg scoreboard.SubmitScoreParams) (scoreboard.ScoreboardEntry, error) {
+	defer s.lock()()
+	if _, ok := s.db.scoreboards[arg.ScoreboardID]; !ok {
+		return scoreboard.ScoreboardEntry{}, scoreboard.ErrUnknownEntity
+	}
+	key := playerKey{scoreboardID: arg.ScoreboardID, userID: arg.UserID}
+	entry, ok := s.db.entries[key]
+	switch {
+	case !ok:
+		entry = scoreboard.ScoreboardEntry{
+			ScoreboardID: arg.ScoreboardID,
+			UserID:       arg.UserID,
+			Score:        arg.Score,
+			CreatedAt:    now(),
+		}
+	case scoreboard.SubmissionRule(arg.Rule) == scoreboard.SubmitAdd:
+		entry.Score += arg.Score
+	case scoreboard.SubmissionRule(arg.Rule) == scoreboard.SubmitKeepBest:
+		entry.Score = max(entry.Score, arg.Score)
+	default:
+		entry.Score = arg.Score
+	}
+	entry.UpdatedAt = now()
+	s.db.entries[key] = entry
+	return entry, nil
+}
+
+func (s Scoreboards) DeleteEntry(_ context.Context, arg scoreboard.DeleteEntryParams) error {
+	defer s.lock()()
+	delete(s.db.entries, playerKey{scoreboardID: arg.ScoreboardID, userID: arg.UserID})
+	return nil
+}
+
+func (s Scoreboards) GetSettings(_ context.Context, scoreboardID uuid.UUID) (scoreboard.ScoreboardSetting, error) {
+	defer s.lock()()
+	settings, ok := s.db.settings[scoreboardID]
+	if !ok {
+		return scoreboard.ScoreboardSetting{}, pgx.ErrNoRows
+	}
+	return settings, nil
+}
+
+func (s Scoreboards) UpsertSettings(_ context.Context, arg scoreboard.UpsertSettingsParams) (scoreboard.ScoreboardSetting, error) {
+	defer s.lock()()
+	if _, ok := s.db.scoreboards[arg.ScoreboardID]; !ok {
+		return scoreboard.ScoreboardSetting{}, scoreboard.ErrUnknownEntity
+	}
+	settings, ok := s.db.settings[arg.ScoreboardID]
+	if !ok {
+		settings = scoreboard.ScoreboardSetting{ScoreboardID: arg.ScoreboardID, CreatedAt: now()}
+	}
+	settings.Decay = arg.Decay
+	settings.HalfLifeHours = arg.HalfLifeHours
+	settings.DecayPerDay = arg.DecayPerDay
+	settings.SubmissionRule = arg.SubmissionRule
+	settings.UpdatedAt = now()
+	s.db.settings[arg.ScoreboardID] = settings
+	return settings, nil
+}
+
+func (s Scoreboards) ListHandicaps(_ context.Context, scoreboardID uuid.UUID) ([]scoreboard.ScoreboardHandicap, error) {
+	defer s.lock()()
+	var handicaps []scoreboard.ScoreboardHandicap
+	for key, handicap := range s.db.handicaps {
+		if key.scoreboardID == scoreboardID {
+			handicaps = append(handicaps, handicap)
+		}
+	}
+	return handicaps, nil
+}
+
+func (s Scoreboards) UpsertHandicap(_ context.Context, arg scoreboard.UpsertHandicapParams) (scoreboard.ScoreboardHandicap, error) {
+	defer s.lock()()
+	if _, ok := s.db.scoreboards[arg.ScoreboardID]; !ok {
+		return scoreboard.ScoreboardHandicap{}, scoreboard.ErrUnknownEntity
+	}
+	key := playerKey{scoreboardID: arg.ScoreboardID, userID: arg.UserID}
+	handicap, ok := s.db.handicaps[key]
+	if !ok {
+		handicap = scoreboard.ScoreboardHandicap{ScoreboardID: arg.ScoreboardID, UserID: arg.UserID, CreatedAt: now()}
+	}
+	handicap.Class = arg.Class
+	handicap.Handicap = arg.Handicap
+	handicap.Multiplier = arg.Multiplier
+	handicap.UpdatedAt = now()
+	s.db.handicaps[key] = handicap
+	return handicap, nil
+}
+
+func (s Scoreboards) DeleteHandicap(_ context.Context, arg scoreboard.DeleteHandicapParams) error {
+	defer s.lock()()
+	delete(s.db.handicaps, playerKey{scoreboardID: arg.ScoreboardID, userID: arg.UserID})
+	return nil
+}
+
+func (s Scoreboards) AppendEvent(_ context.Context, arg scoreboard.AppendEventParams) (scoreboard.ScoreboardEvent, error) {
+	defer s.lock()()
+	event := scoreboard.ScoreboardEvent{
+		ID:           int64(len(s.db.events) + 1),
+		ScoreboardID: arg.ScoreboardID,
+		UserID:       arg.UserID,
+		Type:         arg.Type,
+		Payload:      arg.Payload,
+		CreatedAt:    arg.CreatedAt,
+	}
+	s.db.events = append(s.db.events, event)
+	return event, nil
+}
+
+// ListEvents returns up to arg.Limit events appended after the event arg.ID.
+func (s Scoreboards) ListEvents(_ context.Context, arg scoreboard.ListEventsParams) ([]scoreboard.ScoreboardEvent, error) {
+	defer s.lock()()
+	// Event IDs are positions in the log, counted from one.
+	start := min(max(arg.ID, 0), int64(len(s.db.events)))
+	end := min(start+int64(arg.Limit), int64(len(s.db.events)))
+	return append([]scoreboard.ScoreboardEvent(nil), s.db.events[start:end]...), nil
+}
+
+func (s Scoreboards) ListScoreboardEvents(_ context.Context, arg scoreboard.ListScoreboardEventsParams) ([]scoreboard.ScoreboardEvent, error) {
+	defer s.lock()()
+	var events []scoreboard.ScoreboardEvent
+	for _, event := range s.db.events[min(max(arg.ID, 0), int64(len(s.db.events))):] {
+		if len(events) == int(arg.Limit) {
+			break
+		}
+		if event.ScoreboardID == arg.ScoreboardID {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (s Scoreboards) ClearEntries(_ context.Context) error {
+	defer s.lock()()
+	clear(s.db.entries)
+	return nil
+}
+
+func (s Scoreboards) RestoreScoreboard(_ context.Context, arg scoreboard.RestoreScoreboardParams) error {
+	defer s.lock()()
+	s.db.scoreboards[arg.ID] = scoreboard.Scoreboard(arg)
+	return nil
+}
+
+func (s Scoreboards) RestoreEntry(_ context.Context, arg scoreboard.RestoreEntryParams) (int64, error) {
+	defer s.lock()()
+	key := playerKey{scoreboardID: arg.ScoreboardID, userID: arg.UserID}
+	if _, ok := s.db.entries[key]; ok {
+		return 0, scoreboard.ErrEntryExists
+	}
+	s.db.entries[key] = scoreboard.ScoreboardEntry(arg)
+	return 1, nil
+}
+
+// entry must be called with the lock held.
+func (s Scoreboards) entry(scoreboardID, userID uuid.UUID) (scoreboard.ScoreboardEntry, error) {
+	entry, ok := s.db.entries[playerKey{scoreboardID: scoreboardID, userID: userID}]
+	if !ok {
+		return scoreboard.ScoreboardEntry{}, pgx.ErrNoRows
+	}
+	return entry, nil
+}
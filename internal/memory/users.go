@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"context"
+
+	"scoreboard-api/internal/user"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Users implements user.Querier.
+type Users struct {
+	db *DB
+}
+
+var _ user.Querier = Users{}
+
+func (u Users) GetByID(_ context.Context, id uuid.UUID) (user.User, error) {
+	u.db.mu.Lock()
+	defer u.db.mu.Unlock()
+	found, ok := u.db.users[id]
+	if !ok {
+		return user.User{}, pgx.ErrNoRows
+	}
+	return found, nil
+}
+
+func (u Users) GetByEmail(_ context.Context, email string) (user.User, error) {
+	u.db.mu.Lock()
+	defer u.db.mu.Unlock()
+	found, ok := u.byEmail(email)
+	if !ok {
+		return user.User{}, pgx.ErrNoRows
+	}
+	return found, nil
+}
+
+func (u Users) ListByIDs(_ context.Context, ids []uuid.UUID) ([]user.User, error) {
+	u.db.mu.Lock()
+	defer u.db.mu.Unlock()
+	var found []user.User
+	for _, id := range ids {
+		if match, ok := u.db.users[id]; ok {
+			found = append(found, match)
+		}
+	}
+	return found, nil
+}
+
+func (u Users) ExistsByEmail(_ context.Context, email string) (bool, error) {
+	u.db.mu.Lock()
+	defer u.db.mu.Unlock()
+	_, ok := u.byEmail(email)
+	return ok, nil
+}
+
+func (u Users) Create(_ context.Context, arg user.CreateParams) (user.User, error) {
+	u.db.mu.Lock()
+	defer u.db.mu.Unlock()
+	if _, ok := u.byEmail(arg.Email); ok {
+		return user.User{}, user.ErrDuplicateEmail
+	}
+	created := user.User{
+		ID:            uuid.New(),
+		Email:         arg.Email,
+		Name:          arg.Name,
+		GivenName:     arg.GivenName,
+		FamilyName:    arg.FamilyName,
+		Picture:       arg.Picture,
+		EmailVerified: arg.EmailVerified,
+		Locale:        arg.Locale,
+		CreatedAt:     now(),
+		UpdatedAt:     now(),
+	}
+	u.db.users[created.ID] = created
+	return created, nil
+}
+
+// Update changes the profile of the user with the given email.
+func (u Users) Update(_ context.Context, arg user.UpdateParams) (user.User, error) {
+	u.db.mu.Lock()
+	defer u.db.mu.Unlock()
+	updated, ok := u.byEmail(arg.Email)
+	if !ok {
+		return user.User{}, pgx.ErrNoRows
+	}
+	updated.Name = arg.Name
+	updated.GivenName = arg.GivenName
+	updated.FamilyName = arg.FamilyName
+	updated.Picture = arg.Picture
+	updated.EmailVerified = arg.EmailVerified
+	updated.Locale = arg.Locale
+	updated.UpdatedAt = now()
+	u.db.users[updated.ID] = updated
+	return updated, nil
+}
+
+func (u Users) Delete(_ context.Context, id uuid.UUID) error {
+	u.db.mu.Lock()
+	defer u.db.mu.Unlock()
+	delete(u.db.users, id)
+	return nil
+}
+
+// byEmail must be called with the lock held.
+func (u Users) byEmail(email string) (user.User, bool) {
+	for _, found := range u.db.users {
+		if found.Email == email {
+			return found, true
+		}
+	}
+	return user.User{}, false
+}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// unmatched is the route of requests that matched no pattern. Their paths
+// are not used as labels, so that scanners can't create a series per path.
+const unmatched = "unmatched"
+
+// recorder remembers the status code a handler wrote.
+type recorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *recorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the flusher of streaming
+// handlers.
+func (r *recorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Middleware counts and times the requests served by mux, labelled by the
+// pattern they matched. It must wrap the ServeMux itself, since the pattern
+// is only known once the mux has routed the request.
+func (m *Metrics) Middleware(mux http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &recorder{ResponseWriter: w}
+		mux.ServeHTTP(recorder, r)
+
+		route := r.Pattern
+		if route == "" {
+			route = unmatched
+		}
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+		status := strconv.Itoa(recorder.status)
+		m.requests.WithLabelValues(route, r.Method, status).Inc()
+		m.requestDuration.WithLabelValues(route, status).Observe(time.Since(start).Seconds())
+		if recorder.status >= http.StatusInternalServerError {
+			m.requestErrors.WithLabelValues(route).Inc()
+		}
+	})
+}
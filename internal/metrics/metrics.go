@@ -0,0 +1,139 @@
+// Package metrics collects Prometheus metrics about HTTP requests, service
+// operations, the Postgres connection pool and migrations, and serves them in
+// the Prometheus text format.
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"scoreboard-api/internal/scoreboard"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "scoreboard"
+
+// Metrics holds the collectors of one process. Each Metrics has its own
+// registry, so tests can build as many as they like.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	requestErrors   *prometheus.CounterVec
+
+	operations        *prometheus.CounterVec
+	operationDuration *prometheus.HistogramVec
+	operationErrors   *prometheus.CounterVec
+
+	migrations        *prometheus.CounterVec
+	migrationDuration *prometheus.GaugeVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by route, method and status code.",
+		}, []string{"route", "method", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Time taken to serve HTTP requests, by route and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "status"}),
+		requestErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_request_errors_total",
+			Help:      "HTTP requests answered with a 5xx status code, by route.",
+		}, []string{"route"}),
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "service_operations_total",
+			Help:      "Scoreboard service operations by operation and outcome: ok, not_found or error.",
+		}, []string{"operation", "outcome"}),
+		operationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "service_operation_duration_seconds",
+			Help:      "Time taken by scoreboard service operations.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation"}),
+		operationErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "service_operation_errors_total",
+			Help:      "Scoreboard service operations that failed, not counting missing scoreboards and entries.",
+		}, []string{"operation"}),
+		migrations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "migrations_total",
+			Help:      "Schema migration runs by storage backend and outcome: ok or error.",
+		}, []string{"storage", "outcome"}),
+		migrationDuration: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "migration_duration_seconds",
+			Help:      "Time taken by the last schema migration run.",
+		}, []string{"storage"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.requestErrors,
+		m.operations,
+		m.operationDuration,
+		m.operationErrors,
+		m.migrations,
+		m.migrationDuration,
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveOperation records a scoreboard service operation. Missing
+// scoreboards and entries are answers rather than failures, so they are
+// counted apart from errors.
+func (m *Metrics) ObserveOperation(operation string, duration time.Duration, err error) {
+	outcome := "ok"
+	switch {
+	case err == nil:
+	case errors.Is(err, pgx.ErrNoRows), errors.Is(err, scoreboard.ErrEntryNotFound):
+		outcome = "not_found"
+	default:
+		outcome = "error"
+		m.operationErrors.WithLabelValues(operation).Inc()
+	}
+	m.operations.WithLabelValues(operation, outcome).Inc()
+	m.operationDuration.WithLabelValues(operation).Observe(duration.Seconds())
+}
+
+// ObservePool reports the connection pool's statistics on every scrape.
+func (m *Metrics) ObservePool(pool *pgxpool.Pool) {
+	m.registry.MustRegister(newPoolCollector(pool))
+}
+
+// Migrate runs a schema migration for the storage backend and records how
+// long it took and whether it failed.
+func (m *Metrics) Migrate(storage string, migrate func() error) error {
+	start := time.Now()
+	err := migrate()
+	m.migrationDuration.WithLabelValues(storage).Set(time.Since(start).Seconds())
+	if err != nil {
+		m.migrations.WithLabelValues(storage, "error").Inc()
+		return err
+	}
+	m.migrations.WithLabelValues(storage, "ok").Inc()
+	return nil
+}
@@ -0,0 +1,123 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"scoreboard-api/internal/memory"
+	"scoreboard-api/internal/scoreboard"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(recorder.Body)
+	return string(body)
+}
+
+func TestMiddleware(t *testing.T) {
+	m := New()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/scoreboards/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") == "broken" {
+			http.Error(w, "broken", http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte("{}"))
+	})
+	mux.HandleFunc("GET /stream", func(w http.ResponseWriter, r *http.Request) {
+		if err := http.NewResponseController(w).Flush(); err != nil {
+			t.Errorf("Flush() error = %v, want the recorder to reach the flusher", err)
+		}
+	})
+	server := httptest.NewServer(m.Middleware(mux))
+	defer server.Close()
+
+	for _, path := range []string{"/api/scoreboards/1", "/api/scoreboards/2", "/api/scoreboards/broken", "/stream", "/nothing/here"} {
+		response, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s error = %v", path, err)
+		}
+		response.Body.Close()
+	}
+
+	body := scrape(t, m)
+	for _, want := range []string{
+		`scoreboard_http_requests_total{method="GET",route="GET /api/scoreboards/{id}",status="200"} 2`,
+		`scoreboard_http_requests_total{method="GET",route="GET /api/scoreboards/{id}",status="500"} 1`,
+		`scoreboard_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`scoreboard_http_request_duration_seconds_count{route="GET /stream",status="200"} 1`,
+		`scoreboard_http_request_errors_total{route="GET /api/scoreboards/{id}"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics do not contain %q:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "/nothing/here") {
+		t.Errorf("metrics label an unmatched path:\n%s", body)
+	}
+}
+
+func TestObserveOperation(t *testing.T) {
+	ctx := context.Background()
+	m := New()
+	store := memory.New().Scoreboards()
+	service := scoreboard.NewServiceWithQuerier(zap.NewNop(), store, store)
+	service.Instrument(m)
+
+	board, err := service.Create(ctx, pgtype.Text{String: "Speedrun", Valid: true})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := service.Standing(ctx, board.ID, uuid.New()); !errors.Is(err, scoreboard.ErrEntryNotFound) {
+		t.Fatalf("Standing() error = %v, want ErrEntryNotFound", err)
+	}
+	m.ObserveOperation("Submit", 0, errors.New("connection reset"))
+
+	body := scrape(t, m)
+	for _, want := range []string{
+		`scoreboard_service_operations_total{operation="Create",outcome="ok"} 1`,
+		`scoreboard_service_operations_total{operation="Standing",outcome="not_found"} 1`,
+		`scoreboard_service_operations_total{operation="Submit",outcome="error"} 1`,
+		`scoreboard_service_operation_errors_total{operation="Submit"} 1`,
+		`scoreboard_service_operation_duration_seconds_count{operation="Create"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics do not contain %q:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, `scoreboard_service_operation_errors_total{operation="Standing"}`) {
+		t.Errorf("a missing entry counts as an error:\n%s", body)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	m := New()
+	failure := errors.New("dirty database")
+	if err := m.Migrate("postgres", func() error { return failure }); !errors.Is(err, failure) {
+		t.Fatalf("Migrate() error = %v, want the migration's error", err)
+	}
+	if err := m.Migrate("postgres", func() error { return nil }); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	body := scrape(t, m)
+	for _, want := range []string{
+		`scoreboard_migrations_total{outcome="error",storage="postgres"} 1`,
+		`scoreboard_migrations_total{outcome="ok",storage="postgres"} 1`,
+		`scoreboard_migration_duration_seconds{storage="postgres"}`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics do not contain %q:\n%s", want, body)
+		}
+	}
+}
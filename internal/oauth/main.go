@@ -17,6 +17,7 @@ import (
 	"golang.org/x/oauth2/google"
 
 	"scoreboard-api/internal/config"
+	"scoreboard-api/internal/sqlite"
 	"scoreboard-api/internal/user"
 )
 
@@ -50,13 +51,27 @@ func main() {
 
 	logger.Info("hello world", zap.String("hello", "world"))
 
-	dbpool, err := pgxpool.New(context.Background(), cfg.DatabaseURL)
-	if err != nil {
-		panic(err)
+	var userService *user.Service
+	switch cfg.Storage {
+	case "memory":
+		// Users signed in here must be seen by the scoreboard server, which
+		// can't read another process's memory.
+		logger.Fatal("The oauth server needs storage shared with the scoreboard server; use sqlite or postgres", zap.String("storage", cfg.Storage))
+	case "sqlite":
+		file, err := sqlite.Open(cfg.SQLitePath)
+		if err != nil {
+			panic(err)
+		}
+		defer file.Close()
+		userService = user.NewServiceWithQuerier(logger, file.Users())
+	default:
+		dbpool, err := pgxpool.New(context.Background(), cfg.DatabaseURL)
+		if err != nil {
+			panic(err)
+		}
+		defer dbpool.Close()
+		userService = user.NewService(logger, dbpool)
 	}
-	defer dbpool.Close()
-
-	userService := user.NewService(logger, dbpool)
 	oauthConfig := &oauth2.Config{
 		ClientID:     cfg.GoogleOauthClientID,
 		ClientSecret: cfg.GoogleOauthClientSecret,
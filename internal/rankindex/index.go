@@ -0,0 +1,215 @@
+// Package rankindex keeps players in leaderboard order in memory.
+package rankindex
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Item is a player placed in an Index, carrying the value it was set with.
+type Item[T any] struct {
+	UserID    uuid.UUID
+	Score     int64
+	UpdatedAt time.Time
+	Value     T
+}
+
+// Ranked is an item with its rank. Players with equal scores share a rank,
+// and the next rank skips the places they take.
+type Ranked[T any] struct {
+	Item[T]
+	Rank int64
+}
+
+// Index keeps items in leaderboard order: highest score first, then the item
+// updated earliest. It is an order-statistic treap, so placing an item,
+// looking up the rank of a player and reading a page of the leaderboard all
+// take O(log n) steps.
+//
+// An Index is not safe for concurrent use.
+type Index[T any] struct {
+	root  *node[T]
+	items map[uuid.UUID]Item[T]
+}
+
+type node[T any] struct {
+	item        Item[T]
+	priority    uint64
+	size        int
+	left, right *node[T]
+}
+
+func New[T any]() *Index[T] {
+	return &Index[T]{items: make(map[uuid.UUID]Item[T])}
+}
+
+func (x *Index[T]) Len() int {
+	return size(x.root)
+}
+
+// Set places an item, replacing the earlier item of the same player.
+func (x *Index[T]) Set(item Item[T]) {
+	x.Remove(item.UserID)
+	x.items[item.UserID] = item
+	left, right := split(x.root, item)
+	x.root = merge(merge(left, &node[T]{item: item, priority: rand.Uint64(), size: 1}), right)
+}
+
+// Remove takes a player off the index. Removing an absent player does nothing.
+func (x *Index[T]) Remove(userID uuid.UUID) {
+	item, ok := x.items[userID]
+	if !ok {
+		return
+	}
+	delete(x.items, userID)
+	x.root = remove(x.root, item)
+}
+
+// Get returns the item of a player.
+func (x *Index[T]) Get(userID uuid.UUID) (Item[T], bool) {
+	item, ok := x.items[userID]
+	return item, ok
+}
+
+// Rank returns the item of a player with its rank.
+func (x *Index[T]) Rank(userID uuid.UUID) (Ranked[T], bool) {
+	item, ok := x.items[userID]
+	if !ok {
+		return Ranked[T]{}, false
+	}
+	return Ranked[T]{Item: item, Rank: int64(x.countAbove(item.Score)) + 1}, true
+}
+
+// Page returns up to limit ranked items starting at the given position,
+// counted from zero.
+func (x *Index[T]) Page(offset, limit int) []Ranked[T] {
+	offset = max(offset, 0)
+	if limit <= 0 || offset >= x.Len() {
+		return []Ranked[T]{}
+	}
+	page := make([]Ranked[T], 0, min(limit, x.Len()-offset))
+	walk(x.root, offset, func(item Item[T]) bool {
+		ranked := Ranked[T]{Item: item}
+		switch count := len(page); {
+		case count == 0:
+			ranked.Rank = int64(x.countAbove(item.Score)) + 1
+		case page[count-1].Score == item.Score:
+			ranked.Rank = page[count-1].Rank
+		default:
+			ranked.Rank = int64(offset + count + 1)
+		}
+		page = append(page, ranked)
+		return len(page) < limit
+	})
+	return page
+}
+
+// countAbove counts the items scoring more than score.
+func (x *Index[T]) countAbove(score int64) int {
+	count := 0
+	for current := x.root; current != nil; {
+		if current.item.Score > score {
+			count += size(current.left) + 1
+			current = current.right
+		} else {
+			current = current.left
+		}
+	}
+	return count
+}
+
+// before reports whether a is listed before b.
+func before[T any](a, b Item[T]) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	if !a.UpdatedAt.Equal(b.UpdatedAt) {
+		return a.UpdatedAt.Before(b.UpdatedAt)
+	}
+	return bytes.Compare(a.UserID[:], b.UserID[:]) < 0
+}
+
+func size[T any](n *node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *node[T]) update() *node[T] {
+	n.size = size(n.left) + size(n.right) + 1
+	return n
+}
+
+// split divides a tree into the items listed before item and the rest.
+func split[T any](n *node[T], item Item[T]) (*node[T], *node[T]) {
+	if n == nil {
+		return nil, nil
+	}
+	if before(n.item, item) {
+		left, right := split(n.right, item)
+		n.right = left
+		return n.update(), right
+	}
+	left, right := split(n.left, item)
+	n.left = right
+	return left, n.update()
+}
+
+// merge joins two trees where every item of left is listed before right.
+func merge[T any](left, right *node[T]) *node[T] {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	if left.priority > right.priority {
+		left.right = merge(left.right, right)
+		return left.update()
+	}
+	right.left = merge(left, right.left)
+	return right.update()
+}
+
+func remove[T any](n *node[T], item Item[T]) *node[T] {
+	if n == nil {
+		return nil
+	}
+	switch {
+	case n.item.UserID == item.UserID:
+		return merge(n.left, n.right)
+	case before(item, n.item):
+		n.left = remove(n.left, item)
+	default:
+		n.right = remove(n.right, item)
+	}
+	return n.update()
+}
+
+// walk visits the items in order from the given position until visit
+// returns false. It reports whether the walk should go on.
+func walk[T any](n *node[T], skip int, visit func(Item[T]) bool) bool {
+	if n == nil {
+		return true
+	}
+	if leftSize := size(n.left); skip < leftSize {
+		if !walk(n.left, skip, visit) {
+			return false
+		}
+		skip = 0
+	} else {
+		skip -= leftSize
+	}
+	if skip == 0 {
+		if !visit(n.item) {
+			return false
+		}
+	} else {
+		skip--
+	}
+	return walk(n.right, skip, visit)
+}
@@ -0,0 +1,93 @@
+package rankindex
+
+import (
+	"math/rand/v2"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var start = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestIndex(t *testing.T) {
+	index := New[string]()
+	alice, bob, carol, dave := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	index.Set(Item[string]{UserID: alice, Score: 50, UpdatedAt: start, Value: "alice"})
+	index.Set(Item[string]{UserID: bob, Score: 80, UpdatedAt: start, Value: "bob"})
+	index.Set(Item[string]{UserID: carol, Score: 50, UpdatedAt: start.Add(time.Minute), Value: "carol"})
+	index.Set(Item[string]{UserID: dave, Score: 10, UpdatedAt: start, Value: "dave"})
+
+	page := index.Page(0, 10)
+	wantValues := []string{"bob", "alice", "carol", "dave"}
+	wantRanks := []int64{1, 2, 2, 4}
+	if len(page) != len(wantValues) {
+		t.Fatalf("Page() returned %d items, want %d", len(page), len(wantValues))
+	}
+	for position, ranked := range page {
+		if ranked.Value != wantValues[position] || ranked.Rank != wantRanks[position] {
+			t.Errorf("Page()[%d] = %s ranked %d, want %s ranked %d", position, ranked.Value, ranked.Rank, wantValues[position], wantRanks[position])
+		}
+	}
+
+	if page := index.Page(2, 1); len(page) != 1 || page[0].Value != "carol" || page[0].Rank != 2 {
+		t.Errorf("Page(2, 1) = %v, want carol ranked 2", page)
+	}
+
+	index.Set(Item[string]{UserID: dave, Score: 90, UpdatedAt: start.Add(time.Hour), Value: "dave"})
+	if ranked, ok := index.Rank(dave); !ok || ranked.Rank != 1 || ranked.Score != 90 {
+		t.Errorf("Rank(dave) = %v, %v, want rank 1 with 90", ranked, ok)
+	}
+	if ranked, _ := index.Rank(carol); ranked.Rank != 3 {
+		t.Errorf("Rank(carol) = %d, want 3", ranked.Rank)
+	}
+
+	index.Remove(bob)
+	index.Remove(bob)
+	if _, ok := index.Rank(bob); ok || index.Len() != 3 {
+		t.Errorf("Remove() left %d items, want 3 without bob", index.Len())
+	}
+}
+
+func TestIndexMatchesSort(t *testing.T) {
+	random := rand.New(rand.NewPCG(1, 2))
+	index := New[struct{}]()
+	users := make([]uuid.UUID, 200)
+	for position := range users {
+		users[position] = uuid.New()
+	}
+	for step := 0; step < 2000; step++ {
+		userID := users[random.IntN(len(users))]
+		if random.IntN(5) == 0 {
+			index.Remove(userID)
+			continue
+		}
+		index.Set(Item[struct{}]{UserID: userID, Score: int64(random.IntN(20)), UpdatedAt: start.Add(time.Duration(step) * time.Second)})
+	}
+
+	var items []Item[struct{}]
+	for _, userID := range users {
+		if item, ok := index.Get(userID); ok {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return before(items[i], items[j]) })
+
+	page := index.Page(0, len(items))
+	if len(page) != len(items) || index.Len() != len(items) {
+		t.Fatalf("Page() returned %d items and Len() %d, want %d", len(page), index.Len(), len(items))
+	}
+	for position, item := range items {
+		if page[position].UserID != item.UserID {
+			t.Fatalf("Page()[%d] = %v, want %v", position, page[position].UserID, item.UserID)
+		}
+		ranked, _ := index.Rank(item.UserID)
+		if ranked.Rank != page[position].Rank {
+			t.Errorf("Rank(%v) = %d, Page() ranked it %d", item.UserID, ranked.Rank, page[position].Rank)
+		}
+		if position > 0 && item.Score != items[position-1].Score && ranked.Rank != int64(position+1) {
+			t.Errorf("Rank(%v) = %d, want %d", item.UserID, ranked.Rank, position+1)
+		}
+	}
+}
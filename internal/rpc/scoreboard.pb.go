@@ -0,0 +1,1498 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: scoreboard.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RankBy int32
+
+const (
+	RankBy_RANK_BY_UNSPECIFIED RankBy = 0
+	RankBy_RANK_BY_RAW         RankBy = 1
+	RankBy_RANK_BY_ADJUSTED    RankBy = 2
+)
+
+// Enum value maps for RankBy.
+var (
+	RankBy_name = map[int32]string{
+		0: "RANK_BY_UNSPECIFIED",
+		1: "RANK_BY_RAW",
+		2: "RANK_BY_ADJUSTED",
+	}
+	RankBy_value = map[string]int32{
+		"RANK_BY_UNSPECIFIED": 0,
+		"RANK_BY_RAW":         1,
+		"RANK_BY_ADJUSTED":    2,
+	}
+)
+
+func (x RankBy) Enum() *RankBy {
+	p := new(RankBy)
+	*p = x
+	return p
+}
+
+func (x RankBy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RankBy) Descriptor() protoreflect.EnumDescriptor {
+	return file_scoreboard_proto_enumTypes[0].Descriptor()
+}
+
+func (RankBy) Type() protoreflect.EnumType {
+	return &file_scoreboard_proto_enumTypes[0]
+}
+
+func (x RankBy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RankBy.Descriptor instead.
+func (RankBy) EnumDescriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{0}
+}
+
+type Scoreboard struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Scoreboard) Reset() {
+	*x = Scoreboard{}
+	mi := &file_scoreboard_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Scoreboard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Scoreboard) ProtoMessage() {}
+
+func (x *Scoreboard) ProtoReflect() protoreflect.Message {
+	mi := &file_scoreboard_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Scoreboard.ProtoReflect.Descriptor instead.
+func (*Scoreboard) Descriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Scoreboard) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Scoreboard) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Scoreboard) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Scoreboard) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Entry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScoreboardId  string                 `protobuf:"bytes,1,opt,name=scoreboard_id,json=scoreboardId,proto3" json:"scoreboard_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Score         int64                  `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Entry) Reset() {
+	*x = Entry{}
+	mi := &file_scoreboard_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Entry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Entry) ProtoMessage() {}
+
+func (x *Entry) ProtoReflect() protoreflect.Message {
+	mi := &file_scoreboard_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Entry.ProtoReflect.Descriptor instead.
+func (*Entry) Descriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Entry) GetScoreboardId() string {
+	if x != nil {
+		return x.ScoreboardId
+	}
+	return ""
+}
+
+func (x *Entry) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Entry) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Entry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Entry) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Handicap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Class         *string                `protobuf:"bytes,1,opt,name=class,proto3,oneof" json:"class,omitempty"`
+	Handicap      float64                `protobuf:"fixed64,2,opt,name=handicap,proto3" json:"handicap,omitempty"`
+	Multiplier    float64                `protobuf:"fixed64,3,opt,name=multiplier,proto3" json:"multiplier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Handicap) Reset() {
+	*x = Handicap{}
+	mi := &file_scoreboard_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Handicap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Handicap) ProtoMessage() {}
+
+func (x *Handicap) ProtoReflect() protoreflect.Message {
+	mi := &file_scoreboard_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Handicap.ProtoReflect.Descriptor instead.
+func (*Handicap) Descriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Handicap) GetClass() string {
+	if x != nil && x.Class != nil {
+		return *x.Class
+	}
+	return ""
+}
+
+func (x *Handicap) GetHandicap() float64 {
+	if x != nil {
+		return x.Handicap
+	}
+	return 0
+}
+
+func (x *Handicap) GetMultiplier() float64 {
+	if x != nil {
+		return x.Multiplier
+	}
+	return 0
+}
+
+type Standing struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Entry *Entry                 `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	Rank  int64                  `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	// effective_score is the score the entry is ranked by.
+	EffectiveScore float64 `protobuf:"fixed64,3,opt,name=effective_score,json=effectiveScore,proto3" json:"effective_score,omitempty"`
+	// adjusted_rank and adjusted_score are only set when handicaps were read.
+	AdjustedRank  *int64    `protobuf:"varint,4,opt,name=adjusted_rank,json=adjustedRank,proto3,oneof" json:"adjusted_rank,omitempty"`
+	AdjustedScore *float64  `protobuf:"fixed64,5,opt,name=adjusted_score,json=adjustedScore,proto3,oneof" json:"adjusted_score,omitempty"`
+	Handicap      *Handicap `protobuf:"bytes,6,opt,name=handicap,proto3" json:"handicap,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Standing) Reset() {
+	*x = Standing{}
+	mi := &file_scoreboard_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Standing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Standing) ProtoMessage() {}
+
+func (x *Standing) ProtoReflect() protoreflect.Message {
+	mi := &file_scoreboard_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Standing.ProtoReflect.Descriptor instead.
+func (*Standing) Descriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Standing) GetEntry() *Entry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *Standing) GetRank() int64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *Standing) GetEffectiveScore() float64 {
+	if x != nil {
+		return x.EffectiveScore
+	}
+	return 0
+}
+
+func (x *Standing) GetAdjustedRank() int64 {
+	if x != nil && x.AdjustedRank != nil {
+		return *x.AdjustedRank
+	}
+	return 0
+}
+
+func (x *Standing) GetAdjustedScore() float64 {
+	if x != nil && x.AdjustedScore != nil {
+		return *x.AdjustedScore
+	}
+	return 0
+}
+
+func (x *Standing) GetHandicap() *Handicap {
+	if x != nil {
+		return x.Handicap
+	}
+	return nil
+}
+
+type Leaderboard struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Standings     []*Standing            `protobuf:"bytes,1,rep,name=standings,proto3" json:"standings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Leaderboard) Reset() {
+	*x = Leaderboard{}
+	mi := &file_scoreboard_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Leaderboard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Leaderboard) ProtoMessage() {}
+
+func (x *Leaderboard) ProtoReflect() protoreflect.Message {
+	mi := &file_scoreboard_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Leaderboard.ProtoReflect.Descriptor instead.
+func (*Leaderboard) Descriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Leaderboard) GetStandings() []*Standing {
+	if x != nil {
+		return x.Standings
+	}
+	return nil
+}
+
+type ListScoreboardsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScoreboardsRequest) Reset() {
+	*x = ListScoreboardsRequest{}
+	mi := &file_scoreboard_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScoreboardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScoreboardsRequest) ProtoMessage() {}
+
+func (x *ListScoreboardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scoreboard_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScoreboardsRequest.ProtoReflect.Descriptor instead.
+func (*ListScoreboardsRequest) Descriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{5}
+}
+
+type ListScoreboardsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scoreboards   []*Scoreboard          `protobuf:"bytes,1,rep,name=scoreboards,proto3" json:"scoreboards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScoreboardsResponse) Reset() {
+	*x = ListScoreboardsResponse{}
+	mi := &file_scoreboard_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScoreboardsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScoreboardsResponse) ProtoMessage() {}
+
+func (x *ListScoreboardsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scoreboard_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScoreboardsResponse.ProtoReflect.Descriptor instead.
+func (*ListScoreboardsResponse) Descriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListScoreboardsResponse) GetScoreboards() []*Scoreboard {
+	if x != nil {
+		return x.Scoreboards
+	}
+	return nil
+}
+
+type GetScoreboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScoreboardRequest) Reset() {
+	*x = GetScoreboardRequest{}
+	mi := &file_scoreboard_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScoreboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScoreboardRequest) ProtoMessage() {}
+
+func (x *GetScoreboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scoreboard_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScoreboardRequest.ProtoReflect.Descriptor instead.
+func (*GetScoreboardRequest) Descriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetScoreboardRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreateScoreboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateScoreboardRequest) Reset() {
+	*x = CreateScoreboardRequest{}
+	mi := &file_scoreboard_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateScoreboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateScoreboardRequest) ProtoMessage() {}
+
+func (x *CreateScoreboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scoreboard_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateScoreboardRequest.ProtoReflect.Descriptor instead.
+func (*CreateScoreboardRequest) Descriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateScoreboardRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type UpdateScoreboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateScoreboardRequest) Reset() {
+	*x = UpdateScoreboardRequest{}
+	mi := &file_scoreboard_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateScoreboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateScoreboardRequest) ProtoMessage() {}
+
+func (x *UpdateScoreboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scoreboard_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateScoreboardRequest.ProtoReflect.Descriptor instead.
+func (*UpdateScoreboardRequest) Descriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateScoreboardRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateScoreboardRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteScoreboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteScoreboardRequest) Reset() {
+	*x = DeleteScoreboardRequest{}
+	mi := &file_scoreboard_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteScoreboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteScoreboardRequest) ProtoMessage() {}
+
+func (x *DeleteScoreboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scoreboard_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteScoreboardRequest.ProtoReflect.Descriptor instead.
+func (*DeleteScoreboardRequest) Descriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteScoreboardRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScoreboardId  string                 `protobuf:"bytes,1,opt,name=scoreboard_id,json=scoreboardId,proto3" json:"scoreboard_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEntryRequest) Reset() {
+	*x = GetEntryRequest{}
+	mi := &file_scoreboard_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEntryRequest) ProtoMessage() {}
+
+func (x *GetEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scoreboard_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEntryRequest.ProtoReflect.Descriptor instead.
+func (*GetEntryRequest) Descriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetEntryRequest) GetScoreboardId() string {
+	if x != nil {
+		return x.ScoreboardId
+	}
+	return ""
+}
+
+func (x *GetEntryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type CreateEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScoreboardId  string                 `protobuf:"bytes,1,opt,name=scoreboard_id,json=scoreboardId,proto3" json:"scoreboard_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Score         int64                  `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateEntryRequest) Reset() {
+	*x = CreateEntryRequest{}
+	mi := &file_scoreboard_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateEntryRequest) ProtoMessage() {}
+
+func (x *CreateEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scoreboard_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateEntryRequest.ProtoReflect.Descriptor instead.
+func (*CreateEntryRequest) Descriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateEntryRequest) GetScoreboardId() string {
+	if x != nil {
+		return x.ScoreboardId
+	}
+	return ""
+}
+
+func (x *CreateEntryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateEntryRequest) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type UpdateEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScoreboardId  string                 `protobuf:"bytes,1,opt,name=scoreboard_id,json=scoreboardId,proto3" json:"scoreboard_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Score         int64                  `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateEntryRequest) Reset() {
+	*x = UpdateEntryRequest{}
+	mi := &file_scoreboard_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEntryRequest) ProtoMessage() {}
+
+func (x *UpdateEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scoreboard_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEntryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEntryRequest) Descriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateEntryRequest) GetScoreboardId() string {
+	if x != nil {
+		return x.ScoreboardId
+	}
+	return ""
+}
+
+func (x *UpdateEntryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateEntryRequest) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type DeleteEntryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScoreboardId  string                 `protobuf:"bytes,1,opt,name=scoreboard_id,json=scoreboardId,proto3" json:"scoreboard_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEntryRequest) Reset() {
+	*x = DeleteEntryRequest{}
+	mi := &file_scoreboard_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEntryRequest) ProtoMessage() {}
+
+func (x *DeleteEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scoreboard_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEntryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEntryRequest) Descriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteEntryRequest) GetScoreboardId() string {
+	if x != nil {
+		return x.ScoreboardId
+	}
+	return ""
+}
+
+func (x *DeleteEntryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type SubmitScoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScoreboardId  string                 `protobuf:"bytes,1,opt,name=scoreboard_id,json=scoreboardId,proto3" json:"scoreboard_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Score         int64                  `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitScoreRequest) Reset() {
+	*x = SubmitScoreRequest{}
+	mi := &file_scoreboard_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitScoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitScoreRequest) ProtoMessage() {}
+
+func (x *SubmitScoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scoreboard_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitScoreRequest.ProtoReflect.Descriptor instead.
+func (*SubmitScoreRequest) Descriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SubmitScoreRequest) GetScoreboardId() string {
+	if x != nil {
+		return x.ScoreboardId
+	}
+	return ""
+}
+
+func (x *SubmitScoreRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SubmitScoreRequest) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type GetLeaderboardRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ScoreboardId string                 `protobuf:"bytes,1,opt,name=scoreboard_id,json=scoreboardId,proto3" json:"scoreboard_id,omitempty"`
+	// limit caps the number of standings; zero returns the whole board.
+	Limit         int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	RankBy        RankBy `protobuf:"varint,3,opt,name=rank_by,json=rankBy,proto3,enum=scoreboard.v1.RankBy" json:"rank_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLeaderboardRequest) Reset() {
+	*x = GetLeaderboardRequest{}
+	mi := &file_scoreboard_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardRequest) ProtoMessage() {}
+
+func (x *GetLeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scoreboard_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetLeaderboardRequest) GetScoreboardId() string {
+	if x != nil {
+		return x.ScoreboardId
+	}
+	return ""
+}
+
+func (x *GetLeaderboardRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetLeaderboardRequest) GetRankBy() RankBy {
+	if x != nil {
+		return x.RankBy
+	}
+	return RankBy_RANK_BY_UNSPECIFIED
+}
+
+type GetStandingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScoreboardId  string                 `protobuf:"bytes,1,opt,name=scoreboard_id,json=scoreboardId,proto3" json:"scoreboard_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStandingRequest) Reset() {
+	*x = GetStandingRequest{}
+	mi := &file_scoreboard_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStandingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStandingRequest) ProtoMessage() {}
+
+func (x *GetStandingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scoreboard_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStandingRequest.ProtoReflect.Descriptor instead.
+func (*GetStandingRequest) Descriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetStandingRequest) GetScoreboardId() string {
+	if x != nil {
+		return x.ScoreboardId
+	}
+	return ""
+}
+
+func (x *GetStandingRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type WatchLeaderboardRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ScoreboardId string                 `protobuf:"bytes,1,opt,name=scoreboard_id,json=scoreboardId,proto3" json:"scoreboard_id,omitempty"`
+	// limit is the size of the snapshot; it defaults to 10.
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// after_event_id resumes a stream after the last event_id received.
+	AfterEventId  *int64 `protobuf:"varint,3,opt,name=after_event_id,json=afterEventId,proto3,oneof" json:"after_event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchLeaderboardRequest) Reset() {
+	*x = WatchLeaderboardRequest{}
+	mi := &file_scoreboard_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchLeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchLeaderboardRequest) ProtoMessage() {}
+
+func (x *WatchLeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scoreboard_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchLeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*WatchLeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *WatchLeaderboardRequest) GetScoreboardId() string {
+	if x != nil {
+		return x.ScoreboardId
+	}
+	return ""
+}
+
+func (x *WatchLeaderboardRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *WatchLeaderboardRequest) GetAfterEventId() int64 {
+	if x != nil && x.AfterEventId != nil {
+		return *x.AfterEventId
+	}
+	return 0
+}
+
+type LeaderboardUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event_id is the position of the change in the event log, or zero for a
+	// snapshot.
+	EventId int64 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// Types that are valid to be assigned to Update:
+	//
+	//	*LeaderboardUpdate_Snapshot
+	//	*LeaderboardUpdate_Standing
+	//	*LeaderboardUpdate_RemovedUserId
+	//	*LeaderboardUpdate_Renamed
+	//	*LeaderboardUpdate_DeletedScoreboardId
+	Update        isLeaderboardUpdate_Update `protobuf_oneof:"update"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardUpdate) Reset() {
+	*x = LeaderboardUpdate{}
+	mi := &file_scoreboard_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardUpdate) ProtoMessage() {}
+
+func (x *LeaderboardUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_scoreboard_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardUpdate.ProtoReflect.Descriptor instead.
+func (*LeaderboardUpdate) Descriptor() ([]byte, []int) {
+	return file_scoreboard_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *LeaderboardUpdate) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *LeaderboardUpdate) GetUpdate() isLeaderboardUpdate_Update {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *LeaderboardUpdate) GetSnapshot() *Leaderboard {
+	if x != nil {
+		if x, ok := x.Update.(*LeaderboardUpdate_Snapshot); ok {
+			return x.Snapshot
+		}
+	}
+	return nil
+}
+
+func (x *LeaderboardUpdate) GetStanding() *Standing {
+	if x != nil {
+		if x, ok := x.Update.(*LeaderboardUpdate_Standing); ok {
+			return x.Standing
+		}
+	}
+	return nil
+}
+
+func (x *LeaderboardUpdate) GetRemovedUserId() string {
+	if x != nil {
+		if x, ok := x.Update.(*LeaderboardUpdate_RemovedUserId); ok {
+			return x.RemovedUserId
+		}
+	}
+	return ""
+}
+
+func (x *LeaderboardUpdate) GetRenamed() *Scoreboard {
+	if x != nil {
+		if x, ok := x.Update.(*LeaderboardUpdate_Renamed); ok {
+			return x.Renamed
+		}
+	}
+	return nil
+}
+
+func (x *LeaderboardUpdate) GetDeletedScoreboardId() string {
+	if x != nil {
+		if x, ok := x.Update.(*LeaderboardUpdate_DeletedScoreboardId); ok {
+			return x.DeletedScoreboardId
+		}
+	}
+	return ""
+}
+
+type isLeaderboardUpdate_Update interface {
+	isLeaderboardUpdate_Update()
+}
+
+type LeaderboardUpdate_Snapshot struct {
+	Snapshot *Leaderboard `protobuf:"bytes,2,opt,name=snapshot,proto3,oneof"`
+}
+
+type LeaderboardUpdate_Standing struct {
+	Standing *Standing `protobuf:"bytes,3,opt,name=standing,proto3,oneof"`
+}
+
+type LeaderboardUpdate_RemovedUserId struct {
+	RemovedUserId string `protobuf:"bytes,4,opt,name=removed_user_id,json=removedUserId,proto3,oneof"`
+}
+
+type LeaderboardUpdate_Renamed struct {
+	Renamed *Scoreboard `protobuf:"bytes,5,opt,name=renamed,proto3,oneof"`
+}
+
+type LeaderboardUpdate_DeletedScoreboardId struct {
+	DeletedScoreboardId string `protobuf:"bytes,6,opt,name=deleted_scoreboard_id,json=deletedScoreboardId,proto3,oneof"`
+}
+
+func (*LeaderboardUpdate_Snapshot) isLeaderboardUpdate_Update() {}
+
+func (*LeaderboardUpdate_Standing) isLeaderboardUpdate_Update() {}
+
+func (*LeaderboardUpdate_RemovedUserId) isLeaderboardUpdate_Update() {}
+
+func (*LeaderboardUpdate_Renamed) isLeaderboardUpdate_Update() {}
+
+func (*LeaderboardUpdate_DeletedScoreboardId) isLeaderboardUpdate_Update() {}
+
+var File_scoreboard_proto protoreflect.FileDescriptor
+
+const file_scoreboard_proto_rawDesc = "" +
+	"\n" +
+	"\x10scoreboard.proto\x12\rscoreboard.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa6\x01\n" +
+	"\n" +
+	"Scoreboard\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xd1\x01\n" +
+	"\x05Entry\x12#\n" +
+	"\rscoreboard_id\x18\x01 \x01(\tR\fscoreboardId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x03R\x05score\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"k\n" +
+	"\bHandicap\x12\x19\n" +
+	"\x05class\x18\x01 \x01(\tH\x00R\x05class\x88\x01\x01\x12\x1a\n" +
+	"\bhandicap\x18\x02 \x01(\x01R\bhandicap\x12\x1e\n" +
+	"\n" +
+	"multiplier\x18\x03 \x01(\x01R\n" +
+	"multiplierB\b\n" +
+	"\x06_class\"\xa3\x02\n" +
+	"\bStanding\x12*\n" +
+	"\x05entry\x18\x01 \x01(\v2\x14.scoreboard.v1.EntryR\x05entry\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x03R\x04rank\x12'\n" +
+	"\x0feffective_score\x18\x03 \x01(\x01R\x0eeffectiveScore\x12(\n" +
+	"\radjusted_rank\x18\x04 \x01(\x03H\x00R\fadjustedRank\x88\x01\x01\x12*\n" +
+	"\x0eadjusted_score\x18\x05 \x01(\x01H\x01R\radjustedScore\x88\x01\x01\x123\n" +
+	"\bhandicap\x18\x06 \x01(\v2\x17.scoreboard.v1.HandicapR\bhandicapB\x10\n" +
+	"\x0e_adjusted_rankB\x11\n" +
+	"\x0f_adjusted_score\"D\n" +
+	"\vLeaderboard\x125\n" +
+	"\tstandings\x18\x01 \x03(\v2\x17.scoreboard.v1.StandingR\tstandings\"\x18\n" +
+	"\x16ListScoreboardsRequest\"V\n" +
+	"\x17ListScoreboardsResponse\x12;\n" +
+	"\vscoreboards\x18\x01 \x03(\v2\x19.scoreboard.v1.ScoreboardR\vscoreboards\"&\n" +
+	"\x14GetScoreboardRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"-\n" +
+	"\x17CreateScoreboardRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"=\n" +
+	"\x17UpdateScoreboardRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\")\n" +
+	"\x17DeleteScoreboardRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"O\n" +
+	"\x0fGetEntryRequest\x12#\n" +
+	"\rscoreboard_id\x18\x01 \x01(\tR\fscoreboardId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"h\n" +
+	"\x12CreateEntryRequest\x12#\n" +
+	"\rscoreboard_id\x18\x01 \x01(\tR\fscoreboardId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x03R\x05score\"h\n" +
+	"\x12UpdateEntryRequest\x12#\n" +
+	"\rscoreboard_id\x18\x01 \x01(\tR\fscoreboardId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x03R\x05score\"R\n" +
+	"\x12DeleteEntryRequest\x12#\n" +
+	"\rscoreboard_id\x18\x01 \x01(\tR\fscoreboardId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"h\n" +
+	"\x12SubmitScoreRequest\x12#\n" +
+	"\rscoreboard_id\x18\x01 \x01(\tR\fscoreboardId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x03R\x05score\"\x82\x01\n" +
+	"\x15GetLeaderboardRequest\x12#\n" +
+	"\rscoreboard_id\x18\x01 \x01(\tR\fscoreboardId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12.\n" +
+	"\arank_by\x18\x03 \x01(\x0e2\x15.scoreboard.v1.RankByR\x06rankBy\"R\n" +
+	"\x12GetStandingRequest\x12#\n" +
+	"\rscoreboard_id\x18\x01 \x01(\tR\fscoreboardId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x92\x01\n" +
+	"\x17WatchLeaderboardRequest\x12#\n" +
+	"\rscoreboard_id\x18\x01 \x01(\tR\fscoreboardId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12)\n" +
+	"\x0eafter_event_id\x18\x03 \x01(\x03H\x00R\fafterEventId\x88\x01\x01B\x11\n" +
+	"\x0f_after_event_id\"\xc0\x02\n" +
+	"\x11LeaderboardUpdate\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x128\n" +
+	"\bsnapshot\x18\x02 \x01(\v2\x1a.scoreboard.v1.LeaderboardH\x00R\bsnapshot\x125\n" +
+	"\bstanding\x18\x03 \x01(\v2\x17.scoreboard.v1.StandingH\x00R\bstanding\x12(\n" +
+	"\x0fremoved_user_id\x18\x04 \x01(\tH\x00R\rremovedUserId\x125\n" +
+	"\arenamed\x18\x05 \x01(\v2\x19.scoreboard.v1.ScoreboardH\x00R\arenamed\x124\n" +
+	"\x15deleted_scoreboard_id\x18\x06 \x01(\tH\x00R\x13deletedScoreboardIdB\b\n" +
+	"\x06update*H\n" +
+	"\x06RankBy\x12\x17\n" +
+	"\x13RANK_BY_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vRANK_BY_RAW\x10\x01\x12\x14\n" +
+	"\x10RANK_BY_ADJUSTED\x10\x022\xab\b\n" +
+	"\x11ScoreboardService\x12`\n" +
+	"\x0fListScoreboards\x12%.scoreboard.v1.ListScoreboardsRequest\x1a&.scoreboard.v1.ListScoreboardsResponse\x12O\n" +
+	"\rGetScoreboard\x12#.scoreboard.v1.GetScoreboardRequest\x1a\x19.scoreboard.v1.Scoreboard\x12U\n" +
+	"\x10CreateScoreboard\x12&.scoreboard.v1.CreateScoreboardRequest\x1a\x19.scoreboard.v1.Scoreboard\x12U\n" +
+	"\x10UpdateScoreboard\x12&.scoreboard.v1.UpdateScoreboardRequest\x1a\x19.scoreboard.v1.Scoreboard\x12R\n" +
+	"\x10DeleteScoreboard\x12&.scoreboard.v1.DeleteScoreboardRequest\x1a\x16.google.protobuf.Empty\x12@\n" +
+	"\bGetEntry\x12\x1e.scoreboard.v1.GetEntryRequest\x1a\x14.scoreboard.v1.Entry\x12F\n" +
+	"\vCreateEntry\x12!.scoreboard.v1.CreateEntryRequest\x1a\x14.scoreboard.v1.Entry\x12F\n" +
+	"\vUpdateEntry\x12!.scoreboard.v1.UpdateEntryRequest\x1a\x14.scoreboard.v1.Entry\x12H\n" +
+	"\vDeleteEntry\x12!.scoreboard.v1.DeleteEntryRequest\x1a\x16.google.protobuf.Empty\x12F\n" +
+	"\vSubmitScore\x12!.scoreboard.v1.SubmitScoreRequest\x1a\x14.scoreboard.v1.Entry\x12R\n" +
+	"\x0eGetLeaderboard\x12$.scoreboard.v1.GetLeaderboardRequest\x1a\x1a.scoreboard.v1.Leaderboard\x12I\n" +
+	"\vGetStanding\x12!.scoreboard.v1.GetStandingRequest\x1a\x17.scoreboard.v1.Standing\x12^\n" +
+	"\x10WatchLeaderboard\x12&.scoreboard.v1.WatchLeaderboardRequest\x1a .scoreboard.v1.LeaderboardUpdate0\x01B!Z\x1fscoreboard-api/internal/rpc;rpcb\x06proto3"
+
+var (
+	file_scoreboard_proto_rawDescOnce sync.Once
+	file_scoreboard_proto_rawDescData []byte
+)
+
+func file_scoreboard_proto_rawDescGZIP() []byte {
+	file_scoreboard_proto_rawDescOnce.Do(func() {
+		file_scoreboard_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_scoreboard_proto_rawDesc), len(file_scoreboard_proto_rawDesc)))
+	})
+	return file_scoreboard_proto_rawDescData
+}
+
+var file_scoreboard_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_scoreboard_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_scoreboard_proto_goTypes = []any{
+	(RankBy)(0),                     // 0: scoreboard.v1.RankBy
+	(*Scoreboard)(nil),              // 1: scoreboard.v1.Scoreboard
+	(*Entry)(nil),                   // 2: scoreboard.v1.Entry
+	(*Handicap)(nil),                // 3: scoreboard.v1.Handicap
+	(*Standing)(nil),                // 4: scoreboard.v1.Standing
+	(*Leaderboard)(nil),             // 5: scoreboard.v1.Leaderboard
+	(*ListScoreboardsRequest)(nil),  // 6: scoreboard.v1.ListScoreboardsRequest
+	(*ListScoreboardsResponse)(nil), // 7: scoreboard.v1.ListScoreboardsResponse
+	(*GetScoreboardRequest)(nil),    // 8: scoreboard.v1.GetScoreboardRequest
+	(*CreateScoreboardRequest)(nil), // 9: scoreboard.v1.CreateScoreboardRequest
+	(*UpdateScoreboardRequest)(nil), // 10: scoreboard.v1.UpdateScoreboardRequest
+	(*DeleteScoreboardRequest)(nil), // 11: scoreboard.v1.DeleteScoreboardRequest
+	(*GetEntryRequest)(nil),         // 12: scoreboard.v1.GetEntryRequest
+	(*CreateEntryRequest)(nil),      // 13: scoreboard.v1.CreateEntryRequest
+	(*UpdateEntryRequest)(nil),      // 14: scoreboard.v1.UpdateEntryRequest
+	(*DeleteEntryRequest)(nil),      // 15: scoreboard.v1.DeleteEntryRequest
+	(*SubmitScoreRequest)(nil),      // 16: scoreboard.v1.SubmitScoreRequest
+	(*GetLeaderboardRequest)(nil),   // 17: scoreboard.v1.GetLeaderboardRequest
+	(*GetStandingRequest)(nil),      // 18: scoreboard.v1.GetStandingRequest
+	(*WatchLeaderboardRequest)(nil), // 19: scoreboard.v1.WatchLeaderboardRequest
+	(*LeaderboardUpdate)(nil),       // 20: scoreboard.v1.LeaderboardUpdate
+	(*timestamppb.Timestamp)(nil),   // 21: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),           // 22: google.protobuf.Empty
+}
+var file_scoreboard_proto_depIdxs = []int32{
+	21, // 0: scoreboard.v1.Scoreboard.created_at:type_name -> google.protobuf.Timestamp
+	21, // 1: scoreboard.v1.Scoreboard.updated_at:type_name -> google.protobuf.Timestamp
+	21, // 2: scoreboard.v1.Entry.created_at:type_name -> google.protobuf.Timestamp
+	21, // 3: scoreboard.v1.Entry.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 4: scoreboard.v1.Standing.entry:type_name -> scoreboard.v1.Entry
+	3,  // 5: scoreboard.v1.Standing.handicap:type_name -> scoreboard.v1.Handicap
+	4,  // 6: scoreboard.v1.Leaderboard.standings:type_name -> scoreboard.v1.Standing
+	1,  // 7: scoreboard.v1.ListScoreboardsResponse.scoreboards:type_name -> scoreboard.v1.Scoreboard
+	0,  // 8: scoreboard.v1.GetLeaderboardRequest.rank_by:type_name -> scoreboard.v1.RankBy
+	5,  // 9: scoreboard.v1.LeaderboardUpdate.snapshot:type_name -> scoreboard.v1.Leaderboard
+	4,  // 10: scoreboard.v1.LeaderboardUpdate.standing:type_name -> scoreboard.v1.Standing
+	1,  // 11: scoreboard.v1.LeaderboardUpdate.renamed:type_name -> scoreboard.v1.Scoreboard
+	6,  // 12: scoreboard.v1.ScoreboardService.ListScoreboards:input_type -> scoreboard.v1.ListScoreboardsRequest
+	8,  // 13: scoreboard.v1.ScoreboardService.GetScoreboard:input_type -> scoreboard.v1.GetScoreboardRequest
+	9,  // 14: scoreboard.v1.ScoreboardService.CreateScoreboard:input_type -> scoreboard.v1.CreateScoreboardRequest
+	10, // 15: scoreboard.v1.ScoreboardService.UpdateScoreboard:input_type -> scoreboard.v1.UpdateScoreboardRequest
+	11, // 16: scoreboard.v1.ScoreboardService.DeleteScoreboard:input_type -> scoreboard.v1.DeleteScoreboardRequest
+	12, // 17: scoreboard.v1.ScoreboardService.GetEntry:input_type -> scoreboard.v1.GetEntryRequest
+	13, // 18: scoreboard.v1.ScoreboardService.CreateEntry:input_type -> scoreboard.v1.CreateEntryRequest
+	14, // 19: scoreboard.v1.ScoreboardService.UpdateEntry:input_type -> scoreboard.v1.UpdateEntryRequest
+	15, // 20: scoreboard.v1.ScoreboardService.DeleteEntry:input_type -> scoreboard.v1.DeleteEntryRequest
+	16, // 21: scoreboard.v1.ScoreboardService.SubmitScore:input_type -> scoreboard.v1.SubmitScoreRequest
+	17, // 22: scoreboard.v1.ScoreboardService.GetLeaderboard:input_type -> scoreboard.v1.GetLeaderboardRequest
+	18, // 23: scoreboard.v1.ScoreboardService.GetStanding:input_type -> scoreboard.v1.GetStandingRequest
+	19, // 24: scoreboard.v1.ScoreboardService.WatchLeaderboard:input_type -> scoreboard.v1.WatchLeaderboardRequest
+	7,  // 25: scoreboard.v1.ScoreboardService.ListScoreboards:output_type -> scoreboard.v1.ListScoreboardsResponse
+	1,  // 26: scoreboard.v1.ScoreboardService.GetScoreboard:output_type -> scoreboard.v1.Scoreboard
+	1,  // 27: scoreboard.v1.ScoreboardService.CreateScoreboard:output_type -> scoreboard.v1.Scoreboard
+	1,  // 28: scoreboard.v1.ScoreboardService.UpdateScoreboard:output_type -> scoreboard.v1.Scoreboard
+	22, // 29: scoreboard.v1.ScoreboardService.DeleteScoreboard:output_type -> google.protobuf.Empty
+	2,  // 30: scoreboard.v1.ScoreboardService.GetEntry:output_type -> scoreboard.v1.Entry
+	2,  // 31: scoreboard.v1.ScoreboardService.CreateEntry:output_type -> scoreboard.v1.Entry
+	2,  // 32: scoreboard.v1.ScoreboardService.UpdateEntry:output_type -> scoreboard.v1.Entry
+	22, // 33: scoreboard.v1.ScoreboardService.DeleteEntry:output_type -> google.protobuf.Empty
+	2,  // 34: scoreboard.v1.ScoreboardService.SubmitScore:output_type -> scoreboard.v1.Entry
+	5,  // 35: scoreboard.v1.ScoreboardService.GetLeaderboard:output_type -> scoreboard.v1.Leaderboard
+	4,  // 36: scoreboard.v1.ScoreboardService.GetStanding:output_type -> scoreboard.v1.Standing
+	20, // 37: scoreboard.v1.ScoreboardService.WatchLeaderboard:output_type -> scoreboard.v1.LeaderboardUpdate
+	25, // [25:38] is the sub-list for method output_type
+	12, // [12:25] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_scoreboard_proto_init() }
+func file_scoreboard_proto_init() {
+	if File_scoreboard_proto != nil {
+		return
+	}
+	file_scoreboard_proto_msgTypes[2].OneofWrappers = []any{}
+	file_scoreboard_proto_msgTypes[3].OneofWrappers = []any{}
+	file_scoreboard_proto_msgTypes[18].OneofWrappers = []any{}
+	file_scoreboard_proto_msgTypes[19].OneofWrappers = []any{
+		(*LeaderboardUpdate_Snapshot)(nil),
+		(*LeaderboardUpdate_Standing)(nil),
+		(*LeaderboardUpdate_RemovedUserId)(nil),
+		(*LeaderboardUpdate_Renamed)(nil),
+		(*LeaderboardUpdate_DeletedScoreboardId)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_scoreboard_proto_rawDesc), len(file_scoreboard_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_scoreboard_proto_goTypes,
+		DependencyIndexes: file_scoreboard_proto_depIdxs,
+		EnumInfos:         file_scoreboard_proto_enumTypes,
+		MessageInfos:      file_scoreboard_proto_msgTypes,
+	}.Build()
+	File_scoreboard_proto = out.File
+	file_scoreboard_proto_goTypes = nil
+	file_scoreboard_proto_depIdxs = nil
+}
@@ -0,0 +1,600 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: scoreboard.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ScoreboardService_ListScoreboards_FullMethodName  = "/scoreboard.v1.ScoreboardService/ListScoreboards"
+	ScoreboardService_GetScoreboard_FullMethodName    = "/scoreboard.v1.ScoreboardService/GetScoreboard"
+	ScoreboardService_CreateScoreboard_FullMethodName = "/scoreboard.v1.ScoreboardService/CreateScoreboard"
+	ScoreboardService_UpdateScoreboard_FullMethodName = "/scoreboard.v1.ScoreboardService/UpdateScoreboard"
+	ScoreboardService_DeleteScoreboard_FullMethodName = "/scoreboard.v1.ScoreboardService/DeleteScoreboard"
+	ScoreboardService_GetEntry_FullMethodName         = "/scoreboard.v1.ScoreboardService/GetEntry"
+	ScoreboardService_CreateEntry_FullMethodName      = "/scoreboard.v1.ScoreboardService/CreateEntry"
+	ScoreboardService_UpdateEntry_FullMethodName      = "/scoreboard.v1.ScoreboardService/UpdateEntry"
+	ScoreboardService_DeleteEntry_FullMethodName      = "/scoreboard.v1.ScoreboardService/DeleteEntry"
+	ScoreboardService_SubmitScore_FullMethodName      = "/scoreboard.v1.ScoreboardService/SubmitScore"
+	ScoreboardService_GetLeaderboard_FullMethodName   = "/scoreboard.v1.ScoreboardService/GetLeaderboard"
+	ScoreboardService_GetStanding_FullMethodName      = "/scoreboard.v1.ScoreboardService/GetStanding"
+	ScoreboardService_WatchLeaderboard_FullMethodName = "/scoreboard.v1.ScoreboardService/WatchLeaderboard"
+)
+
+// ScoreboardServiceClient is the client API for ScoreboardService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ScoreboardService serves the same scoreboards, entries and leaderboards as
+// the REST API.
+type ScoreboardServiceClient interface {
+	ListScoreboards(ctx context.Context, in *ListScoreboardsRequest, opts ...grpc.CallOption) (*ListScoreboardsResponse, error)
+	GetScoreboard(ctx context.Context, in *GetScoreboardRequest, opts ...grpc.CallOption) (*Scoreboard, error)
+	CreateScoreboard(ctx context.Context, in *CreateScoreboardRequest, opts ...grpc.CallOption) (*Scoreboard, error)
+	UpdateScoreboard(ctx context.Context, in *UpdateScoreboardRequest, opts ...grpc.CallOption) (*Scoreboard, error)
+	DeleteScoreboard(ctx context.Context, in *DeleteScoreboardRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetEntry(ctx context.Context, in *GetEntryRequest, opts ...grpc.CallOption) (*Entry, error)
+	CreateEntry(ctx context.Context, in *CreateEntryRequest, opts ...grpc.CallOption) (*Entry, error)
+	UpdateEntry(ctx context.Context, in *UpdateEntryRequest, opts ...grpc.CallOption) (*Entry, error)
+	DeleteEntry(ctx context.Context, in *DeleteEntryRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// SubmitScore applies a score using the scoreboard's submission rule,
+	// creating the entry when needed.
+	SubmitScore(ctx context.Context, in *SubmitScoreRequest, opts ...grpc.CallOption) (*Entry, error)
+	GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*Leaderboard, error)
+	GetStanding(ctx context.Context, in *GetStandingRequest, opts ...grpc.CallOption) (*Standing, error)
+	// WatchLeaderboard streams the changes of a scoreboard. A new watcher first
+	// gets a snapshot of the top of the board. A watcher resuming with
+	// after_event_id instead gets the current standing of every player changed
+	// since that event. The stream ends when the scoreboard is deleted.
+	WatchLeaderboard(ctx context.Context, in *WatchLeaderboardRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LeaderboardUpdate], error)
+}
+
+type scoreboardServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScoreboardServiceClient(cc grpc.ClientConnInterface) ScoreboardServiceClient {
+	return &scoreboardServiceClient{cc}
+}
+
+func (c *scoreboardServiceClient) ListScoreboards(ctx context.Context, in *ListScoreboardsRequest, opts ...grpc.CallOption) (*ListScoreboardsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScoreboardsResponse)
+	err := c.cc.Invoke(ctx, ScoreboardService_ListScoreboards_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scoreboardServiceClient) GetScoreboard(ctx context.Context, in *GetScoreboardRequest, opts ...grpc.CallOption) (*Scoreboard, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Scoreboard)
+	err := c.cc.Invoke(ctx, ScoreboardService_GetScoreboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scoreboardServiceClient) CreateScoreboard(ctx context.Context, in *CreateScoreboardRequest, opts ...grpc.CallOption) (*Scoreboard, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Scoreboard)
+	err := c.cc.Invoke(ctx, ScoreboardService_CreateScoreboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scoreboardServiceClient) UpdateScoreboard(ctx context.Context, in *UpdateScoreboardRequest, opts ...grpc.CallOption) (*Scoreboard, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Scoreboard)
+	err := c.cc.Invoke(ctx, ScoreboardService_UpdateScoreboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scoreboardServiceClient) DeleteScoreboard(ctx context.Context, in *DeleteScoreboardRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ScoreboardService_DeleteScoreboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scoreboardServiceClient) GetEntry(ctx context.Context, in *GetEntryRequest, opts ...grpc.CallOption) (*Entry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Entry)
+	err := c.cc.Invoke(ctx, ScoreboardService_GetEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scoreboardServiceClient) CreateEntry(ctx context.Context, in *CreateEntryRequest, opts ...grpc.CallOption) (*Entry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Entry)
+	err := c.cc.Invoke(ctx, ScoreboardService_CreateEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scoreboardServiceClient) UpdateEntry(ctx context.Context, in *UpdateEntryRequest, opts ...grpc.CallOption) (*Entry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Entry)
+	err := c.cc.Invoke(ctx, ScoreboardService_UpdateEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scoreboardServiceClient) DeleteEntry(ctx context.Context, in *DeleteEntryRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ScoreboardService_DeleteEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scoreboardServiceClient) SubmitScore(ctx context.Context, in *SubmitScoreRequest, opts ...grpc.CallOption) (*Entry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Entry)
+	err := c.cc.Invoke(ctx, ScoreboardService_SubmitScore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scoreboardServiceClient) GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*Leaderboard, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Leaderboard)
+	err := c.cc.Invoke(ctx, ScoreboardService_GetLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scoreboardServiceClient) GetStanding(ctx context.Context, in *GetStandingRequest, opts ...grpc.CallOption) (*Standing, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Standing)
+	err := c.cc.Invoke(ctx, ScoreboardService_GetStanding_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scoreboardServiceClient) WatchLeaderboard(ctx context.Context, in *WatchLeaderboardRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LeaderboardUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ScoreboardService_ServiceDesc.Streams[0], ScoreboardService_WatchLeaderboard_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchLeaderboardRequest, LeaderboardUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScoreboardService_WatchLeaderboardClient = grpc.ServerStreamingClient[LeaderboardUpdate]
+
+// ScoreboardServiceServer is the server API for ScoreboardService service.
+// All implementations must embed UnimplementedScoreboardServiceServer
+// for forward compatibility.
+//
+// ScoreboardService serves the same scoreboards, entries and leaderboards as
+// the REST API.
+type ScoreboardServiceServer interface {
+	ListScoreboards(context.Context, *ListScoreboardsRequest) (*ListScoreboardsResponse, error)
+	GetScoreboard(context.Context, *GetScoreboardRequest) (*Scoreboard, error)
+	CreateScoreboard(context.Context, *CreateScoreboardRequest) (*Scoreboard, error)
+	UpdateScoreboard(context.Context, *UpdateScoreboardRequest) (*Scoreboard, error)
+	DeleteScoreboard(context.Context, *DeleteScoreboardRequest) (*emptypb.Empty, error)
+	GetEntry(context.Context, *GetEntryRequest) (*Entry, error)
+	CreateEntry(context.Context, *CreateEntryRequest) (*Entry, error)
+	UpdateEntry(context.Context, *UpdateEntryRequest) (*Entry, error)
+	DeleteEntry(context.Context, *DeleteEntryRequest) (*emptypb.Empty, error)
+	// SubmitScore applies a score using the scoreboard's submission rule,
+	// creating the entry when needed.
+	SubmitScore(context.Context, *SubmitScoreRequest) (*Entry, error)
+	GetLeaderboard(context.Context, *GetLeaderboardRequest) (*Leaderboard, error)
+	GetStanding(context.Context, *GetStandingRequest) (*Standing, error)
+	// WatchLeaderboard streams the changes of a scoreboard. A new watcher first
+	// gets a snapshot of the top of the board. A watcher resuming with
+	// after_event_id instead gets the current standing of every player changed
+	// since that event. The stream ends when the scoreboard is deleted.
+	WatchLeaderboard(*WatchLeaderboardRequest, grpc.ServerStreamingServer[LeaderboardUpdate]) error
+	mustEmbedUnimplementedScoreboardServiceServer()
+}
+
+// UnimplementedScoreboardServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedScoreboardServiceServer struct{}
+
+func (UnimplementedScoreboardServiceServer) ListScoreboards(context.Context, *ListScoreboardsRequest) (*ListScoreboardsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScoreboards not implemented")
+}
+func (UnimplementedScoreboardServiceServer) GetScoreboard(context.Context, *GetScoreboardRequest) (*Scoreboard, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScoreboard not implemented")
+}
+func (UnimplementedScoreboardServiceServer) CreateScoreboard(context.Context, *CreateScoreboardRequest) (*Scoreboard, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateScoreboard not implemented")
+}
+func (UnimplementedScoreboardServiceServer) UpdateScoreboard(context.Context, *UpdateScoreboardRequest) (*Scoreboard, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateScoreboard not implemented")
+}
+func (UnimplementedScoreboardServiceServer) DeleteScoreboard(context.Context, *DeleteScoreboardRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteScoreboard not implemented")
+}
+func (UnimplementedScoreboardServiceServer) GetEntry(context.Context, *GetEntryRequest) (*Entry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEntry not implemented")
+}
+func (UnimplementedScoreboardServiceServer) CreateEntry(context.Context, *CreateEntryRequest) (*Entry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEntry not implemented")
+}
+func (UnimplementedScoreboardServiceServer) UpdateEntry(context.Context, *UpdateEntryRequest) (*Entry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEntry not implemented")
+}
+func (UnimplementedScoreboardServiceServer) DeleteEntry(context.Context, *DeleteEntryRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEntry not implemented")
+}
+func (UnimplementedScoreboardServiceServer) SubmitScore(context.Context, *SubmitScoreRequest) (*Entry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitScore not implemented")
+}
+func (UnimplementedScoreboardServiceServer) GetLeaderboard(context.Context, *GetLeaderboardRequest) (*Leaderboard, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeaderboard not implemented")
+}
+func (UnimplementedScoreboardServiceServer) GetStanding(context.Context, *GetStandingRequest) (*Standing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStanding not implemented")
+}
+func (UnimplementedScoreboardServiceServer) WatchLeaderboard(*WatchLeaderboardRequest, grpc.ServerStreamingServer[LeaderboardUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchLeaderboard not implemented")
+}
+func (UnimplementedScoreboardServiceServer) mustEmbedUnimplementedScoreboardServiceServer() {}
+func (UnimplementedScoreboardServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeScoreboardServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ScoreboardServiceServer will
+// result in compilation errors.
+type UnsafeScoreboardServiceServer interface {
+	mustEmbedUnimplementedScoreboardServiceServer()
+}
+
+func RegisterScoreboardServiceServer(s grpc.ServiceRegistrar, srv ScoreboardServiceServer) {
+	// If the following call pancis, it indicates UnimplementedScoreboardServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ScoreboardService_ServiceDesc, srv)
+}
+
+func _ScoreboardService_ListScoreboards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScoreboardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreboardServiceServer).ListScoreboards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScoreboardService_ListScoreboards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreboardServiceServer).ListScoreboards(ctx, req.(*ListScoreboardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScoreboardService_GetScoreboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScoreboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreboardServiceServer).GetScoreboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScoreboardService_GetScoreboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreboardServiceServer).GetScoreboard(ctx, req.(*GetScoreboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScoreboardService_CreateScoreboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateScoreboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreboardServiceServer).CreateScoreboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScoreboardService_CreateScoreboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreboardServiceServer).CreateScoreboard(ctx, req.(*CreateScoreboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScoreboardService_UpdateScoreboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateScoreboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreboardServiceServer).UpdateScoreboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScoreboardService_UpdateScoreboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreboardServiceServer).UpdateScoreboard(ctx, req.(*UpdateScoreboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScoreboardService_DeleteScoreboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteScoreboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreboardServiceServer).DeleteScoreboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScoreboardService_DeleteScoreboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreboardServiceServer).DeleteScoreboard(ctx, req.(*DeleteScoreboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScoreboardService_GetEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreboardServiceServer).GetEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScoreboardService_GetEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreboardServiceServer).GetEntry(ctx, req.(*GetEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScoreboardService_CreateEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreboardServiceServer).CreateEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScoreboardService_CreateEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreboardServiceServer).CreateEntry(ctx, req.(*CreateEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScoreboardService_UpdateEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreboardServiceServer).UpdateEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScoreboardService_UpdateEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreboardServiceServer).UpdateEntry(ctx, req.(*UpdateEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScoreboardService_DeleteEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreboardServiceServer).DeleteEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScoreboardService_DeleteEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreboardServiceServer).DeleteEntry(ctx, req.(*DeleteEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScoreboardService_SubmitScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreboardServiceServer).SubmitScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScoreboardService_SubmitScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreboardServiceServer).SubmitScore(ctx, req.(*SubmitScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScoreboardService_GetLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreboardServiceServer).GetLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScoreboardService_GetLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreboardServiceServer).GetLeaderboard(ctx, req.(*GetLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScoreboardService_GetStanding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStandingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoreboardServiceServer).GetStanding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScoreboardService_GetStanding_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoreboardServiceServer).GetStanding(ctx, req.(*GetStandingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScoreboardService_WatchLeaderboard_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchLeaderboardRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ScoreboardServiceServer).WatchLeaderboard(m, &grpc.GenericServerStream[WatchLeaderboardRequest, LeaderboardUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScoreboardService_WatchLeaderboardServer = grpc.ServerStreamingServer[LeaderboardUpdate]
+
+// ScoreboardService_ServiceDesc is the grpc.ServiceDesc for ScoreboardService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ScoreboardService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "scoreboard.v1.ScoreboardService",
+	HandlerType: (*ScoreboardServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListScoreboards",
+			Handler:    _ScoreboardService_ListScoreboards_Handler,
+		},
+		{
+			MethodName: "GetScoreboard",
+			Handler:    _ScoreboardService_GetScoreboard_Handler,
+		},
+		{
+			MethodName: "CreateScoreboard",
+			Handler:    _ScoreboardService_CreateScoreboard_Handler,
+		},
+		{
+			MethodName: "UpdateScoreboard",
+			Handler:    _ScoreboardService_UpdateScoreboard_Handler,
+		},
+		{
+			MethodName: "DeleteScoreboard",
+			Handler:    _ScoreboardService_DeleteScoreboard_Handler,
+		},
+		{
+			MethodName: "GetEntry",
+			Handler:    _ScoreboardService_GetEntry_Handler,
+		},
+		{
+			MethodName: "CreateEntry",
+			Handler:    _ScoreboardService_CreateEntry_Handler,
+		},
+		{
+			MethodName: "UpdateEntry",
+			Handler:    _ScoreboardService_UpdateEntry_Handler,
+		},
+		{
+			MethodName: "DeleteEntry",
+			Handler:    _ScoreboardService_DeleteEntry_Handler,
+		},
+		{
+			MethodName: "SubmitScore",
+			Handler:    _ScoreboardService_SubmitScore_Handler,
+		},
+		{
+			MethodName: "GetLeaderboard",
+			Handler:    _ScoreboardService_GetLeaderboard_Handler,
+		},
+		{
+			MethodName: "GetStanding",
+			Handler:    _ScoreboardService_GetStanding_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchLeaderboard",
+			Handler:       _ScoreboardService_WatchLeaderboard_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "scoreboard.proto",
+}
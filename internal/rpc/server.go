@@ -0,0 +1,465 @@
+// Package rpc serves scoreboards over gRPC. It is backed by the same store as
+// the REST handlers and streams leaderboard changes from the same broker as
+// the Server-Sent Events and WebSocket endpoints.
+package rpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative scoreboard.proto
+
+import (
+	"context"
+	"errors"
+
+	"scoreboard-api/internal/scoreboard"
+	"scoreboard-api/internal/stream"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultWatchLimit is the size of the snapshot sent to new watchers.
+const defaultWatchLimit = 10
+
+type Server struct {
+	UnimplementedScoreboardServiceServer
+
+	validator *validator.Validate
+	logger    *zap.Logger
+	store     scoreboard.Store
+	broker    *stream.Broker
+}
+
+func NewServer(v *validator.Validate, logger *zap.Logger, store scoreboard.Store, broker *stream.Broker) *Server {
+	return &Server{
+		validator: v,
+		logger:    logger,
+		store:     store,
+		broker:    broker,
+	}
+}
+
+func (s *Server) ListScoreboards(ctx context.Context, _ *ListScoreboardsRequest) (*ListScoreboardsResponse, error) {
+	scoreboards, err := s.store.List(ctx)
+	if err != nil {
+		return nil, s.error(err)
+	}
+	response := &ListScoreboardsResponse{Scoreboards: make([]*Scoreboard, len(scoreboards))}
+	for index, board := range scoreboards {
+		response.Scoreboards[index] = toScoreboard(board)
+	}
+	return response, nil
+}
+
+func (s *Server) GetScoreboard(ctx context.Context, request *GetScoreboardRequest) (*Scoreboard, error) {
+	id, err := parseUUID("id", request.GetId())
+	if err != nil {
+		return nil, err
+	}
+	board, err := s.store.Get(ctx, id)
+	if err != nil {
+		return nil, s.error(err)
+	}
+	return toScoreboard(board), nil
+}
+
+func (s *Server) CreateScoreboard(ctx context.Context, request *CreateScoreboardRequest) (*Scoreboard, error) {
+	if err := scoreboard.ValidateName(s.validator, request.GetName()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	board, err := s.store.Create(ctx, pgtype.Text{String: request.GetName(), Valid: true})
+	if err != nil {
+		return nil, s.error(err)
+	}
+	return toScoreboard(board), nil
+}
+
+func (s *Server) UpdateScoreboard(ctx context.Context, request *UpdateScoreboardRequest) (*Scoreboard, error) {
+	id, err := parseUUID("id", request.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := scoreboard.ValidateName(s.validator, request.GetName()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	board, err := s.store.Update(ctx, scoreboard.UpdateParams{
+		ID:   id,
+		Name: pgtype.Text{String: request.GetName(), Valid: true},
+	})
+	if err != nil {
+		return nil, s.error(err)
+	}
+	return toScoreboard(board), nil
+}
+
+func (s *Server) DeleteScoreboard(ctx context.Context, request *DeleteScoreboardRequest) (*emptypb.Empty, error) {
+	id, err := parseUUID("id", request.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.Delete(ctx, id); err != nil {
+		return nil, s.error(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) GetEntry(ctx context.Context, request *GetEntryRequest) (*Entry, error) {
+	scoreboardID, userID, err := parseEntryIDs(request.GetScoreboardId(), request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.store.GetEntry(ctx, scoreboardID, userID)
+	if err != nil {
+		return nil, s.error(err)
+	}
+	return toEntry(entry), nil
+}
+
+func (s *Server) CreateEntry(ctx context.Context, request *CreateEntryRequest) (*Entry, error) {
+	scoreboardID, userID, err := parseEntryIDs(request.GetScoreboardId(), request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.store.CreateEntry(ctx, scoreboard.CreateEntryParams{
+		ScoreboardID: scoreboardID,
+		UserID:       userID,
+		Score:        request.GetScore(),
+	})
+	if err != nil {
+		return nil, s.error(err)
+	}
+	return toEntry(entry), nil
+}
+
+func (s *Server) UpdateEntry(ctx context.Context, request *UpdateEntryRequest) (*Entry, error) {
+	scoreboardID, userID, err := parseEntryIDs(request.GetScoreboardId(), request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.store.UpdateEntry(ctx, scoreboard.UpdateEntryParams{
+		ScoreboardID: scoreboardID,
+		UserID:       userID,
+		Score:        request.GetScore(),
+	})
+	if err != nil {
+		return nil, s.error(err)
+	}
+	return toEntry(entry), nil
+}
+
+func (s *Server) DeleteEntry(ctx context.Context, request *DeleteEntryRequest) (*emptypb.Empty, error) {
+	scoreboardID, userID, err := parseEntryIDs(request.GetScoreboardId(), request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.DeleteEntry(ctx, scoreboardID, userID); err != nil {
+		return nil, s.error(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) SubmitScore(ctx context.Context, request *SubmitScoreRequest) (*Entry, error) {
+	scoreboardID, userID, err := parseEntryIDs(request.GetScoreboardId(), request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.store.Submit(ctx, scoreboard.SubmitScoreParams{
+		ScoreboardID: scoreboardID,
+		UserID:       userID,
+		Score:        request.GetScore(),
+	})
+	if err != nil {
+		return nil, s.error(err)
+	}
+	return toEntry(entry), nil
+}
+
+// GetLeaderboard lists the standings of a scoreboard. With a limit and raw
+// ranking only the top of the board is read, without handicaps.
+func (s *Server) GetLeaderboard(ctx context.Context, request *GetLeaderboardRequest) (*Leaderboard, error) {
+	scoreboardID, err := parseUUID("scoreboard_id", request.GetScoreboardId())
+	if err != nil {
+		return nil, err
+	}
+	limit := int(request.GetLimit())
+	if limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+
+	var standings []scoreboard.Standing
+	switch request.GetRankBy() {
+	case RankBy_RANK_BY_UNSPECIFIED, RankBy_RANK_BY_RAW:
+		if limit > 0 {
+			standings, err = s.store.Top(ctx, scoreboardID, limit)
+		} else {
+			standings, err = s.store.Leaderboard(ctx, scoreboardID)
+		}
+	case RankBy_RANK_BY_ADJUSTED:
+		standings, err = s.store.Leaderboard(ctx, scoreboardID)
+		if err == nil {
+			standings = scoreboard.ByAdjusted(standings)
+			if limit > 0 && limit < len(standings) {
+				standings = standings[:limit]
+			}
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "rank_by must be raw or adjusted")
+	}
+	if err != nil {
+		return nil, s.error(err)
+	}
+	return toLeaderboard(standings), nil
+}
+
+// GetStanding returns a player's entry with its raw rank on the scoreboard.
+func (s *Server) GetStanding(ctx context.Context, request *GetStandingRequest) (*Standing, error) {
+	scoreboardID, userID, err := parseEntryIDs(request.GetScoreboardId(), request.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	standing, err := s.store.Standing(ctx, scoreboardID, userID)
+	if err != nil {
+		return nil, s.error(err)
+	}
+	return toStanding(standing), nil
+}
+
+// WatchLeaderboard streams the changes of a scoreboard from the broker. The
+// broker only signals which player changed; the standing sent is read from
+// the store, so it is never older than the change.
+func (s *Server) WatchLeaderboard(request *WatchLeaderboardRequest, watcher ScoreboardService_WatchLeaderboardServer) error {
+	ctx := watcher.Context()
+	scoreboardID, err := parseUUID("scoreboard_id", request.GetScoreboardId())
+	if err != nil {
+		return err
+	}
+	limit := int(request.GetLimit())
+	switch {
+	case limit < 0:
+		return status.Error(codes.InvalidArgument, "limit must not be negative")
+	case limit == 0:
+		limit = defaultWatchLimit
+	}
+	if request.AfterEventId != nil && request.GetAfterEventId() < 0 {
+		return status.Error(codes.InvalidArgument, "after_event_id must be an event ID")
+	}
+	if _, err := s.store.Get(ctx, scoreboardID); err != nil {
+		return s.error(err)
+	}
+
+	// Subscribe before reading the board, so no change falls in between.
+	subscription := s.broker.Subscribe(scoreboardID)
+	defer s.broker.Unsubscribe(subscription)
+
+	var initial []*LeaderboardUpdate
+	if request.AfterEventId == nil {
+		initial, err = s.snapshot(ctx, scoreboardID, limit)
+	} else {
+		initial, err = s.missed(ctx, scoreboardID, request.GetAfterEventId(), limit)
+	}
+	if err != nil {
+		return s.error(err)
+	}
+
+	// sent holds the last event sent for each player. Changes to a player
+	// can be published out of order, and the replay may already cover them.
+	sent := make(map[uuid.UUID]int64)
+	for _, update := range initial {
+		if err := watcher.Send(update); err != nil {
+			return err
+		}
+		if standing := update.GetStanding(); standing != nil {
+			sent[uuid.MustParse(standing.GetEntry().GetUserId())] = update.GetEventId()
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case change, ok := <-subscription.Updates:
+			if !ok {
+				return status.Error(codes.Unavailable, "watcher fell behind; resume with after_event_id")
+			}
+			if change.ID <= sent[change.UserID] {
+				continue
+			}
+			sent[change.UserID] = change.ID
+			update, err := s.update(ctx, scoreboardID, change)
+			if err != nil {
+				return s.error(err)
+			}
+			if update == nil {
+				continue
+			}
+			if err := watcher.Send(update); err != nil {
+				return err
+			}
+			if change.Event == stream.EventDeleted {
+				return nil
+			}
+		}
+	}
+}
+
+// snapshot returns the top of the board. It has no event ID, so a watcher
+// that resumes before any change gets a new snapshot.
+func (s *Server) snapshot(ctx context.Context, scoreboardID uuid.UUID, limit int) ([]*LeaderboardUpdate, error) {
+	standings, err := s.store.Top(ctx, scoreboardID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return []*LeaderboardUpdate{{Update: &LeaderboardUpdate_Snapshot{Snapshot: toLeaderboard(standings)}}}, nil
+}
+
+// missed returns the current standing of every player changed after the
+// event after, in the order of their last change.
+func (s *Server) missed(ctx context.Context, scoreboardID uuid.UUID, after int64, limit int) ([]*LeaderboardUpdate, error) {
+	events, ok, err := stream.Missed(ctx, s.store, scoreboardID, after)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return s.snapshot(ctx, scoreboardID, limit)
+	}
+
+	var updates []*LeaderboardUpdate
+	for _, event := range events {
+		update, err := s.standing(ctx, event.ID, scoreboardID, event.UserID.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		updates = append(updates, update)
+	}
+	return updates, nil
+}
+
+// update turns a broker update into the message sent to watchers. It returns
+// nil for updates watchers do not need, such as a rename of a board deleted
+// since.
+func (s *Server) update(ctx context.Context, scoreboardID uuid.UUID, change stream.Update) (*LeaderboardUpdate, error) {
+	switch change.Event {
+	case stream.EventEntry:
+		return s.standing(ctx, change.ID, scoreboardID, change.UserID)
+	case stream.EventRemoved:
+		return &LeaderboardUpdate{EventId: change.ID, Update: &LeaderboardUpdate_RemovedUserId{RemovedUserId: change.UserID.String()}}, nil
+	case stream.EventRenamed:
+		board, err := s.store.Get(ctx, scoreboardID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &LeaderboardUpdate{EventId: change.ID, Update: &LeaderboardUpdate_Renamed{Renamed: toScoreboard(board)}}, nil
+	case stream.EventDeleted:
+		return &LeaderboardUpdate{EventId: change.ID, Update: &LeaderboardUpdate_DeletedScoreboardId{DeletedScoreboardId: scoreboardID.String()}}, nil
+	}
+	return nil, nil
+}
+
+// standing reads the current standing of a player. A player removed since
+// the event is reported as removed.
+func (s *Server) standing(ctx context.Context, eventID int64, scoreboardID, userID uuid.UUID) (*LeaderboardUpdate, error) {
+	standing, err := s.store.Standing(ctx, scoreboardID, userID)
+	if errors.Is(err, scoreboard.ErrEntryNotFound) {
+		return &LeaderboardUpdate{EventId: eventID, Update: &LeaderboardUpdate_RemovedUserId{RemovedUserId: userID.String()}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &LeaderboardUpdate{EventId: eventID, Update: &LeaderboardUpdate_Standing{Standing: toStanding(standing)}}, nil
+}
+
+// error maps the errors of the store to gRPC status codes the way the REST
+// handlers map them to HTTP statuses.
+func (s *Server) error(err error) error {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return status.Error(codes.NotFound, "scoreboard not found")
+	case errors.Is(err, scoreboard.ErrEntryNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, scoreboard.ErrUnknownEntity):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, scoreboard.ErrEntryExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		s.logger.Error("gRPC request failed", zap.Error(err))
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func parseUUID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "%s must be a UUID", field)
+	}
+	return id, nil
+}
+
+func parseEntryIDs(scoreboardID, userID string) (uuid.UUID, uuid.UUID, error) {
+	board, err := parseUUID("scoreboard_id", scoreboardID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	user, err := parseUUID("user_id", userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	return board, user, nil
+}
+
+func toScoreboard(board scoreboard.Scoreboard) *Scoreboard {
+	return &Scoreboard{
+		Id:        board.ID.String(),
+		Name:      board.Name.String,
+		CreatedAt: timestamppb.New(board.CreatedAt.Time),
+		UpdatedAt: timestamppb.New(board.UpdatedAt.Time),
+	}
+}
+
+func toEntry(entry scoreboard.ScoreboardEntry) *Entry {
+	return &Entry{
+		ScoreboardId: entry.ScoreboardID.String(),
+		UserId:       entry.UserID.String(),
+		Score:        entry.Score,
+		CreatedAt:    timestamppb.New(entry.CreatedAt.Time),
+		UpdatedAt:    timestamppb.New(entry.UpdatedAt.Time),
+	}
+}
+
+func toStanding(standing scoreboard.Standing) *Standing {
+	response := &Standing{
+		Entry:          toEntry(standing.ScoreboardEntry),
+		Rank:           standing.Rank,
+		EffectiveScore: standing.Effective,
+	}
+	// Standings read without handicaps carry no adjusted rank.
+	if standing.AdjustedRank > 0 {
+		adjustedRank, adjusted := standing.AdjustedRank, standing.Adjusted
+		response.AdjustedRank = &adjustedRank
+		response.AdjustedScore = &adjusted
+	}
+	if handicap := standing.Handicap; handicap != nil {
+		response.Handicap = &Handicap{Handicap: handicap.Handicap, Multiplier: handicap.Multiplier}
+		if handicap.Class.Valid {
+			response.Handicap.Class = &handicap.Class.String
+		}
+	}
+	return response
+}
+
+func toLeaderboard(standings []scoreboard.Standing) *Leaderboard {
+	leaderboard := &Leaderboard{Standings: make([]*Standing, len(standings))}
+	for index, standing := range standings {
+		leaderboard.Standings[index] = toStanding(standing)
+	}
+	return leaderboard
+}
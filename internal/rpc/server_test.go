@@ -0,0 +1,160 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"scoreboard-api/internal"
+	"scoreboard-api/internal/memory"
+	"scoreboard-api/internal/scoreboard"
+	"scoreboard-api/internal/stream"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dial serves the memory backend in process and returns a client for it.
+func dial(t *testing.T) ScoreboardServiceClient {
+	t.Helper()
+	store := memory.New().Scoreboards()
+	service := scoreboard.NewServiceWithQuerier(zap.NewNop(), store, store)
+	broker := stream.NewBroker(zap.NewNop(), service)
+	service.Observe(broker)
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	validator := internal.NewValidator()
+	internal.RegisterCustomValidations(validator)
+	RegisterScoreboardServiceServer(server, NewServer(validator, zap.NewNop(), service, broker))
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return NewScoreboardServiceClient(conn)
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	client := dial(t)
+
+	board, err := client.CreateScoreboard(ctx, &CreateScoreboardRequest{Name: "Arcade"})
+	if err != nil {
+		t.Fatalf("CreateScoreboard() error = %v", err)
+	}
+	for _, name := range []string{"", "<b>Arcade</b>"} {
+		if _, err := client.CreateScoreboard(ctx, &CreateScoreboardRequest{Name: name}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("CreateScoreboard(%q) error = %v, want InvalidArgument", name, err)
+		}
+	}
+	if _, err := client.UpdateScoreboard(ctx, &UpdateScoreboardRequest{Id: board.Id, Name: "Arcade!"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("UpdateScoreboard() with markup error = %v, want InvalidArgument", err)
+	}
+	player, rival := uuid.NewString(), uuid.NewString()
+	if _, err := client.CreateEntry(ctx, &CreateEntryRequest{ScoreboardId: board.Id, UserId: player, Score: 10}); err != nil {
+		t.Fatalf("CreateEntry() error = %v", err)
+	}
+	_, err = client.CreateEntry(ctx, &CreateEntryRequest{ScoreboardId: board.Id, UserId: player, Score: 5})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("CreateEntry() twice error = %v, want AlreadyExists", err)
+	}
+	if _, err := client.SubmitScore(ctx, &SubmitScoreRequest{ScoreboardId: board.Id, UserId: rival, Score: 30}); err != nil {
+		t.Fatalf("SubmitScore() error = %v", err)
+	}
+
+	leaderboard, err := client.GetLeaderboard(ctx, &GetLeaderboardRequest{ScoreboardId: board.Id})
+	if err != nil {
+		t.Fatalf("GetLeaderboard() error = %v", err)
+	}
+	standings := leaderboard.GetStandings()
+	if len(standings) != 2 || standings[0].GetEntry().GetUserId() != rival || standings[0].GetRank() != 1 || standings[1].GetRank() != 2 {
+		t.Errorf("GetLeaderboard() = %v, want the rival first", standings)
+	}
+
+	renamed, err := client.UpdateScoreboard(ctx, &UpdateScoreboardRequest{Id: board.Id, Name: "Pinball"})
+	if err != nil || renamed.GetName() != "Pinball" {
+		t.Errorf("UpdateScoreboard() = %v, %v, want the new name", renamed, err)
+	}
+	if _, err := client.DeleteEntry(ctx, &DeleteEntryRequest{ScoreboardId: board.Id, UserId: player}); err != nil {
+		t.Fatalf("DeleteEntry() error = %v", err)
+	}
+	_, err = client.GetEntry(ctx, &GetEntryRequest{ScoreboardId: board.Id, UserId: player})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetEntry() of a deleted entry error = %v, want NotFound", err)
+	}
+	_, err = client.GetScoreboard(ctx, &GetScoreboardRequest{Id: "arcade"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetScoreboard() with a bad ID error = %v, want InvalidArgument", err)
+	}
+	_, err = client.GetScoreboard(ctx, &GetScoreboardRequest{Id: uuid.NewString()})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetScoreboard() of a missing board error = %v, want NotFound", err)
+	}
+}
+
+func TestWatchLeaderboard(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client := dial(t)
+
+	board, err := client.CreateScoreboard(ctx, &CreateScoreboardRequest{Name: "Arcade"})
+	if err != nil {
+		t.Fatalf("CreateScoreboard() error = %v", err)
+	}
+	player := uuid.NewString()
+	if _, err := client.SubmitScore(ctx, &SubmitScoreRequest{ScoreboardId: board.Id, UserId: player, Score: 10}); err != nil {
+		t.Fatalf("SubmitScore() error = %v", err)
+	}
+
+	watch, err := client.WatchLeaderboard(ctx, &WatchLeaderboardRequest{ScoreboardId: board.Id})
+	if err != nil {
+		t.Fatalf("WatchLeaderboard() error = %v", err)
+	}
+	update, err := watch.Recv()
+	if err != nil || len(update.GetSnapshot().GetStandings()) != 1 || update.GetEventId() != 0 {
+		t.Fatalf("first update = %v, %v, want a snapshot of one player", update, err)
+	}
+
+	if _, err := client.SubmitScore(ctx, &SubmitScoreRequest{ScoreboardId: board.Id, UserId: player, Score: 25}); err != nil {
+		t.Fatalf("SubmitScore() error = %v", err)
+	}
+	update, err = watch.Recv()
+	if err != nil || update.GetStanding().GetEntry().GetScore() != 25 || update.GetEventId() == 0 {
+		t.Fatalf("update = %v, %v, want the new score", update, err)
+	}
+	lastEventID := update.GetEventId()
+
+	// A watcher resuming from before the change gets the player's standing.
+	after := lastEventID - 1
+	resumed, err := client.WatchLeaderboard(ctx, &WatchLeaderboardRequest{ScoreboardId: board.Id, AfterEventId: &after})
+	if err != nil {
+		t.Fatalf("WatchLeaderboard() resuming error = %v", err)
+	}
+	update, err = resumed.Recv()
+	if err != nil || update.GetEventId() != lastEventID || update.GetStanding().GetEntry().GetUserId() != player {
+		t.Fatalf("resumed update = %v, %v, want the missed change", update, err)
+	}
+
+	if _, err := client.DeleteScoreboard(ctx, &DeleteScoreboardRequest{Id: board.Id}); err != nil {
+		t.Fatalf("DeleteScoreboard() error = %v", err)
+	}
+	update, err = watch.Recv()
+	if err != nil || update.GetDeletedScoreboardId() != board.Id {
+		t.Fatalf("update = %v, %v, want the board deleted", update, err)
+	}
+	if _, err := watch.Recv(); err == nil {
+		t.Errorf("Recv() after the board was deleted succeeded, want the stream closed")
+	}
+}
@@ -0,0 +1,201 @@
+package scoreboard
+
+import (
+	lru "container/list"
+	"context"
+	"sync"
+
+	"scoreboard-api/internal/rankindex"
+
+	"github.com/google/uuid"
+)
+
+// Cache holds a ranked index for the scoreboards ranked most recently. A
+// board is loaded from the database the first time it is needed and then kept
+// current by observing entry changes, so the cache only sees writes made
+// through this process. Once more than capacity boards are cached, the least
+// recently used one is dropped and loaded again when next needed.
+type Cache struct {
+	capacity int
+
+	// mu guards recent, and boards changing along with it.
+	mu sync.Mutex
+	// boards maps scoreboard IDs to *lru.Element of recent.
+	boards sync.Map
+	// recent holds the *cachedBoard values, most recently used first.
+	recent *lru.List
+}
+
+type cachedBoard struct {
+	id     uuid.UUID
+	mu     sync.RWMutex
+	loaded bool
+	index  *rankindex.Index[ScoreboardEntry]
+}
+
+// loadFunc reads the entries of a scoreboard. It must fail for a scoreboard
+// that does not exist, so that the cache never holds one.
+type loadFunc func(ctx context.Context, scoreboardID uuid.UUID) ([]ScoreboardEntry, error)
+
+func NewCache(capacity int) *Cache {
+	return &Cache{
+		capacity: capacity,
+		recent:   lru.New(),
+	}
+}
+
+// Top returns the first limit standings of a scoreboard.
+func (c *Cache) Top(ctx context.Context, scoreboardID uuid.UUID, limit int, load loadFunc) ([]Standing, error) {
+	var standings []Standing
+	err := c.view(ctx, scoreboardID, load, func(index *rankindex.Index[ScoreboardEntry]) {
+		page := index.Page(0, limit)
+		standings = make([]Standing, len(page))
+		for position, ranked := range page {
+			standings[position] = standingOf(ranked)
+		}
+	})
+	return standings, err
+}
+
+// Standing returns the standing of a player, reporting false when the player
+// has no entry on the scoreboard.
+func (c *Cache) Standing(ctx context.Context, scoreboardID, userID uuid.UUID, load loadFunc) (Standing, bool, error) {
+	var standing Standing
+	found := false
+	err := c.view(ctx, scoreboardID, load, func(index *rankindex.Index[ScoreboardEntry]) {
+		var ranked rankindex.Ranked[ScoreboardEntry]
+		if ranked, found = index.Rank(userID); found {
+			standing = standingOf(ranked)
+		}
+	})
+	return standing, found, err
+}
+
+// Count returns the number of entries on a scoreboard.
+func (c *Cache) Count(ctx context.Context, scoreboardID uuid.UUID, load loadFunc) (int, error) {
+	var count int
+	err := c.view(ctx, scoreboardID, load, func(index *rankindex.Index[ScoreboardEntry]) {
+		count = index.Len()
+	})
+	return count, err
+}
+
+// Forget drops a scoreboard from the cache.
+func (c *Cache) Forget(scoreboardID uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.boards.LoadAndDelete(scoreboardID); ok {
+		c.recent.Remove(element.(*lru.Element))
+	}
+}
+
+// Len returns the number of cached scoreboards.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.recent.Len()
+}
+
+// ObserveEntry applies an entry change to the board's index when the board is
+// cached. An update older than the cached entry is ignored, since changes to
+// the same player can be observed out of order.
+func (c *Cache) ObserveEntry(_ context.Context, change EntryChange) error {
+	element, ok := c.boards.Load(change.ScoreboardID)
+	if !ok {
+		return nil
+	}
+	board := element.(*lru.Element).Value.(*cachedBoard)
+
+	board.mu.Lock()
+	defer board.mu.Unlock()
+	if !board.loaded {
+		return nil
+	}
+	if change.After == nil {
+		board.index.Remove(change.UserID)
+		return nil
+	}
+	if current, ok := board.index.Get(change.UserID); ok && current.UpdatedAt.After(change.After.UpdatedAt.Time) {
+		return nil
+	}
+	board.index.Set(itemOf(*change.After))
+	return nil
+}
+
+// view calls fn with the index of a scoreboard, loading the board when it is
+// not cached yet. The index must not be kept after fn returns.
+func (c *Cache) view(ctx context.Context, scoreboardID uuid.UUID, load loadFunc, fn func(index *rankindex.Index[ScoreboardEntry])) error {
+	board := c.use(scoreboardID)
+
+	board.mu.RLock()
+	if board.loaded {
+		defer board.mu.RUnlock()
+		fn(board.index)
+		return nil
+	}
+	board.mu.RUnlock()
+
+	board.mu.Lock()
+	defer board.mu.Unlock()
+	if !board.loaded {
+		entries, err := load(ctx, scoreboardID)
+		if err != nil {
+			c.drop(board)
+			return err
+		}
+		board.index = rankindex.New[ScoreboardEntry]()
+		for _, entry := range entries {
+			board.index.Set(itemOf(entry))
+		}
+		board.loaded = true
+	}
+	fn(board.index)
+	return nil
+}
+
+// use returns the cached board of a scoreboard, adding an unloaded one when
+// there is none, and marks it as the most recently used. Adding a board past
+// the capacity evicts the least recently used one.
+func (c *Cache) use(scoreboardID uuid.UUID) *cachedBoard {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.boards.Load(scoreboardID); ok {
+		c.recent.MoveToFront(element.(*lru.Element))
+		return element.(*lru.Element).Value.(*cachedBoard)
+	}
+	board := &cachedBoard{id: scoreboardID}
+	c.boards.Store(scoreboardID, c.recent.PushFront(board))
+	for c.recent.Len() > c.capacity {
+		oldest := c.recent.Back()
+		c.recent.Remove(oldest)
+		c.boards.Delete(oldest.Value.(*cachedBoard).id)
+	}
+	return board
+}
+
+// drop removes a board that failed to load, unless it was already replaced.
+func (c *Cache) drop(board *cachedBoard) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.boards.Load(board.id); ok && element.(*lru.Element).Value == board {
+		c.recent.Remove(element.(*lru.Element))
+		c.boards.Delete(board.id)
+	}
+}
+
+func itemOf(entry ScoreboardEntry) rankindex.Item[ScoreboardEntry] {
+	return rankindex.Item[ScoreboardEntry]{
+		UserID:    entry.UserID,
+		Score:     entry.Score,
+		UpdatedAt: entry.UpdatedAt.Time,
+		Value:     entry,
+	}
+}
+
+func standingOf(ranked rankindex.Ranked[ScoreboardEntry]) Standing {
+	return Standing{
+		ScoreboardEntry: ranked.Value,
+		Rank:            ranked.Rank,
+		Effective:       float64(ranked.Score),
+	}
+}
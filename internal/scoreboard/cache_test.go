@@ -0,0 +1,49 @@
+package scoreboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestCacheBounds(t *testing.T) {
+	ctx := context.Background()
+	cache := NewCache(2)
+	loads := 0
+	load := func(context.Context, uuid.UUID) ([]ScoreboardEntry, error) {
+		loads++
+		return nil, nil
+	}
+	missing := func(context.Context, uuid.UUID) ([]ScoreboardEntry, error) {
+		return nil, pgx.ErrNoRows
+	}
+
+	if _, err := cache.Count(ctx, uuid.New(), missing); !errors.Is(err, pgx.ErrNoRows) {
+		t.Fatalf("Count() of a missing board error = %v, want pgx.ErrNoRows", err)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Len() = %d after a failed load, want 0", cache.Len())
+	}
+
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+	for _, id := range []uuid.UUID{first, second, first, third} {
+		if _, err := cache.Count(ctx, id, load); err != nil {
+			t.Fatalf("Count() error = %v", err)
+		}
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d, want the capacity of 2", cache.Len())
+	}
+	// The second board was used least recently, so it was evicted and the
+	// first is still cached.
+	loads = 0
+	if _, err := cache.Count(ctx, first, load); err != nil || loads != 0 {
+		t.Errorf("Count() of a recent board loaded it %d times, %v", loads, err)
+	}
+	if _, err := cache.Count(ctx, second, load); err != nil || loads != 1 {
+		t.Errorf("Count() of an evicted board loaded it %d times, %v", loads, err)
+	}
+}
@@ -0,0 +1,82 @@
+package scoreboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type EventType string
+
+const (
+	EventScoreboardCreated EventType = "scoreboard_created"
+	EventScoreboardRenamed EventType = "scoreboard_renamed"
+	EventScoreboardDeleted EventType = "scoreboard_deleted"
+	EventEntryCreated      EventType = "entry_created"
+	// EventScoreSet records a score replaced through the entry API.
+	EventScoreSet EventType = "score_set"
+	// EventScoreSubmitted records a submission and the score it resulted in
+	// under the board's submission rule.
+	EventScoreSubmitted EventType = "score_submitted"
+	EventEntryRemoved   EventType = "entry_removed"
+)
+
+// EventData is the payload of an event. Scores are the score of the entry
+// after the event, so replaying the log does not depend on the settings the
+// board had at the time.
+type EventData struct {
+	Name      string `json:"name,omitempty"`
+	Score     int64  `json:"score,omitempty"`
+	Submitted int64  `json:"submitted,omitempty"`
+	Rule      string `json:"rule,omitempty"`
+}
+
+// Data decodes the payload of the event.
+func (e ScoreboardEvent) Data() (EventData, error) {
+	var data EventData
+	if err := json.Unmarshal(e.Payload, &data); err != nil {
+		return EventData{}, fmt.Errorf("event %d: %w", e.ID, err)
+	}
+	return data, nil
+}
+
+// record appends an event to the log and returns its ID. userID is uuid.Nil
+// for events of the scoreboard itself, and a zero at stands for the current
+// time. Backends that support it also notify the other instances, once the
+// transaction commits.
+func (s Service) record(ctx context.Context, queries Querier, eventType EventType, scoreboardID, userID uuid.UUID, data EventData, at pgtype.Timestamp) (int64, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return 0, err
+	}
+	if !at.Valid {
+		at = pgtype.Timestamp{Time: time.Now().UTC(), Valid: true}
+	}
+	event, err := queries.AppendEvent(ctx, AppendEventParams{
+		ScoreboardID: scoreboardID,
+		UserID:       pgtype.UUID{Bytes: userID, Valid: userID != uuid.Nil},
+		Type:         string(eventType),
+		Payload:      payload,
+		CreatedAt:    at,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if notifier, ok := queries.(Notifier); ok {
+		notification := event.Notification()
+		notification.Instance = s.instance
+		payload, err := json.Marshal(notification)
+		if err != nil {
+			return 0, err
+		}
+		if err := notifier.NotifyChange(ctx, string(payload)); err != nil {
+			return 0, err
+		}
+	}
+	return event.ID, nil
+}
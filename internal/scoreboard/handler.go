@@ -3,12 +3,15 @@ package scoreboard
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/google/uuid"
@@ -17,12 +20,31 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	defaultEventLimit = 100
+	maxEventLimit     = 1000
+)
+
 type Store interface {
 	List(ctx context.Context) ([]Scoreboard, error)
 	Get(ctx context.Context, id uuid.UUID) (Scoreboard, error)
 	Create(ctx context.Context, name pgtype.Text) (Scoreboard, error)
 	Update(ctx context.Context, arg UpdateParams) (Scoreboard, error)
 	Delete(ctx context.Context, id uuid.UUID) error
+	Settings(ctx context.Context, scoreboardID uuid.UUID) (ScoreboardSetting, error)
+	UpdateSettings(ctx context.Context, arg UpsertSettingsParams) (ScoreboardSetting, error)
+	Leaderboard(ctx context.Context, scoreboardID uuid.UUID) ([]Standing, error)
+	Top(ctx context.Context, scoreboardID uuid.UUID, limit int) ([]Standing, error)
+	Standing(ctx context.Context, scoreboardID, userID uuid.UUID) (Standing, error)
+	ListHandicaps(ctx context.Context, scoreboardID uuid.UUID) ([]ScoreboardHandicap, error)
+	SetHandicap(ctx context.Context, arg UpsertHandicapParams) (ScoreboardHandicap, error)
+	DeleteHandicap(ctx context.Context, scoreboardID, userID uuid.UUID) error
+	GetEntry(ctx context.Context, scoreboardID, userID uuid.UUID) (ScoreboardEntry, error)
+	CreateEntry(ctx context.Context, arg CreateEntryParams) (ScoreboardEntry, error)
+	UpdateEntry(ctx context.Context, arg UpdateEntryParams) (ScoreboardEntry, error)
+	DeleteEntry(ctx context.Context, scoreboardID, userID uuid.UUID) error
+	Submit(ctx context.Context, arg SubmitScoreParams) (ScoreboardEntry, error)
+	Events(ctx context.Context, scoreboardID uuid.UUID, after int64, limit int32) ([]ScoreboardEvent, error)
 }
 
 // CreateScoreboardPayload defines the expected request body for creating a scoreboard.
@@ -30,6 +52,69 @@ type CreateScoreboardPayload struct {
 	Name string `json:"name" validate:"required,Alphanumericspaceunderhyphen"`
 }
 
+// ErrInvalidName is returned by ValidateName.
+var ErrInvalidName = errors.New("name must only contain letters, digits, spaces, hyphens and underscores")
+
+// ValidateName checks a scoreboard name against the rule of
+// CreateScoreboardPayload, for the APIs that take names without that payload.
+// v must have the validations of internal.RegisterCustomValidations.
+func ValidateName(v *validator.Validate, name string) error {
+	if err := v.Struct(CreateScoreboardPayload{Name: name}); err != nil {
+		return ErrInvalidName
+	}
+	return nil
+}
+
+// CreateEntryPayload defines the expected request body for adding a player to a scoreboard.
+type CreateEntryPayload struct {
+	UserID string `json:"userId" validate:"required,uuid"`
+	Score  int64  `json:"score"`
+}
+
+// UpdateEntryPayload defines the expected request body for changing a player's score.
+type UpdateEntryPayload struct {
+	Score *int64 `json:"score" validate:"required"`
+}
+
+// SubmitScorePayload defines the expected request body for submitting a player's score.
+type SubmitScorePayload struct {
+	UserID string `json:"userId" validate:"required,uuid"`
+	Score  *int64 `json:"score" validate:"required"`
+}
+
+// SettingsPayload defines the expected request body for configuring how a scoreboard ranks entries.
+type SettingsPayload struct {
+	Decay         string  `json:"decay" validate:"required,oneof=none half_life linear"`
+	HalfLifeHours float64 `json:"halfLifeHours" validate:"min=0"`
+	DecayPerDay   float64 `json:"decayPerDay" validate:"min=0"`
+	// SubmissionRule keeps its current value when omitted, keep_best for a
+	// board never configured.
+	SubmissionRule string `json:"submissionRule" validate:"omitempty,oneof=keep_best replace add"`
+}
+
+// HandicapPayload defines the expected request body for a player's handicap.
+// The adjusted score is the score times the multiplier, plus the handicap.
+type HandicapPayload struct {
+	Class      string   `json:"class" validate:"max=64"`
+	Handicap   float64  `json:"handicap"`
+	Multiplier *float64 `json:"multiplier" validate:"omitempty,gt=0"`
+}
+
+type HandicapResponse struct {
+	UserID     string  `json:"userId"`
+	Class      *string `json:"class"`
+	Handicap   float64 `json:"handicap"`
+	Multiplier float64 `json:"multiplier"`
+	UpdatedAt  string  `json:"updatedAt"`
+}
+
+type SettingsResponse struct {
+	Decay          string  `json:"decay"`
+	HalfLifeHours  float64 `json:"halfLifeHours"`
+	DecayPerDay    float64 `json:"decayPerDay"`
+	SubmissionRule string  `json:"submissionRule"`
+}
+
 type Response struct {
 	ID        string `json:"id" validate:"required,uuid4"`
 	Name      string `json:"name" validate:"required"`
@@ -37,6 +122,35 @@ type Response struct {
 	UpdatedAt string `json:"updatedAt" validate:"required"`
 }
 
+type EntryResponse struct {
+	Rank           *int64            `json:"rank,omitempty"`
+	UserID         string            `json:"userId"`
+	Score          int64             `json:"score"`
+	EffectiveScore *float64          `json:"effectiveScore,omitempty"`
+	AdjustedRank   *int64            `json:"adjustedRank,omitempty"`
+	AdjustedScore  *float64          `json:"adjustedScore,omitempty"`
+	Handicap       *HandicapResponse `json:"handicap,omitempty"`
+	CreatedAt      string            `json:"createdAt"`
+	UpdatedAt      string            `json:"updatedAt"`
+}
+
+type EventResponse struct {
+	ID        int64     `json:"id"`
+	Type      string    `json:"type"`
+	UserID    *string   `json:"userId,omitempty"`
+	Data      EventData `json:"data"`
+	CreatedAt string    `json:"createdAt"`
+}
+
+// EventPageResponse is one page of an event log. Next is the ID of the last
+// event on the page, or the requested ?after= when the page is empty, and is
+// passed as ?after= to read on. A page shorter than the limit reached the end
+// of the log.
+type EventPageResponse struct {
+	Events []EventResponse `json:"events"`
+	Next   int64           `json:"next"`
+}
+
 type Handler struct {
 	validator *validator.Validate
 	tracer    trace.Tracer
@@ -208,6 +322,414 @@ func (h Handler) DeleteHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// LeaderboardHandler lists the standings of a scoreboard. With a limit and
+// raw ranking only the top of the board is read, without handicaps.
+func (h Handler) LeaderboardHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			http.Error(w, "limit must be a positive number", http.StatusBadRequest)
+			return
+		}
+	}
+
+	var standings []Standing
+	switch r.URL.Query().Get("rankBy") {
+	case "", "raw":
+		if limit > 0 {
+			standings, err = h.store.Top(ctx, scoreboardID, limit)
+		} else {
+			standings, err = h.store.Leaderboard(ctx, scoreboardID)
+		}
+	case "adjusted":
+		standings, err = h.store.Leaderboard(ctx, scoreboardID)
+		if err == nil {
+			standings = ByAdjusted(standings)
+			if limit > 0 && limit < len(standings) {
+				standings = standings[:limit]
+			}
+		}
+	default:
+		http.Error(w, "rankBy must be raw or adjusted", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		h.writeEntryError(w, err)
+		return
+	}
+	response := make([]EntryResponse, len(standings))
+	for index, standing := range standings {
+		response[index] = GenerateLeaderboardResponse(standing)
+	}
+	WriteJSONResponse(w, http.StatusOK, response)
+}
+
+// RankHandler returns a player's entry with its raw rank on the scoreboard.
+func (h Handler) RankHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	userID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	standing, err := h.store.Standing(ctx, scoreboardID, userID)
+	if err != nil {
+		h.writeEntryError(w, err)
+		return
+	}
+	WriteJSONResponse(w, http.StatusOK, GenerateLeaderboardResponse(standing))
+}
+
+// EventsHandler returns a page of the event log of a scoreboard, oldest
+// first, starting after the event ?after= when given. ?limit= caps the page
+// at 100 events by default and 1000 at most. The log outlives the scoreboard,
+// so deleted scoreboards still have one.
+func (h Handler) EventsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	var after int64
+	if value := r.URL.Query().Get("after"); value != "" {
+		after, err = strconv.ParseInt(value, 10, 64)
+		if err != nil || after < 0 {
+			http.Error(w, "after must be an event ID", http.StatusBadRequest)
+			return
+		}
+	}
+	limit := defaultEventLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxEventLimit {
+			http.Error(w, "limit must be between 1 and 1000", http.StatusBadRequest)
+			return
+		}
+	}
+	events, err := h.store.Events(ctx, scoreboardID, after, int32(limit))
+	if err != nil {
+		h.logger.Error("Failed to list events", zap.Error(err))
+		http.Error(w, "Failed to list events", http.StatusInternalServerError)
+		return
+	}
+	response := EventPageResponse{Events: make([]EventResponse, len(events)), Next: after}
+	for index, event := range events {
+		response.Events[index], err = GenerateEventResponse(event)
+		if err != nil {
+			h.logger.Error("Failed to decode event", zap.Error(err))
+			http.Error(w, "Failed to list events", http.StatusInternalServerError)
+			return
+		}
+		response.Next = event.ID
+	}
+	WriteJSONResponse(w, http.StatusOK, response)
+}
+
+func (h Handler) ListHandicapsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	handicaps, err := h.store.ListHandicaps(ctx, scoreboardID)
+	if err != nil {
+		h.writeEntryError(w, err)
+		return
+	}
+	response := make([]HandicapResponse, len(handicaps))
+	for index, handicap := range handicaps {
+		response[index] = GenerateHandicapResponse(handicap)
+	}
+	WriteJSONResponse(w, http.StatusOK, response)
+}
+
+func (h Handler) SetHandicapHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	userID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+
+	var payload HandicapPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validator.Struct(payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	params := UpsertHandicapParams{
+		ScoreboardID: scoreboardID,
+		UserID:       userID,
+		Class:        pgtype.Text{String: payload.Class, Valid: payload.Class != ""},
+		Handicap:     payload.Handicap,
+		Multiplier:   1,
+	}
+	if payload.Multiplier != nil {
+		params.Multiplier = *payload.Multiplier
+	}
+	handicap, err := h.store.SetHandicap(ctx, params)
+	if err != nil {
+		h.writeEntryError(w, err)
+		return
+	}
+	WriteJSONResponse(w, http.StatusOK, GenerateHandicapResponse(handicap))
+}
+
+func (h Handler) DeleteHandicapHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	userID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	if err := h.store.DeleteHandicap(ctx, scoreboardID, userID); err != nil {
+		h.writeEntryError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h Handler) GetSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	settings, err := h.store.Settings(ctx, scoreboardID)
+	if err != nil {
+		h.writeEntryError(w, err)
+		return
+	}
+	WriteJSONResponse(w, http.StatusOK, GenerateSettingsResponse(settings))
+}
+
+func (h Handler) UpdateSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+
+	var payload SettingsPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validator.Struct(payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if Decay(payload.Decay) == DecayHalfLife && payload.HalfLifeHours <= 0 {
+		http.Error(w, "halfLifeHours must be positive for half_life decay", http.StatusBadRequest)
+		return
+	}
+
+	// Changing the decay alone keeps the board's submission rule.
+	if payload.SubmissionRule == "" {
+		current, err := h.store.Settings(ctx, scoreboardID)
+		if err != nil {
+			h.writeEntryError(w, err)
+			return
+		}
+		payload.SubmissionRule = current.SubmissionRule
+	}
+
+	settings, err := h.store.UpdateSettings(ctx, UpsertSettingsParams{
+		ScoreboardID:   scoreboardID,
+		Decay:          payload.Decay,
+		HalfLifeHours:  payload.HalfLifeHours,
+		DecayPerDay:    payload.DecayPerDay,
+		SubmissionRule: payload.SubmissionRule,
+	})
+	if err != nil {
+		h.writeEntryError(w, err)
+		return
+	}
+	WriteJSONResponse(w, http.StatusOK, GenerateSettingsResponse(settings))
+}
+
+func (h Handler) GetEntryHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	userID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	entry, err := h.store.GetEntry(ctx, scoreboardID, userID)
+	if err != nil {
+		h.writeEntryError(w, err)
+		return
+	}
+	WriteJSONResponse(w, http.StatusOK, GenerateEntryResponse(entry))
+}
+
+func (h Handler) CreateEntryHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+
+	var payload CreateEntryPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validator.Struct(payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entry, err := h.store.CreateEntry(ctx, CreateEntryParams{
+		ScoreboardID: scoreboardID,
+		UserID:       uuid.MustParse(payload.UserID),
+		Score:        payload.Score,
+	})
+	if err != nil {
+		h.writeEntryError(w, err)
+		return
+	}
+	WriteJSONResponse(w, http.StatusCreated, GenerateEntryResponse(entry))
+}
+
+func (h Handler) UpdateEntryHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	userID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+
+	var payload UpdateEntryPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validator.Struct(payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entry, err := h.store.UpdateEntry(ctx, UpdateEntryParams{
+		ScoreboardID: scoreboardID,
+		UserID:       userID,
+		Score:        *payload.Score,
+	})
+	if err != nil {
+		h.writeEntryError(w, err)
+		return
+	}
+	WriteJSONResponse(w, http.StatusOK, GenerateEntryResponse(entry))
+}
+
+// SubmitScoreHandler applies a score to a player's entry using the
+// scoreboard's submission rule, creating the entry when needed.
+func (h Handler) SubmitScoreHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+
+	var payload SubmitScorePayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validator.Struct(payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entry, err := h.store.Submit(ctx, SubmitScoreParams{
+		ScoreboardID: scoreboardID,
+		UserID:       uuid.MustParse(payload.UserID),
+		Score:        *payload.Score,
+	})
+	if err != nil {
+		h.writeEntryError(w, err)
+		return
+	}
+	WriteJSONResponse(w, http.StatusOK, GenerateEntryResponse(entry))
+}
+
+func (h Handler) DeleteEntryHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	userID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	if err := h.store.DeleteEntry(ctx, scoreboardID, userID); err != nil {
+		h.writeEntryError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h Handler) writeEntryError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrEntryNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, pgx.ErrNoRows):
+		http.Error(w, "Scoreboard not found", http.StatusNotFound)
+	case errors.Is(err, ErrUnknownEntity):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, ErrEntryExists):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		h.logger.Error("Entry request failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 func WriteJSONResponse(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -231,3 +753,70 @@ func GenerateResponse(scoreboard Scoreboard) Response {
 		UpdatedAt: scoreboard.UpdatedAt.Time.Format(time.RFC3339),
 	}
 }
+
+func GenerateEntryResponse(entry ScoreboardEntry) EntryResponse {
+	return EntryResponse{
+		UserID:    entry.UserID.String(),
+		Score:     entry.Score,
+		CreatedAt: entry.CreatedAt.Time.Format(time.RFC3339),
+		UpdatedAt: entry.UpdatedAt.Time.Format(time.RFC3339),
+	}
+}
+
+func GenerateLeaderboardResponse(standing Standing) EntryResponse {
+	response := GenerateEntryResponse(standing.ScoreboardEntry)
+	rank, effective := standing.Rank, standing.Effective
+	response.Rank = &rank
+	response.EffectiveScore = &effective
+	// Standings read without handicaps carry no adjusted rank.
+	if standing.AdjustedRank > 0 {
+		adjustedRank, adjusted := standing.AdjustedRank, standing.Adjusted
+		response.AdjustedRank = &adjustedRank
+		response.AdjustedScore = &adjusted
+	}
+	if standing.Handicap != nil {
+		handicap := GenerateHandicapResponse(*standing.Handicap)
+		response.Handicap = &handicap
+	}
+	return response
+}
+
+func GenerateHandicapResponse(handicap ScoreboardHandicap) HandicapResponse {
+	response := HandicapResponse{
+		UserID:     handicap.UserID.String(),
+		Handicap:   handicap.Handicap,
+		Multiplier: handicap.Multiplier,
+		UpdatedAt:  handicap.UpdatedAt.Time.Format(time.RFC3339),
+	}
+	if handicap.Class.Valid {
+		response.Class = &handicap.Class.String
+	}
+	return response
+}
+
+func GenerateSettingsResponse(settings ScoreboardSetting) SettingsResponse {
+	return SettingsResponse{
+		Decay:          settings.Decay,
+		HalfLifeHours:  settings.HalfLifeHours,
+		DecayPerDay:    settings.DecayPerDay,
+		SubmissionRule: settings.SubmissionRule,
+	}
+}
+
+func GenerateEventResponse(event ScoreboardEvent) (EventResponse, error) {
+	data, err := event.Data()
+	if err != nil {
+		return EventResponse{}, err
+	}
+	response := EventResponse{
+		ID:        event.ID,
+		Type:      event.Type,
+		Data:      data,
+		CreatedAt: event.CreatedAt.Time.Format(time.RFC3339),
+	}
+	if event.UserID.Valid {
+		userID := uuid.UUID(event.UserID.Bytes).String()
+		response.UserID = &userID
+	}
+	return response, nil
+}
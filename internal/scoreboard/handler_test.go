@@ -1,10 +1,17 @@
 package scoreboard
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
 	"scoreboard-api/internal"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 func TestValidateName(t *testing.T) {
@@ -78,3 +85,109 @@ func TestValidateName(t *testing.T) {
 		})
 	}
 }
+
+// eventStore serves a made-up event log.
+type eventStore struct {
+	Store
+	events []ScoreboardEvent
+}
+
+func (s eventStore) Events(_ context.Context, _ uuid.UUID, after int64, limit int32) ([]ScoreboardEvent, error) {
+	var events []ScoreboardEvent
+	for _, event := range s.events {
+		if event.ID > after && len(events) < int(limit) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func TestEventsHandler(t *testing.T) {
+	store := eventStore{}
+	for id := int64(1); id <= 250; id++ {
+		store.events = append(store.events, ScoreboardEvent{ID: id, Type: string(EventScoreSet), Payload: []byte(`{"score":1}`)})
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/scoreboards/{id}/events", NewHandler(internal.NewValidator(), zap.NewNop(), store).EventsHandler)
+	get := func(query string) (int, EventPageResponse) {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/scoreboards/"+uuid.NewString()+"/events"+query, nil))
+		var page EventPageResponse
+		if recorder.Code == http.StatusOK {
+			if err := json.NewDecoder(recorder.Body).Decode(&page); err != nil {
+				t.Fatalf("decoding page error = %v", err)
+			}
+		}
+		return recorder.Code, page
+	}
+
+	if _, page := get(""); len(page.Events) != defaultEventLimit || page.Next != defaultEventLimit {
+		t.Errorf("first page = %d events, next %d, want %d", len(page.Events), page.Next, defaultEventLimit)
+	}
+	if _, page := get("?after=200&limit=80"); len(page.Events) != 50 || page.Events[0].ID != 201 || page.Next != 250 {
+		t.Errorf("last page = %d events, next %d, want 50 ending at 250", len(page.Events), page.Next)
+	}
+	if _, page := get("?after=250"); len(page.Events) != 0 || page.Next != 250 {
+		t.Errorf("page past the end = %d events, next %d, want none and the same cursor", len(page.Events), page.Next)
+	}
+	for _, query := range []string{"?limit=0", "?limit=1001", "?after=-1"} {
+		if code, _ := get(query); code != http.StatusBadRequest {
+			t.Errorf("GET %s status = %d, want 400", query, code)
+		}
+	}
+}
+
+// settingsStore keeps the settings of one board.
+type settingsStore struct {
+	Store
+	settings *ScoreboardSetting
+}
+
+func (s settingsStore) Settings(_ context.Context, scoreboardID uuid.UUID) (ScoreboardSetting, error) {
+	if s.settings.ScoreboardID != scoreboardID {
+		return DefaultSettings(scoreboardID), nil
+	}
+	return *s.settings, nil
+}
+
+func (s settingsStore) UpdateSettings(_ context.Context, arg UpsertSettingsParams) (ScoreboardSetting, error) {
+	*s.settings = ScoreboardSetting{
+		ScoreboardID:   arg.ScoreboardID,
+		Decay:          arg.Decay,
+		HalfLifeHours:  arg.HalfLifeHours,
+		DecayPerDay:    arg.DecayPerDay,
+		SubmissionRule: arg.SubmissionRule,
+	}
+	return *s.settings, nil
+}
+
+func TestUpdateSettingsHandler(t *testing.T) {
+	board := uuid.New()
+	store := settingsStore{settings: &ScoreboardSetting{ScoreboardID: board, Decay: string(DecayNone), SubmissionRule: string(SubmitAdd)}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /api/scoreboards/{id}/settings", NewHandler(internal.NewValidator(), zap.NewNop(), store).UpdateSettingsHandler)
+	put := func(id uuid.UUID, body string) SettingsResponse {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/api/scoreboards/"+id.String()+"/settings", strings.NewReader(body)))
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("PUT settings %s status = %d: %s", body, recorder.Code, recorder.Body)
+		}
+		var response SettingsResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+			t.Fatalf("decoding settings error = %v", err)
+		}
+		return response
+	}
+
+	if settings := put(board, `{"decay":"linear","decayPerDay":5}`); settings.SubmissionRule != string(SubmitAdd) {
+		t.Errorf("changing the decay left submission rule %q, want add", settings.SubmissionRule)
+	}
+	if settings := put(board, `{"decay":"none","submissionRule":"replace"}`); settings.SubmissionRule != string(SubmitReplace) {
+		t.Errorf("setting the rule left submission rule %q, want replace", settings.SubmissionRule)
+	}
+	if settings := put(uuid.New(), `{"decay":"none"}`); settings.SubmissionRule != string(SubmitKeepBest) {
+		t.Errorf("new board submission rule %q, want keep_best", settings.SubmissionRule)
+	}
+}
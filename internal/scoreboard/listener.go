@@ -0,0 +1,201 @@
+package scoreboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// changesChannel is the notification channel changes are published on.
+const changesChannel = "scoreboard_changes"
+
+const (
+	listenBackoff    = time.Second
+	maxListenBackoff = 30 * time.Second
+	// catchUpPage is the number of events read at a time after a reconnect.
+	catchUpPage = 1000
+)
+
+// Notification announces an event to the other instances sharing the
+// database. Instance is uuid.Nil when the origin is unknown.
+type Notification struct {
+	Instance     uuid.UUID `json:"instance"`
+	EventID      int64     `json:"eventId"`
+	ScoreboardID uuid.UUID `json:"scoreboardId"`
+	UserID       uuid.UUID `json:"userId"`
+	Type         EventType `json:"type"`
+}
+
+// Notifier is implemented by queriers that can publish a notification when
+// their transaction commits.
+type Notifier interface {
+	NotifyChange(ctx context.Context, payload string) error
+}
+
+// Notification returns the notification announcing the event.
+func (e ScoreboardEvent) Notification() Notification {
+	return Notification{
+		EventID:      e.ID,
+		ScoreboardID: e.ScoreboardID,
+		UserID:       e.UserID.Bytes,
+		Type:         EventType(e.Type),
+	}
+}
+
+// ApplyNotification brings the cache and observers of this instance up to
+// date with a change made by another instance. The current state is read
+// back from the database, so a notification may be applied late or twice.
+func (s Service) ApplyNotification(ctx context.Context, notification Notification) error {
+	if notification.Instance == s.instance {
+		return nil
+	}
+	traceCtx, span := s.tracer.Start(ctx, "ApplyNotification")
+	defer span.End()
+
+	switch notification.Type {
+	case EventScoreboardRenamed:
+		board, err := s.query.Get(traceCtx, notification.ScoreboardID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.notifyScoreboard(traceCtx, ScoreboardChange{ScoreboardID: board.ID, After: &board, EventID: notification.EventID, Remote: true})
+	case EventScoreboardDeleted:
+		s.cache.Forget(notification.ScoreboardID)
+		s.notifyScoreboard(traceCtx, ScoreboardChange{ScoreboardID: notification.ScoreboardID, EventID: notification.EventID, Remote: true})
+	case EventEntryCreated, EventScoreSet, EventScoreSubmitted, EventEntryRemoved:
+		change := EntryChange{
+			ScoreboardID: notification.ScoreboardID,
+			UserID:       notification.UserID,
+			EventID:      notification.EventID,
+			Remote:       true,
+		}
+		entry, err := s.query.GetEntry(traceCtx, GetEntryParams{ScoreboardID: notification.ScoreboardID, UserID: notification.UserID})
+		switch {
+		case err == nil:
+			change.After = &entry
+		case !errors.Is(err, pgx.ErrNoRows):
+			return err
+		}
+		s.notify(traceCtx, change)
+	}
+	return nil
+}
+
+// Listener applies the changes other instances publish, so subscribers
+// connected to this instance see writes made on any of them.
+type Listener struct {
+	logger  *zap.Logger
+	db      *pgxpool.Pool
+	queries *Queries
+	service *Service
+	// last is the highest event ID seen, where a reconnect resumes. It is
+	// valid once positioned is set.
+	last       int64
+	positioned bool
+}
+
+func NewListener(logger *zap.Logger, db *pgxpool.Pool, service *Service) *Listener {
+	return &Listener{
+		logger:  logger,
+		db:      db,
+		queries: New(db),
+		service: service,
+	}
+}
+
+// Listen holds a dedicated connection listening for changes until ctx is
+// done. When the connection drops it reconnects with backoff and replays the
+// event log from the last change it saw.
+func (l *Listener) Listen(ctx context.Context) {
+	backoff := listenBackoff
+	for {
+		connected, err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			backoff = listenBackoff
+		}
+		l.logger.Warn("Change notifications interrupted", zap.Error(err), zap.Duration("retry_in", backoff))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxListenBackoff)
+	}
+}
+
+// listen runs one connection until it fails and reports whether it got as far
+// as listening.
+func (l *Listener) listen(ctx context.Context) (bool, error) {
+	conn, err := l.db.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+	// A listening connection must not go back to the pool.
+	defer func() {
+		_ = conn.Hijack().Close(context.Background())
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+changesChannel); err != nil {
+		return false, err
+	}
+	// Changes made before LISTEN took effect are read from the log.
+	if l.positioned {
+		err = l.catchUp(ctx)
+	} else {
+		l.last, err = l.queries.LatestEventID(ctx)
+		l.positioned = err == nil
+	}
+	if err != nil {
+		return true, err
+	}
+	l.logger.Info("Listening for changes from other instances")
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return true, err
+		}
+		var change Notification
+		if err := json.Unmarshal([]byte(notification.Payload), &change); err != nil {
+			l.logger.Warn("Ignoring a malformed change notification", zap.Error(err))
+			continue
+		}
+		l.apply(ctx, change)
+	}
+}
+
+// catchUp applies every event after the last one seen. Events of this
+// instance are applied again, which observers tolerate.
+func (l *Listener) catchUp(ctx context.Context) error {
+	for {
+		events, err := l.queries.ListEvents(ctx, ListEventsParams{ID: l.last, Limit: catchUpPage})
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			l.apply(ctx, event.Notification())
+		}
+		if len(events) < catchUpPage {
+			return nil
+		}
+	}
+}
+
+func (l *Listener) apply(ctx context.Context, notification Notification) {
+	l.last = max(l.last, notification.EventID)
+	if err := l.service.ApplyNotification(ctx, notification); err != nil {
+		l.logger.Error("Failed to apply a change from another instance", zap.Error(err))
+	}
+}
@@ -15,3 +15,51 @@ type Scoreboard struct {
 	CreatedAt pgtype.Timestamp
 	UpdatedAt pgtype.Timestamp
 }
+
+type ScoreboardEntry struct {
+	ScoreboardID uuid.UUID
+	UserID       uuid.UUID
+	Score        int64
+	CreatedAt    pgtype.Timestamp
+	UpdatedAt    pgtype.Timestamp
+}
+
+type ScoreboardEvent struct {
+	ID           int64
+	ScoreboardID uuid.UUID
+	UserID       pgtype.UUID
+	Type         string
+	Payload      []byte
+	CreatedAt    pgtype.Timestamp
+}
+
+type ScoreboardHandicap struct {
+	ScoreboardID uuid.UUID
+	UserID       uuid.UUID
+	Class        pgtype.Text
+	Handicap     float64
+	Multiplier   float64
+	CreatedAt    pgtype.Timestamp
+	UpdatedAt    pgtype.Timestamp
+}
+
+type ScoreboardOutbox struct {
+	ID            int64
+	Instance      uuid.UUID
+	Payload       []byte
+	Attempts      int32
+	NextAttemptAt pgtype.Timestamp
+	LastError     pgtype.Text
+	CreatedAt     pgtype.Timestamp
+	DispatchedAt  pgtype.Timestamp
+}
+
+type ScoreboardSetting struct {
+	ScoreboardID   uuid.UUID
+	Decay          string
+	HalfLifeHours  float64
+	DecayPerDay    float64
+	CreatedAt      pgtype.Timestamp
+	UpdatedAt      pgtype.Timestamp
+	SubmissionRule string
+}
@@ -0,0 +1,299 @@
+package scoreboard
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+const (
+	// outboxPollInterval is how often the outbox is checked for retries and
+	// for changes left behind by other instances.
+	outboxPollInterval = 5 * time.Second
+	outboxBatch        = 100
+	// outboxLease keeps other dispatchers off a claimed change while its
+	// sinks run.
+	outboxLease = time.Minute
+	// outboxOrphanAfter is how long the instance that made a change has to
+	// dispatch it before any other instance may.
+	outboxOrphanAfter = 30 * time.Second
+	// A failed change is retried after outboxRetryDelay, doubling each time up
+	// to maxOutboxRetryDelay, until every sink accepts it.
+	outboxRetryDelay    = time.Second
+	maxOutboxRetryDelay = 5 * time.Minute
+	// outboxRetention is how long dispatched changes are kept.
+	outboxRetention = 24 * time.Hour
+)
+
+// Outbox is implemented by queriers that can stage a change in the
+// transaction that makes it.
+type Outbox interface {
+	AppendOutbox(ctx context.Context, arg AppendOutboxParams) error
+}
+
+// OutboxMessage is a committed change as written to the outbox. Exactly one
+// of Entry and Scoreboard is set.
+type OutboxMessage struct {
+	Entry      *EntryChange      `json:"entry,omitempty"`
+	Scoreboard *ScoreboardChange `json:"scoreboard,omitempty"`
+}
+
+// stage writes a change to the outbox in the transaction that makes it, on
+// backends that have one, so the sinks hear of it even when the process stops
+// right after the commit.
+func (s Service) stage(ctx context.Context, queries Querier, message OutboxMessage) error {
+	outbox, ok := queries.(Outbox)
+	if !ok {
+		return nil
+	}
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	return outbox.AppendOutbox(ctx, AppendOutboxParams{
+		Instance:      s.instance,
+		Payload:       payload,
+		NextAttemptAt: pgtype.Timestamp{Time: time.Now().UTC(), Valid: true},
+	})
+}
+
+// OutboxDispatcher hands the changes staged in the outbox to its sinks, at
+// least once each. A change is retried until every sink accepts it, so sinks
+// must tolerate seeing a change twice.
+//
+// Each instance dispatches its own changes as soon as they commit, so the
+// sinks of that instance, such as its stream broker, see them right away.
+// Changes of an instance that stopped before dispatching them are picked up
+// by any other.
+type OutboxDispatcher struct {
+	logger   *zap.Logger
+	queries  *Queries
+	instance uuid.UUID
+	sinks    []Observer
+	// wake tells the dispatcher a change was just committed.
+	wake chan struct{}
+}
+
+var (
+	_ Observer           = (*OutboxDispatcher)(nil)
+	_ ScoreboardObserver = (*OutboxDispatcher)(nil)
+)
+
+// NewOutboxDispatcher dispatches the changes of service. It must observe the
+// service to dispatch them without waiting for the next poll.
+func NewOutboxDispatcher(logger *zap.Logger, db *pgxpool.Pool, service *Service) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		logger:   logger,
+		queries:  New(db),
+		instance: service.instance,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Register adds a sink. Sinks implementing ScoreboardObserver also receive
+// renamed and deleted scoreboards. It must be called before Run.
+func (d *OutboxDispatcher) Register(sink Observer) {
+	d.sinks = append(d.sinks, sink)
+}
+
+func (d *OutboxDispatcher) ObserveEntry(_ context.Context, change EntryChange) error {
+	if !change.Remote {
+		d.poke()
+	}
+	return nil
+}
+
+func (d *OutboxDispatcher) ObserveScoreboard(_ context.Context, change ScoreboardChange) error {
+	if !change.Remote {
+		d.poke()
+	}
+	return nil
+}
+
+func (d *OutboxDispatcher) poke() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run dispatches staged changes until ctx is done.
+func (d *OutboxDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+	for {
+		if err := d.dispatch(ctx); err != nil && ctx.Err() == nil {
+			d.logger.Error("Failed to dispatch the outbox", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := d.queries.PruneOutbox(ctx, pgtype.Timestamp{Time: time.Now().UTC().Add(-outboxRetention), Valid: true})
+			if err != nil && ctx.Err() == nil {
+				d.logger.Error("Failed to prune the outbox", zap.Error(err))
+			}
+		case <-d.wake:
+		}
+	}
+}
+
+// dispatch delivers the due changes in the order they were made, a batch at
+// a time until none are left.
+func (d *OutboxDispatcher) dispatch(ctx context.Context) error {
+	for {
+		now := time.Now().UTC()
+		rows, err := d.queries.ClaimOutbox(ctx, ClaimOutboxParams{
+			LeaseUntil:     pgtype.Timestamp{Time: now.Add(outboxLease), Valid: true},
+			Now:            pgtype.Timestamp{Time: now, Valid: true},
+			Instance:       d.instance,
+			OrphanedBefore: pgtype.Timestamp{Time: now.Add(-outboxOrphanAfter), Valid: true},
+			Batch:          outboxBatch,
+		})
+		if err != nil {
+			return err
+		}
+		slices.SortFunc(rows, func(a, b ScoreboardOutbox) int { return cmp.Compare(a.ID, b.ID) })
+		for _, row := range rows {
+			if err := d.dispatchRow(ctx, row); err != nil {
+				return err
+			}
+		}
+		if len(rows) < outboxBatch {
+			return nil
+		}
+	}
+}
+
+// dispatchRow delivers one change and records the outcome. Only failing to
+// record it is returned.
+func (d *OutboxDispatcher) dispatchRow(ctx context.Context, row ScoreboardOutbox) error {
+	err := d.dispatchPayload(ctx, row.Payload)
+	now := time.Now().UTC()
+	if err == nil {
+		return d.queries.MarkOutboxDispatched(ctx, MarkOutboxDispatchedParams{
+			ID:           row.ID,
+			DispatchedAt: pgtype.Timestamp{Time: now, Valid: true},
+		})
+	}
+	delay := outboxBackoff(row.Attempts + 1)
+	d.logger.Warn("Outbox change failed, retrying",
+		zap.Int64("outbox_id", row.ID),
+		zap.Int32("attempt", row.Attempts+1),
+		zap.Duration("retry_in", delay),
+		zap.Error(err))
+	return d.queries.RetryOutbox(ctx, RetryOutboxParams{
+		ID:            row.ID,
+		NextAttemptAt: pgtype.Timestamp{Time: now.Add(delay), Valid: true},
+		LastError:     pgtype.Text{String: err.Error(), Valid: true},
+	})
+}
+
+// dispatchPayload hands a staged change to every sink and joins their
+// errors.
+func (d *OutboxDispatcher) dispatchPayload(ctx context.Context, payload []byte) error {
+	var message OutboxMessage
+	if err := json.Unmarshal(payload, &message); err != nil {
+		return err
+	}
+	var errs []error
+	for _, sink := range d.sinks {
+		var err error
+		switch {
+		case message.Entry != nil:
+			err = sink.ObserveEntry(ctx, *message.Entry)
+		case message.Scoreboard != nil:
+			if sink, ok := sink.(ScoreboardObserver); ok {
+				err = sink.ObserveScoreboard(ctx, *message.Scoreboard)
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%T: %w", sink, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// outboxBackoff returns how long to wait after the given number of failed
+// attempts.
+func outboxBackoff(attempts int32) time.Duration {
+	delay := outboxRetryDelay
+	for i := int32(1); i < attempts && delay < maxOutboxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxOutboxRetryDelay)
+}
+
+// LogSink writes every change it receives to the log.
+type LogSink struct {
+	logger *zap.Logger
+}
+
+var _ ScoreboardObserver = LogSink{}
+
+func NewLogSink(logger *zap.Logger) LogSink {
+	return LogSink{logger: logger}
+}
+
+func (l LogSink) ObserveEntry(_ context.Context, change EntryChange) error {
+	fields := []zap.Field{
+		zap.Int64("event_id", change.EventID),
+		zap.String("scoreboard_id", change.ScoreboardID.String()),
+		zap.String("user_id", change.UserID.String()),
+	}
+	if change.Before != nil {
+		fields = append(fields, zap.Int64("before", change.Before.Score))
+	}
+	if change.After != nil {
+		fields = append(fields, zap.Int64("after", change.After.Score))
+	}
+	l.logger.Info("Entry changed", fields...)
+	return nil
+}
+
+func (l LogSink) ObserveScoreboard(_ context.Context, change ScoreboardChange) error {
+	if change.After == nil {
+		l.logger.Info("Scoreboard deleted", zap.Int64("event_id", change.EventID), zap.String("scoreboard_id", change.ScoreboardID.String()))
+		return nil
+	}
+	l.logger.Info("Scoreboard renamed",
+		zap.Int64("event_id", change.EventID),
+		zap.String("scoreboard_id", change.ScoreboardID.String()),
+		zap.String("name", change.After.Name.String))
+	return nil
+}
+
+// RemoteOnly passes only the changes of other instances to an observer. An
+// observer that is also an outbox sink gets the changes of this instance from
+// the dispatcher instead.
+func RemoteOnly(observer Observer) Observer {
+	return remoteOnly{observer: observer}
+}
+
+type remoteOnly struct {
+	observer Observer
+}
+
+func (r remoteOnly) ObserveEntry(ctx context.Context, change EntryChange) error {
+	if !change.Remote {
+		return nil
+	}
+	return r.observer.ObserveEntry(ctx, change)
+}
+
+func (r remoteOnly) ObserveScoreboard(ctx context.Context, change ScoreboardChange) error {
+	observer, ok := r.observer.(ScoreboardObserver)
+	if !change.Remote || !ok {
+		return nil
+	}
+	return observer.ObserveScoreboard(ctx, change)
+}
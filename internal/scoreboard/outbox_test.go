@@ -0,0 +1,119 @@
+package scoreboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// stagingQuerier keeps what is written to the outbox. Only AppendOutbox may
+// be called on it.
+type stagingQuerier struct {
+	Querier
+	staged []AppendOutboxParams
+}
+
+func (q *stagingQuerier) AppendOutbox(_ context.Context, arg AppendOutboxParams) error {
+	q.staged = append(q.staged, arg)
+	return nil
+}
+
+// sink records the changes handed to it and fails while err is set.
+type sink struct {
+	entries     []EntryChange
+	scoreboards []ScoreboardChange
+	err         error
+}
+
+func (s *sink) ObserveEntry(_ context.Context, change EntryChange) error {
+	s.entries = append(s.entries, change)
+	return s.err
+}
+
+func (s *sink) ObserveScoreboard(_ context.Context, change ScoreboardChange) error {
+	s.scoreboards = append(s.scoreboards, change)
+	return s.err
+}
+
+func TestOutboxDelivery(t *testing.T) {
+	ctx := context.Background()
+	service := NewServiceWithQuerier(zap.NewNop(), nil, nil)
+	queries := &stagingQuerier{}
+
+	at := pgtype.Timestamp{Time: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), Valid: true}
+	before := ScoreboardEntry{ScoreboardID: uuid.New(), UserID: uuid.New(), Score: 10, CreatedAt: at, UpdatedAt: at}
+	after := before
+	after.Score = 25
+	change := EntryChange{ScoreboardID: before.ScoreboardID, UserID: before.UserID, Before: &before, After: &after, EventID: 7}
+	board := Scoreboard{ID: before.ScoreboardID, Name: pgtype.Text{String: "Arcade", Valid: true}}
+	for _, message := range []OutboxMessage{{Entry: &change}, {Scoreboard: &ScoreboardChange{ScoreboardID: board.ID, After: &board, EventID: 8}}} {
+		if err := service.stage(ctx, queries, message); err != nil {
+			t.Fatalf("stage() error = %v", err)
+		}
+	}
+	if len(queries.staged) != 2 || queries.staged[0].Instance != service.instance {
+		t.Fatalf("staged = %+v, want two changes of this instance", queries.staged)
+	}
+
+	healthy, failing := &sink{}, &sink{err: errors.New("unavailable")}
+	dispatcher := &OutboxDispatcher{logger: zap.NewNop()}
+	dispatcher.Register(healthy)
+	dispatcher.Register(failing)
+	for i, staged := range queries.staged {
+		err := dispatcher.dispatchPayload(ctx, staged.Payload)
+		if !errors.Is(err, failing.err) {
+			t.Errorf("change %d: dispatchPayload() error = %v, want the failing sink's error", i, err)
+		}
+	}
+
+	if len(healthy.entries) != 1 || len(failing.entries) != 1 {
+		t.Fatalf("entries = %d and %d, want one for each sink", len(healthy.entries), len(failing.entries))
+	}
+	got := healthy.entries[0]
+	if got.EventID != 7 || got.UserID != change.UserID || got.Before.Score != 10 || got.After.Score != 25 || !got.After.UpdatedAt.Time.Equal(at.Time) {
+		t.Errorf("entry change = %+v, want the staged change", got)
+	}
+	if len(healthy.scoreboards) != 1 || healthy.scoreboards[0].After.Name.String != "Arcade" || healthy.scoreboards[0].EventID != 8 {
+		t.Errorf("scoreboard changes = %+v, want the renamed board", healthy.scoreboards)
+	}
+}
+
+func TestRemoteOnly(t *testing.T) {
+	ctx := context.Background()
+	recorder := &sink{}
+	observer := RemoteOnly(recorder)
+	_ = observer.ObserveEntry(ctx, EntryChange{EventID: 1})
+	_ = observer.ObserveEntry(ctx, EntryChange{EventID: 2, Remote: true})
+	_ = observer.(ScoreboardObserver).ObserveScoreboard(ctx, ScoreboardChange{EventID: 3})
+	_ = observer.(ScoreboardObserver).ObserveScoreboard(ctx, ScoreboardChange{EventID: 4, Remote: true})
+	if len(recorder.entries) != 1 || recorder.entries[0].EventID != 2 {
+		t.Errorf("entries = %+v, want only the remote one", recorder.entries)
+	}
+	if len(recorder.scoreboards) != 1 || recorder.scoreboards[0].EventID != 4 {
+		t.Errorf("scoreboards = %+v, want only the remote one", recorder.scoreboards)
+	}
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int32
+		want     time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{5, 16 * time.Second},
+		{9, 256 * time.Second},
+		{10, 5 * time.Minute},
+		{40, 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := outboxBackoff(tt.attempts); got != tt.want {
+			t.Errorf("outboxBackoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -12,6 +12,128 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const appendEvent = `-- name: AppendEvent :one
+INSERT INTO scoreboard_events (
+    scoreboard_id, user_id, type, payload, created_at
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, scoreboard_id, user_id, type, payload, created_at
+`
+
+type AppendEventParams struct {
+	ScoreboardID uuid.UUID
+	UserID       pgtype.UUID
+	Type         string
+	Payload      []byte
+	CreatedAt    pgtype.Timestamp
+}
+
+func (q *Queries) AppendEvent(ctx context.Context, arg AppendEventParams) (ScoreboardEvent, error) {
+	row := q.db.QueryRow(ctx, appendEvent,
+		arg.ScoreboardID,
+		arg.UserID,
+		arg.Type,
+		arg.Payload,
+		arg.CreatedAt,
+	)
+	var i ScoreboardEvent
+	err := row.Scan(
+		&i.ID,
+		&i.ScoreboardID,
+		&i.UserID,
+		&i.Type,
+		&i.Payload,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const appendOutbox = `-- name: AppendOutbox :exec
+INSERT INTO scoreboard_outbox (
+    instance, payload, next_attempt_at, created_at
+) VALUES (
+    $1, $2, $3, $3
+)
+`
+
+type AppendOutboxParams struct {
+	Instance      uuid.UUID
+	Payload       []byte
+	NextAttemptAt pgtype.Timestamp
+}
+
+func (q *Queries) AppendOutbox(ctx context.Context, arg AppendOutboxParams) error {
+	_, err := q.db.Exec(ctx, appendOutbox, arg.Instance, arg.Payload, arg.NextAttemptAt)
+	return err
+}
+
+const claimOutbox = `-- name: ClaimOutbox :many
+UPDATE scoreboard_outbox
+SET next_attempt_at = $1
+WHERE id IN (
+    SELECT id FROM scoreboard_outbox
+    WHERE dispatched_at IS NULL
+        AND next_attempt_at <= $2
+        AND (instance = $3 OR created_at <= $4)
+    ORDER BY id
+    LIMIT $5
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, instance, payload, attempts, next_attempt_at, last_error, created_at, dispatched_at
+`
+
+type ClaimOutboxParams struct {
+	LeaseUntil     pgtype.Timestamp
+	Now            pgtype.Timestamp
+	Instance       uuid.UUID
+	OrphanedBefore pgtype.Timestamp
+	Batch          int32
+}
+
+func (q *Queries) ClaimOutbox(ctx context.Context, arg ClaimOutboxParams) ([]ScoreboardOutbox, error) {
+	rows, err := q.db.Query(ctx, claimOutbox,
+		arg.LeaseUntil,
+		arg.Now,
+		arg.Instance,
+		arg.OrphanedBefore,
+		arg.Batch,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ScoreboardOutbox
+	for rows.Next() {
+		var i ScoreboardOutbox
+		if err := rows.Scan(
+			&i.ID,
+			&i.Instance,
+			&i.Payload,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.DispatchedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const clearEntries = `-- name: ClearEntries :exec
+DELETE FROM scoreboard_entries
+`
+
+func (q *Queries) ClearEntries(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, clearEntries)
+	return err
+}
+
 const create = `-- name: Create :one
 INSERT INTO scoreboards (
     id, name, created_at, updated_at
@@ -35,6 +157,35 @@ func (q *Queries) Create(ctx context.Context, name pgtype.Text) (Scoreboard, err
 	return i, err
 }
 
+const createEntry = `-- name: CreateEntry :one
+INSERT INTO scoreboard_entries (
+    scoreboard_id, user_id, score, created_at, updated_at
+) VALUES (
+    $1, $2, $3,
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+) RETURNING scoreboard_id, user_id, score, created_at, updated_at
+`
+
+type CreateEntryParams struct {
+	ScoreboardID uuid.UUID
+	UserID       uuid.UUID
+	Score        int64
+}
+
+func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (ScoreboardEntry, error) {
+	row := q.db.QueryRow(ctx, createEntry, arg.ScoreboardID, arg.UserID, arg.Score)
+	var i ScoreboardEntry
+	err := row.Scan(
+		&i.ScoreboardID,
+		&i.UserID,
+		&i.Score,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const delete = `-- name: Delete :exec
 DELETE FROM scoreboards
 WHERE id = $1
@@ -45,6 +196,36 @@ func (q *Queries) Delete(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const deleteEntry = `-- name: DeleteEntry :exec
+DELETE FROM scoreboard_entries
+WHERE scoreboard_id = $1 AND user_id = $2
+`
+
+type DeleteEntryParams struct {
+	ScoreboardID uuid.UUID
+	UserID       uuid.UUID
+}
+
+func (q *Queries) DeleteEntry(ctx context.Context, arg DeleteEntryParams) error {
+	_, err := q.db.Exec(ctx, deleteEntry, arg.ScoreboardID, arg.UserID)
+	return err
+}
+
+const deleteHandicap = `-- name: DeleteHandicap :exec
+DELETE FROM scoreboard_handicaps
+WHERE scoreboard_id = $1 AND user_id = $2
+`
+
+type DeleteHandicapParams struct {
+	ScoreboardID uuid.UUID
+	UserID       uuid.UUID
+}
+
+func (q *Queries) DeleteHandicap(ctx context.Context, arg DeleteHandicapParams) error {
+	_, err := q.db.Exec(ctx, deleteHandicap, arg.ScoreboardID, arg.UserID)
+	return err
+}
+
 const get = `-- name: Get :one
 SELECT id, name, created_at, updated_at FROM scoreboards
 WHERE id = $1 LIMIT 1
@@ -62,6 +243,103 @@ func (q *Queries) Get(ctx context.Context, id uuid.UUID) (Scoreboard, error) {
 	return i, err
 }
 
+const getEntry = `-- name: GetEntry :one
+SELECT scoreboard_id, user_id, score, created_at, updated_at FROM scoreboard_entries
+WHERE scoreboard_id = $1 AND user_id = $2
+`
+
+type GetEntryParams struct {
+	ScoreboardID uuid.UUID
+	UserID       uuid.UUID
+}
+
+func (q *Queries) GetEntry(ctx context.Context, arg GetEntryParams) (ScoreboardEntry, error) {
+	row := q.db.QueryRow(ctx, getEntry, arg.ScoreboardID, arg.UserID)
+	var i ScoreboardEntry
+	err := row.Scan(
+		&i.ScoreboardID,
+		&i.UserID,
+		&i.Score,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSettings = `-- name: GetSettings :one
+SELECT scoreboard_id, decay, half_life_hours, decay_per_day, created_at, updated_at, submission_rule FROM scoreboard_settings
+WHERE scoreboard_id = $1
+`
+
+func (q *Queries) GetSettings(ctx context.Context, scoreboardID uuid.UUID) (ScoreboardSetting, error) {
+	row := q.db.QueryRow(ctx, getSettings, scoreboardID)
+	var i ScoreboardSetting
+	err := row.Scan(
+		&i.ScoreboardID,
+		&i.Decay,
+		&i.HalfLifeHours,
+		&i.DecayPerDay,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SubmissionRule,
+	)
+	return i, err
+}
+
+const latestEventID = `-- name: LatestEventID :one
+SELECT COALESCE(MAX(id), 0)::BIGINT FROM scoreboard_events
+`
+
+func (q *Queries) LatestEventID(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, latestEventID)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const leaderboard = `-- name: Leaderboard :many
+SELECT scoreboard_id, user_id, score, created_at, updated_at, RANK() OVER (ORDER BY score DESC) AS rank
+FROM scoreboard_entries
+WHERE scoreboard_id = $1
+ORDER BY score DESC, updated_at
+`
+
+type LeaderboardRow struct {
+	ScoreboardID uuid.UUID
+	UserID       uuid.UUID
+	Score        int64
+	CreatedAt    pgtype.Timestamp
+	UpdatedAt    pgtype.Timestamp
+	Rank         int64
+}
+
+func (q *Queries) Leaderboard(ctx context.Context, scoreboardID uuid.UUID) ([]LeaderboardRow, error) {
+	rows, err := q.db.Query(ctx, leaderboard, scoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LeaderboardRow
+	for rows.Next() {
+		var i LeaderboardRow
+		if err := rows.Scan(
+			&i.ScoreboardID,
+			&i.UserID,
+			&i.Score,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Rank,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const list = `-- name: List :many
 SELECT id, name, created_at, updated_at FROM scoreboards
 ORDER BY created_at DESC
@@ -92,6 +370,326 @@ func (q *Queries) List(ctx context.Context) ([]Scoreboard, error) {
 	return items, nil
 }
 
+const listEntries = `-- name: ListEntries :many
+SELECT scoreboard_id, user_id, score, created_at, updated_at FROM scoreboard_entries
+WHERE scoreboard_id = $1
+`
+
+func (q *Queries) ListEntries(ctx context.Context, scoreboardID uuid.UUID) ([]ScoreboardEntry, error) {
+	rows, err := q.db.Query(ctx, listEntries, scoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ScoreboardEntry
+	for rows.Next() {
+		var i ScoreboardEntry
+		if err := rows.Scan(
+			&i.ScoreboardID,
+			&i.UserID,
+			&i.Score,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEvents = `-- name: ListEvents :many
+SELECT id, scoreboard_id, user_id, type, payload, created_at FROM scoreboard_events
+WHERE id > $1
+ORDER BY id
+LIMIT $2
+`
+
+type ListEventsParams struct {
+	ID    int64
+	Limit int32
+}
+
+func (q *Queries) ListEvents(ctx context.Context, arg ListEventsParams) ([]ScoreboardEvent, error) {
+	rows, err := q.db.Query(ctx, listEvents, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ScoreboardEvent
+	for rows.Next() {
+		var i ScoreboardEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.ScoreboardID,
+			&i.UserID,
+			&i.Type,
+			&i.Payload,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listHandicaps = `-- name: ListHandicaps :many
+SELECT scoreboard_id, user_id, class, handicap, multiplier, created_at, updated_at FROM scoreboard_handicaps
+WHERE scoreboard_id = $1
+`
+
+func (q *Queries) ListHandicaps(ctx context.Context, scoreboardID uuid.UUID) ([]ScoreboardHandicap, error) {
+	rows, err := q.db.Query(ctx, listHandicaps, scoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ScoreboardHandicap
+	for rows.Next() {
+		var i ScoreboardHandicap
+		if err := rows.Scan(
+			&i.ScoreboardID,
+			&i.UserID,
+			&i.Class,
+			&i.Handicap,
+			&i.Multiplier,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listScoreboardEvents = `-- name: ListScoreboardEvents :many
+SELECT id, scoreboard_id, user_id, type, payload, created_at FROM scoreboard_events
+WHERE scoreboard_id = $1 AND id > $2
+ORDER BY id
+LIMIT $3
+`
+
+type ListScoreboardEventsParams struct {
+	ScoreboardID uuid.UUID
+	ID           int64
+	Limit        int32
+}
+
+func (q *Queries) ListScoreboardEvents(ctx context.Context, arg ListScoreboardEventsParams) ([]ScoreboardEvent, error) {
+	rows, err := q.db.Query(ctx, listScoreboardEvents, arg.ScoreboardID, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ScoreboardEvent
+	for rows.Next() {
+		var i ScoreboardEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.ScoreboardID,
+			&i.UserID,
+			&i.Type,
+			&i.Payload,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockEntry = `-- name: LockEntry :one
+SELECT scoreboard_id, user_id, score, created_at, updated_at FROM scoreboard_entries
+WHERE scoreboard_id = $1 AND user_id = $2
+FOR UPDATE
+`
+
+type LockEntryParams struct {
+	ScoreboardID uuid.UUID
+	UserID       uuid.UUID
+}
+
+func (q *Queries) LockEntry(ctx context.Context, arg LockEntryParams) (ScoreboardEntry, error) {
+	row := q.db.QueryRow(ctx, lockEntry, arg.ScoreboardID, arg.UserID)
+	var i ScoreboardEntry
+	err := row.Scan(
+		&i.ScoreboardID,
+		&i.UserID,
+		&i.Score,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const markOutboxDispatched = `-- name: MarkOutboxDispatched :exec
+UPDATE scoreboard_outbox SET dispatched_at = $2 WHERE id = $1
+`
+
+type MarkOutboxDispatchedParams struct {
+	ID           int64
+	DispatchedAt pgtype.Timestamp
+}
+
+func (q *Queries) MarkOutboxDispatched(ctx context.Context, arg MarkOutboxDispatchedParams) error {
+	_, err := q.db.Exec(ctx, markOutboxDispatched, arg.ID, arg.DispatchedAt)
+	return err
+}
+
+const notifyChange = `-- name: NotifyChange :exec
+SELECT pg_notify('scoreboard_changes', $1::TEXT)
+`
+
+func (q *Queries) NotifyChange(ctx context.Context, payload string) error {
+	_, err := q.db.Exec(ctx, notifyChange, payload)
+	return err
+}
+
+const pruneOutbox = `-- name: PruneOutbox :exec
+DELETE FROM scoreboard_outbox WHERE dispatched_at < $1
+`
+
+func (q *Queries) PruneOutbox(ctx context.Context, dispatchedAt pgtype.Timestamp) error {
+	_, err := q.db.Exec(ctx, pruneOutbox, dispatchedAt)
+	return err
+}
+
+const restoreEntry = `-- name: RestoreEntry :execrows
+INSERT INTO scoreboard_entries (
+    scoreboard_id, user_id, score, created_at, updated_at
+)
+SELECT $1, $2, $3, $4, $5
+WHERE EXISTS (SELECT 1 FROM users WHERE id = $2)
+`
+
+type RestoreEntryParams struct {
+	ScoreboardID uuid.UUID
+	UserID       uuid.UUID
+	Score        int64
+	CreatedAt    pgtype.Timestamp
+	UpdatedAt    pgtype.Timestamp
+}
+
+func (q *Queries) RestoreEntry(ctx context.Context, arg RestoreEntryParams) (int64, error) {
+	result, err := q.db.Exec(ctx, restoreEntry,
+		arg.ScoreboardID,
+		arg.UserID,
+		arg.Score,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreScoreboard = `-- name: RestoreScoreboard :exec
+INSERT INTO scoreboards (
+    id, name, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (id) DO UPDATE SET
+    name = EXCLUDED.name,
+    created_at = EXCLUDED.created_at,
+    updated_at = EXCLUDED.updated_at
+`
+
+type RestoreScoreboardParams struct {
+	ID        uuid.UUID
+	Name      pgtype.Text
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+}
+
+func (q *Queries) RestoreScoreboard(ctx context.Context, arg RestoreScoreboardParams) error {
+	_, err := q.db.Exec(ctx, restoreScoreboard,
+		arg.ID,
+		arg.Name,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const retryOutbox = `-- name: RetryOutbox :exec
+UPDATE scoreboard_outbox
+SET attempts = attempts + 1,
+    next_attempt_at = $2,
+    last_error = $3
+WHERE id = $1
+`
+
+type RetryOutboxParams struct {
+	ID            int64
+	NextAttemptAt pgtype.Timestamp
+	LastError     pgtype.Text
+}
+
+func (q *Queries) RetryOutbox(ctx context.Context, arg RetryOutboxParams) error {
+	_, err := q.db.Exec(ctx, retryOutbox, arg.ID, arg.NextAttemptAt, arg.LastError)
+	return err
+}
+
+const submitScore = `-- name: SubmitScore :one
+INSERT INTO scoreboard_entries (
+    scoreboard_id, user_id, score, created_at, updated_at
+) VALUES (
+    $1, $2, $3,
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+)
+ON CONFLICT (scoreboard_id, user_id) DO UPDATE SET
+    score = CASE $4::TEXT
+        WHEN 'add' THEN scoreboard_entries.score + EXCLUDED.score
+        WHEN 'keep_best' THEN GREATEST(scoreboard_entries.score, EXCLUDED.score)
+        ELSE EXCLUDED.score
+    END,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING scoreboard_id, user_id, score, created_at, updated_at
+`
+
+type SubmitScoreParams struct {
+	ScoreboardID uuid.UUID
+	UserID       uuid.UUID
+	Score        int64
+	Rule         string
+}
+
+func (q *Queries) SubmitScore(ctx context.Context, arg SubmitScoreParams) (ScoreboardEntry, error) {
+	row := q.db.QueryRow(ctx, submitScore,
+		arg.ScoreboardID,
+		arg.UserID,
+		arg.Score,
+		arg.Rule,
+	)
+	var i ScoreboardEntry
+	err := row.Scan(
+		&i.ScoreboardID,
+		&i.UserID,
+		&i.Score,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const update = `-- name: Update :one
 UPDATE scoreboards
 SET name = $2, updated_at = CURRENT_TIMESTAMP
@@ -115,3 +713,120 @@ func (q *Queries) Update(ctx context.Context, arg UpdateParams) (Scoreboard, err
 	)
 	return i, err
 }
+
+const updateEntry = `-- name: UpdateEntry :one
+UPDATE scoreboard_entries
+SET score = $3, updated_at = CURRENT_TIMESTAMP
+WHERE scoreboard_id = $1 AND user_id = $2
+RETURNING scoreboard_id, user_id, score, created_at, updated_at
+`
+
+type UpdateEntryParams struct {
+	ScoreboardID uuid.UUID
+	UserID       uuid.UUID
+	Score        int64
+}
+
+func (q *Queries) UpdateEntry(ctx context.Context, arg UpdateEntryParams) (ScoreboardEntry, error) {
+	row := q.db.QueryRow(ctx, updateEntry, arg.ScoreboardID, arg.UserID, arg.Score)
+	var i ScoreboardEntry
+	err := row.Scan(
+		&i.ScoreboardID,
+		&i.UserID,
+		&i.Score,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertHandicap = `-- name: UpsertHandicap :one
+INSERT INTO scoreboard_handicaps (
+    scoreboard_id, user_id, class, handicap, multiplier, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5,
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+)
+ON CONFLICT (scoreboard_id, user_id) DO UPDATE SET
+    class = EXCLUDED.class,
+    handicap = EXCLUDED.handicap,
+    multiplier = EXCLUDED.multiplier,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING scoreboard_id, user_id, class, handicap, multiplier, created_at, updated_at
+`
+
+type UpsertHandicapParams struct {
+	ScoreboardID uuid.UUID
+	UserID       uuid.UUID
+	Class        pgtype.Text
+	Handicap     float64
+	Multiplier   float64
+}
+
+func (q *Queries) UpsertHandicap(ctx context.Context, arg UpsertHandicapParams) (ScoreboardHandicap, error) {
+	row := q.db.QueryRow(ctx, upsertHandicap,
+		arg.ScoreboardID,
+		arg.UserID,
+		arg.Class,
+		arg.Handicap,
+		arg.Multiplier,
+	)
+	var i ScoreboardHandicap
+	err := row.Scan(
+		&i.ScoreboardID,
+		&i.UserID,
+		&i.Class,
+		&i.Handicap,
+		&i.Multiplier,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertSettings = `-- name: UpsertSettings :one
+INSERT INTO scoreboard_settings (
+    scoreboard_id, decay, half_life_hours, decay_per_day, submission_rule, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5,
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+)
+ON CONFLICT (scoreboard_id) DO UPDATE SET
+    decay = EXCLUDED.decay,
+    half_life_hours = EXCLUDED.half_life_hours,
+    decay_per_day = EXCLUDED.decay_per_day,
+    submission_rule = EXCLUDED.submission_rule,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING scoreboard_id, decay, half_life_hours, decay_per_day, created_at, updated_at, submission_rule
+`
+
+type UpsertSettingsParams struct {
+	ScoreboardID   uuid.UUID
+	Decay          string
+	HalfLifeHours  float64
+	DecayPerDay    float64
+	SubmissionRule string
+}
+
+func (q *Queries) UpsertSettings(ctx context.Context, arg UpsertSettingsParams) (ScoreboardSetting, error) {
+	row := q.db.QueryRow(ctx, upsertSettings,
+		arg.ScoreboardID,
+		arg.Decay,
+		arg.HalfLifeHours,
+		arg.DecayPerDay,
+		arg.SubmissionRule,
+	)
+	var i ScoreboardSetting
+	err := row.Scan(
+		&i.ScoreboardID,
+		&i.Decay,
+		&i.HalfLifeHours,
+		&i.DecayPerDay,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SubmissionRule,
+	)
+	return i, err
+}
@@ -0,0 +1,146 @@
+package scoreboard
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Decay string
+
+const (
+	// DecayNone ranks entries by their recorded score.
+	DecayNone Decay = "none"
+	// DecayHalfLife halves a score every HalfLifeHours without activity.
+	DecayHalfLife Decay = "half_life"
+	// DecayLinear takes DecayPerDay points off for every day without
+	// activity, down to zero.
+	DecayLinear Decay = "linear"
+)
+
+// DefaultSettings returns the settings of a scoreboard that was never
+// configured.
+func DefaultSettings(scoreboardID uuid.UUID) ScoreboardSetting {
+	return ScoreboardSetting{
+		ScoreboardID:   scoreboardID,
+		Decay:          string(DecayNone),
+		SubmissionRule: string(SubmitKeepBest),
+	}
+}
+
+// Standing is an entry placed on a leaderboard.
+type Standing struct {
+	ScoreboardEntry
+	Rank int64
+	// Effective is the score the entry is ranked by.
+	Effective float64
+	// Handicap is nil when the player has no handicap on the board.
+	Handicap *ScoreboardHandicap
+	// Adjusted is the effective score after the player's handicap, and
+	// AdjustedRank the rank it earns among the adjusted scores.
+	Adjusted     float64
+	AdjustedRank int64
+}
+
+// Effective returns the score of an entry as of now. Decay starts from the
+// last time the entry was written.
+func (s ScoreboardSetting) Effective(entry ScoreboardEntry, now time.Time) float64 {
+	score := float64(entry.Score)
+	idle := now.Sub(entry.UpdatedAt.Time)
+	if idle <= 0 {
+		return score
+	}
+	switch Decay(s.Decay) {
+	case DecayHalfLife:
+		if s.HalfLifeHours <= 0 {
+			return score
+		}
+		return score * math.Pow(0.5, idle.Hours()/s.HalfLifeHours)
+	case DecayLinear:
+		if score <= 0 {
+			return score
+		}
+		return math.Max(score-s.DecayPerDay*idle.Hours()/24, 0)
+	default:
+		return score
+	}
+}
+
+// Apply adjusts a score by the handicap: the score is scaled by the
+// multiplier, then the handicap allowance is added to it.
+func (h ScoreboardHandicap) Apply(score float64) float64 {
+	return score*h.Multiplier + h.Handicap
+}
+
+// Adjust applies each player's handicap and ranks the adjusted scores,
+// keeping the standings in their raw order.
+func Adjust(standings []Standing, handicaps []ScoreboardHandicap) {
+	byUser := make(map[uuid.UUID]ScoreboardHandicap, len(handicaps))
+	for _, handicap := range handicaps {
+		byUser[handicap.UserID] = handicap
+	}
+	for index := range standings {
+		standing := &standings[index]
+		standing.Adjusted = standing.Effective
+		if handicap, ok := byUser[standing.UserID]; ok {
+			standing.Handicap = &handicap
+			standing.Adjusted = handicap.Apply(standing.Effective)
+		}
+	}
+
+	order := ByAdjusted(standings)
+	for index, standing := range order {
+		rank := int64(index + 1)
+		if index > 0 && standing.Adjusted == order[index-1].Adjusted {
+			rank = order[index-1].AdjustedRank
+		}
+		order[index].AdjustedRank = rank
+	}
+	ranks := make(map[uuid.UUID]int64, len(order))
+	for _, standing := range order {
+		ranks[standing.UserID] = standing.AdjustedRank
+	}
+	for index := range standings {
+		standings[index].AdjustedRank = ranks[standings[index].UserID]
+	}
+}
+
+// ByAdjusted returns a copy of the standings ordered by adjusted score,
+// highest first, keeping the raw order between equal adjusted scores.
+func ByAdjusted(standings []Standing) []Standing {
+	ordered := make([]Standing, len(standings))
+	copy(ordered, standings)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Adjusted > ordered[j].Adjusted
+	})
+	return ordered
+}
+
+// Rank orders entries by their effective score, highest first. Entries with
+// equal effective scores share a rank and are listed in the order they were
+// last written.
+func Rank(entries []ScoreboardEntry, settings ScoreboardSetting, now time.Time) []Standing {
+	standings := make([]Standing, len(entries))
+	for index, entry := range entries {
+		standings[index] = Standing{
+			ScoreboardEntry: entry,
+			Effective:       settings.Effective(entry, now),
+		}
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Effective != standings[j].Effective {
+			return standings[i].Effective > standings[j].Effective
+		}
+		return standings[i].UpdatedAt.Time.Before(standings[j].UpdatedAt.Time)
+	})
+	for index := range standings {
+		if index > 0 && standings[index].Effective == standings[index-1].Effective {
+			standings[index].Rank = standings[index-1].Rank
+			continue
+		}
+		standings[index].Rank = int64(index + 1)
+	}
+	return standings
+}
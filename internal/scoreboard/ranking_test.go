@@ -0,0 +1,98 @@
+package scoreboard
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var now = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func entry(score int64, idle time.Duration) ScoreboardEntry {
+	return ScoreboardEntry{
+		UserID:    uuid.New(),
+		Score:     score,
+		UpdatedAt: pgtype.Timestamp{Time: now.Add(-idle), Valid: true},
+	}
+}
+
+func TestEffective(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings ScoreboardSetting
+		entry    ScoreboardEntry
+		want     float64
+	}{
+		{name: "No decay", settings: ScoreboardSetting{Decay: "none"}, entry: entry(100, 48*time.Hour), want: 100},
+		{name: "One half-life", settings: ScoreboardSetting{Decay: "half_life", HalfLifeHours: 24}, entry: entry(100, 24*time.Hour), want: 50},
+		{name: "Two half-lives", settings: ScoreboardSetting{Decay: "half_life", HalfLifeHours: 24}, entry: entry(100, 48*time.Hour), want: 25},
+		{name: "Linear", settings: ScoreboardSetting{Decay: "linear", DecayPerDay: 10}, entry: entry(100, 36*time.Hour), want: 85},
+		{name: "Linear stops at zero", settings: ScoreboardSetting{Decay: "linear", DecayPerDay: 10}, entry: entry(100, 30*24*time.Hour), want: 0},
+		{name: "Fresh entry", settings: ScoreboardSetting{Decay: "linear", DecayPerDay: 10}, entry: entry(100, 0), want: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.settings.Effective(tt.entry, now); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Effective() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	veteran := entry(1000, 10*24*time.Hour)
+	regular := entry(300, time.Hour)
+	newcomer := entry(300, time.Hour)
+	settings := ScoreboardSetting{Decay: "half_life", HalfLifeHours: 48}
+
+	standings := Rank([]ScoreboardEntry{veteran, regular, newcomer}, settings, now)
+	if standings[0].UserID == veteran.UserID || standings[1].UserID == veteran.UserID {
+		t.Fatalf("Rank() kept the inactive veteran in the top two")
+	}
+	if standings[0].Rank != 1 || standings[1].Rank != 1 || standings[2].Rank != 3 {
+		t.Errorf("Rank() ranks = %d, %d, %d, want 1, 1, 3", standings[0].Rank, standings[1].Rank, standings[2].Rank)
+	}
+
+	standings = Rank([]ScoreboardEntry{regular, veteran}, DefaultSettings(uuid.Nil), now)
+	if standings[0].UserID != veteran.UserID {
+		t.Errorf("Rank() without decay put %v first, want the veteran", standings[0].UserID)
+	}
+}
+
+func TestAdjust(t *testing.T) {
+	scratch := entry(80, 0)
+	amateur := entry(60, 0)
+	junior := entry(50, 0)
+	standings := Rank([]ScoreboardEntry{scratch, amateur, junior}, DefaultSettings(uuid.Nil), now)
+
+	Adjust(standings, []ScoreboardHandicap{
+		{UserID: amateur.UserID, Handicap: 25, Multiplier: 1},
+		{UserID: junior.UserID, Multiplier: 1.6},
+	})
+
+	wantRaw := []uuid.UUID{scratch.UserID, amateur.UserID, junior.UserID}
+	wantAdjusted := map[uuid.UUID]float64{scratch.UserID: 80, amateur.UserID: 85, junior.UserID: 80}
+	wantAdjustedRank := map[uuid.UUID]int64{scratch.UserID: 2, amateur.UserID: 1, junior.UserID: 2}
+	for index, standing := range standings {
+		if standing.UserID != wantRaw[index] || standing.Rank != int64(index+1) {
+			t.Errorf("Adjust() reordered the raw standings at %d", index)
+		}
+		if standing.Adjusted != wantAdjusted[standing.UserID] {
+			t.Errorf("Adjust() adjusted score = %v, want %v", standing.Adjusted, wantAdjusted[standing.UserID])
+		}
+		if standing.AdjustedRank != wantAdjustedRank[standing.UserID] {
+			t.Errorf("Adjust() adjusted rank = %d, want %d", standing.AdjustedRank, wantAdjustedRank[standing.UserID])
+		}
+	}
+	if standings[0].Handicap != nil || standings[1].Handicap == nil {
+		t.Errorf("Adjust() attached handicaps to the wrong players")
+	}
+
+	if ordered := ByAdjusted(standings); ordered[0].UserID != amateur.UserID || ordered[1].UserID != scratch.UserID {
+		t.Errorf("ByAdjusted() put %v and %v first, want the amateur then the scratch player", ordered[0].UserID, ordered[1].UserID)
+	}
+}
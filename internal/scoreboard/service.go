@@ -2,19 +2,90 @@ package scoreboard
 
 import (
 	"context"
+	"errors"
+	"math"
+	"time"
+
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+var (
+	ErrEntryNotFound = errors.New("entry not found")
+	ErrEntryExists   = errors.New("player already has an entry on this scoreboard")
+	ErrUnknownEntity = errors.New("scoreboard or player does not exist")
+)
+
+// Postgres error codes raised by the entry constraints.
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+)
+
+// cacheCapacity is the number of scoreboards whose rankings are kept in
+// memory.
+const cacheCapacity = 1024
+
+// EntryChange describes a write to a player's entry. Before is nil when the
+// entry was created and After is nil when it was deleted.
+type EntryChange struct {
+	ScoreboardID uuid.UUID
+	UserID       uuid.UUID
+	Before       *ScoreboardEntry
+	After        *ScoreboardEntry
+	// EventID is the position of the change in the event log.
+	EventID int64
+	// Remote is set for changes made by another instance. Before is unknown
+	// for them, and observers with side effects already ran there.
+	Remote bool
+}
+
+// Observer is notified after an entry has been written.
+type Observer interface {
+	ObserveEntry(ctx context.Context, change EntryChange) error
+}
+
+// ScoreboardChange describes a renamed or deleted scoreboard. After is nil
+// when the scoreboard was deleted.
+type ScoreboardChange struct {
+	ScoreboardID uuid.UUID
+	After        *Scoreboard
+	EventID      int64
+	// Remote is set for changes made by another instance.
+	Remote bool
+}
+
+// ScoreboardObserver is implemented by observers that also want to know
+// about renamed and deleted scoreboards.
+type ScoreboardObserver interface {
+	ObserveScoreboard(ctx context.Context, change ScoreboardChange) error
+}
+
 type Service struct {
-	logger *zap.Logger
-	tracer trace.Tracer
-	query  Querier
+	// instance tells the changes of this process apart from those of other
+	// instances sharing the database.
+	instance   uuid.UUID
+	logger     *zap.Logger
+	tracer     trace.Tracer
+	query      Querier
+	transactor Transactor
+	// cache ranks the boards without decay. It is the first observer, so
+	// later observers read rankings that include the change.
+	cache     *Cache
+	observers []Observer
+	metrics   Metrics
+}
+
+// Metrics is told how long each service operation took and how it ended.
+type Metrics interface {
+	ObserveOperation(operation string, duration time.Duration, err error)
 }
 
 type Querier interface {
@@ -23,17 +94,47 @@ type Querier interface {
 	Create(ctx context.Context, name pgtype.Text) (Scoreboard, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	Update(ctx context.Context, arg UpdateParams) (Scoreboard, error)
+	ListEntries(ctx context.Context, scoreboardID uuid.UUID) ([]ScoreboardEntry, error)
+	GetEntry(ctx context.Context, arg GetEntryParams) (ScoreboardEntry, error)
+	CreateEntry(ctx context.Context, arg CreateEntryParams) (ScoreboardEntry, error)
+	UpdateEntry(ctx context.Context, arg UpdateEntryParams) (ScoreboardEntry, error)
+	DeleteEntry(ctx context.Context, arg DeleteEntryParams) error
+	GetSettings(ctx context.Context, scoreboardID uuid.UUID) (ScoreboardSetting, error)
+	UpsertSettings(ctx context.Context, arg UpsertSettingsParams) (ScoreboardSetting, error)
+	LockEntry(ctx context.Context, arg LockEntryParams) (ScoreboardEntry, error)
+	SubmitScore(ctx context.Context, arg SubmitScoreParams) (ScoreboardEntry, error)
+	ListHandicaps(ctx context.Context, scoreboardID uuid.UUID) ([]ScoreboardHandicap, error)
+	UpsertHandicap(ctx context.Context, arg UpsertHandicapParams) (ScoreboardHandicap, error)
+	DeleteHandicap(ctx context.Context, arg DeleteHandicapParams) error
+	AppendEvent(ctx context.Context, arg AppendEventParams) (ScoreboardEvent, error)
+	ListEvents(ctx context.Context, arg ListEventsParams) ([]ScoreboardEvent, error)
+	ListScoreboardEvents(ctx context.Context, arg ListScoreboardEventsParams) ([]ScoreboardEvent, error)
+	ClearEntries(ctx context.Context) error
+	RestoreScoreboard(ctx context.Context, arg RestoreScoreboardParams) error
+	RestoreEntry(ctx context.Context, arg RestoreEntryParams) (int64, error)
 }
 
 func NewService(logger *zap.Logger, db *pgxpool.Pool) *Service {
+	return NewServiceWithQuerier(logger, New(db), NewPostgresTransactor(logger, db))
+}
+
+// NewServiceWithQuerier builds a service on any storage backend. The
+// transactor must run its queries against the same data as query.
+func NewServiceWithQuerier(logger *zap.Logger, query Querier, transactor Transactor) *Service {
+	cache := NewCache(cacheCapacity)
 	return &Service{
-		logger: logger,
-		tracer: otel.Tracer("scoreboard/service"),
-		query:  New(db),
+		instance:   uuid.New(),
+		logger:     logger,
+		tracer:     otel.Tracer("scoreboard/service"),
+		query:      query,
+		transactor: transactor,
+		cache:      cache,
+		observers:  []Observer{cache},
 	}
 }
 
-func (s Service) List(ctx context.Context) ([]Scoreboard, error) {
+func (s Service) List(ctx context.Context) (_ []Scoreboard, err error) {
+	defer s.measure("List", time.Now(), &err)
 	traceCtx, span := s.tracer.Start(ctx, "GetAll")
 	defer span.End()
 	scoreboard, err := s.query.List(traceCtx)
@@ -43,7 +144,8 @@ func (s Service) List(ctx context.Context) ([]Scoreboard, error) {
 	return scoreboard, nil
 }
 
-func (s Service) Get(ctx context.Context, id uuid.UUID) (Scoreboard, error) {
+func (s Service) Get(ctx context.Context, id uuid.UUID) (_ Scoreboard, err error) {
+	defer s.measure("Get", time.Now(), &err)
 	traceCtx, span := s.tracer.Start(ctx, "GetByID")
 	defer span.End()
 	scoreboard, err := s.query.Get(traceCtx, id)
@@ -53,20 +155,427 @@ func (s Service) Get(ctx context.Context, id uuid.UUID) (Scoreboard, error) {
 	return scoreboard, nil
 }
 
-func (s Service) Create(ctx context.Context, name pgtype.Text) (Scoreboard, error) {
+func (s Service) Create(ctx context.Context, name pgtype.Text) (_ Scoreboard, err error) {
+	defer s.measure("Create", time.Now(), &err)
 	traceCtx, span := s.tracer.Start(ctx, "Create")
 	defer span.End()
-	createdScoreboard, err := s.query.Create(traceCtx, name)
+	var createdScoreboard Scoreboard
+	err = s.transactor.InTx(traceCtx, func(queries Querier) error {
+		var err error
+		createdScoreboard, err = queries.Create(traceCtx, name)
+		if err != nil {
+			return err
+		}
+		data := EventData{Name: createdScoreboard.Name.String}
+		_, err = s.record(traceCtx, queries, EventScoreboardCreated, createdScoreboard.ID, uuid.Nil, data, createdScoreboard.CreatedAt)
+		return err
+	})
 	if err != nil {
 		return Scoreboard{}, err
 	}
 	return createdScoreboard, nil
 }
 
-func (s Service) Delete(ctx context.Context, id uuid.UUID) error {
-	return s.query.Delete(ctx, id)
+func (s Service) Delete(ctx context.Context, id uuid.UUID) (err error) {
+	defer s.measure("Delete", time.Now(), &err)
+	var change *ScoreboardChange
+	err = s.transactor.InTx(ctx, func(queries Querier) error {
+		change = nil
+		if _, err := queries.Get(ctx, id); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil
+			}
+			return err
+		}
+		if err := queries.Delete(ctx, id); err != nil {
+			return err
+		}
+		eventID, err := s.record(ctx, queries, EventScoreboardDeleted, id, uuid.Nil, EventData{}, pgtype.Timestamp{})
+		if err != nil {
+			return err
+		}
+		change = &ScoreboardChange{ScoreboardID: id, EventID: eventID}
+		return s.stage(ctx, queries, OutboxMessage{Scoreboard: change})
+	})
+	if err != nil {
+		return err
+	}
+	s.cache.Forget(id)
+	if change != nil {
+		s.notifyScoreboard(ctx, *change)
+	}
+	return nil
+}
+
+func (s Service) Update(ctx context.Context, arg UpdateParams) (_ Scoreboard, err error) {
+	defer s.measure("Update", time.Now(), &err)
+	var updated Scoreboard
+	var change ScoreboardChange
+	err = s.transactor.InTx(ctx, func(queries Querier) error {
+		var err error
+		updated, err = queries.Update(ctx, arg)
+		if err != nil {
+			return err
+		}
+		eventID, err := s.record(ctx, queries, EventScoreboardRenamed, updated.ID, uuid.Nil, EventData{Name: updated.Name.String}, updated.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		change = ScoreboardChange{ScoreboardID: updated.ID, After: &updated, EventID: eventID}
+		return s.stage(ctx, queries, OutboxMessage{Scoreboard: &change})
+	})
+	if err != nil {
+		return Scoreboard{}, err
+	}
+	s.notifyScoreboard(ctx, change)
+	return updated, nil
+}
+
+// Observe registers an observer for entry writes. It must be called before
+// the service starts handling requests.
+func (s *Service) Observe(observer Observer) {
+	s.observers = append(s.observers, observer)
+}
+
+// Instrument reports every operation of the service to metrics. Like
+// Observe, it must be called before the service starts handling requests.
+func (s *Service) Instrument(metrics Metrics) {
+	s.metrics = metrics
+}
+
+// measure reports an operation that began at start and ended with err.
+func (s Service) measure(operation string, start time.Time, err *error) {
+	if s.metrics != nil {
+		s.metrics.ObserveOperation(operation, time.Since(start), *err)
+	}
+}
+
+// Settings returns how the scoreboard ranks its entries, with defaults for a
+// scoreboard that was never configured.
+func (s Service) Settings(ctx context.Context, scoreboardID uuid.UUID) (_ ScoreboardSetting, err error) {
+	defer s.measure("Settings", time.Now(), &err)
+	traceCtx, span := s.tracer.Start(ctx, "Settings")
+	defer span.End()
+	settings, err := s.query.GetSettings(traceCtx, scoreboardID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return DefaultSettings(scoreboardID), nil
+		}
+		return ScoreboardSetting{}, err
+	}
+	return settings, nil
+}
+
+func (s Service) UpdateSettings(ctx context.Context, arg UpsertSettingsParams) (_ ScoreboardSetting, err error) {
+	defer s.measure("UpdateSettings", time.Now(), &err)
+	traceCtx, span := s.tracer.Start(ctx, "UpdateSettings")
+	defer span.End()
+	settings, err := s.query.UpsertSettings(traceCtx, arg)
+	if err != nil {
+		return ScoreboardSetting{}, translate(err)
+	}
+	return settings, nil
+}
+
+func (s Service) ListHandicaps(ctx context.Context, scoreboardID uuid.UUID) (_ []ScoreboardHandicap, err error) {
+	defer s.measure("ListHandicaps", time.Now(), &err)
+	traceCtx, span := s.tracer.Start(ctx, "ListHandicaps")
+	defer span.End()
+	handicaps, err := s.query.ListHandicaps(traceCtx, scoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	return handicaps, nil
+}
+
+// SetHandicap assigns a handicap to a player, replacing an earlier one.
+func (s Service) SetHandicap(ctx context.Context, arg UpsertHandicapParams) (_ ScoreboardHandicap, err error) {
+	defer s.measure("SetHandicap", time.Now(), &err)
+	traceCtx, span := s.tracer.Start(ctx, "SetHandicap")
+	defer span.End()
+	handicap, err := s.query.UpsertHandicap(traceCtx, arg)
+	if err != nil {
+		return ScoreboardHandicap{}, translate(err)
+	}
+	return handicap, nil
+}
+
+func (s Service) DeleteHandicap(ctx context.Context, scoreboardID, userID uuid.UUID) (err error) {
+	defer s.measure("DeleteHandicap", time.Now(), &err)
+	traceCtx, span := s.tracer.Start(ctx, "DeleteHandicap")
+	defer span.End()
+	return s.query.DeleteHandicap(traceCtx, DeleteHandicapParams{ScoreboardID: scoreboardID, UserID: userID})
+}
+
+// Leaderboard lists the entries of a scoreboard from the highest score down,
+// with every player's handicap applied alongside.
+func (s Service) Leaderboard(ctx context.Context, scoreboardID uuid.UUID) (_ []Standing, err error) {
+	defer s.measure("Leaderboard", time.Now(), &err)
+	traceCtx, span := s.tracer.Start(ctx, "Leaderboard")
+	defer span.End()
+
+	standings, err := s.rank(traceCtx, scoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	handicaps, err := s.query.ListHandicaps(traceCtx, scoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	Adjust(standings, handicaps)
+	return standings, nil
+}
+
+// Top returns the first limit standings of a scoreboard by raw score,
+// without handicaps.
+func (s Service) Top(ctx context.Context, scoreboardID uuid.UUID, limit int) (_ []Standing, err error) {
+	defer s.measure("Top", time.Now(), &err)
+	traceCtx, span := s.tracer.Start(ctx, "Top")
+	defer span.End()
+
+	settings, err := s.Settings(traceCtx, scoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	if Decay(settings.Decay) != DecayNone {
+		standings, err := s.decayed(traceCtx, settings)
+		if err != nil {
+			return nil, err
+		}
+		return standings[:min(limit, len(standings))], nil
+	}
+
+	return s.cache.Top(traceCtx, scoreboardID, limit, s.entries)
+}
+
+// Standing returns a player's entry with its raw rank on the scoreboard.
+func (s Service) Standing(ctx context.Context, scoreboardID, userID uuid.UUID) (_ Standing, err error) {
+	defer s.measure("Standing", time.Now(), &err)
+	traceCtx, span := s.tracer.Start(ctx, "Standing")
+	defer span.End()
+
+	settings, err := s.Settings(traceCtx, scoreboardID)
+	if err != nil {
+		return Standing{}, err
+	}
+	if Decay(settings.Decay) != DecayNone {
+		standings, err := s.decayed(traceCtx, settings)
+		if err != nil {
+			return Standing{}, err
+		}
+		for _, standing := range standings {
+			if standing.UserID == userID {
+				return standing, nil
+			}
+		}
+		return Standing{}, ErrEntryNotFound
+	}
+
+	standing, found, err := s.cache.Standing(traceCtx, scoreboardID, userID, s.entries)
+	if err != nil {
+		return Standing{}, err
+	}
+	if !found {
+		return Standing{}, ErrEntryNotFound
+	}
+	return standing, nil
+}
+
+// Count returns the number of players on a scoreboard.
+func (s Service) Count(ctx context.Context, scoreboardID uuid.UUID) (_ int, err error) {
+	defer s.measure("Count", time.Now(), &err)
+	traceCtx, span := s.tracer.Start(ctx, "Count")
+	defer span.End()
+
+	settings, err := s.Settings(traceCtx, scoreboardID)
+	if err != nil {
+		return 0, err
+	}
+	if Decay(settings.Decay) != DecayNone {
+		entries, err := s.query.ListEntries(traceCtx, scoreboardID)
+		return len(entries), err
+	}
+	return s.cache.Count(traceCtx, scoreboardID, s.entries)
+}
+
+// entries loads a scoreboard's entries into the ranking cache, failing with
+// pgx.ErrNoRows when the scoreboard does not exist.
+func (s Service) entries(ctx context.Context, scoreboardID uuid.UUID) ([]ScoreboardEntry, error) {
+	if _, err := s.query.Get(ctx, scoreboardID); err != nil {
+		return nil, err
+	}
+	return s.query.ListEntries(ctx, scoreboardID)
+}
+
+// rank orders the entries by their raw score. Boards without decay are read
+// from the ranking cache; decaying scores depend on the time of the request
+// and are ranked on every call.
+func (s Service) rank(ctx context.Context, scoreboardID uuid.UUID) ([]Standing, error) {
+	settings, err := s.Settings(ctx, scoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	if Decay(settings.Decay) != DecayNone {
+		return s.decayed(ctx, settings)
+	}
+
+	return s.cache.Top(ctx, scoreboardID, math.MaxInt, s.entries)
+}
+
+func (s Service) decayed(ctx context.Context, settings ScoreboardSetting) ([]Standing, error) {
+	entries, err := s.query.ListEntries(ctx, settings.ScoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	return Rank(entries, settings, time.Now().UTC()), nil
+}
+
+func (s Service) GetEntry(ctx context.Context, scoreboardID, userID uuid.UUID) (_ ScoreboardEntry, err error) {
+	defer s.measure("GetEntry", time.Now(), &err)
+	traceCtx, span := s.tracer.Start(ctx, "GetEntry")
+	defer span.End()
+	entry, err := s.query.GetEntry(traceCtx, GetEntryParams{ScoreboardID: scoreboardID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ScoreboardEntry{}, ErrEntryNotFound
+		}
+		return ScoreboardEntry{}, err
+	}
+	return entry, nil
+}
+
+// Events lists at most limit events of a scoreboard appended after the event
+// after, oldest first.
+func (s Service) Events(ctx context.Context, scoreboardID uuid.UUID, after int64, limit int32) (_ []ScoreboardEvent, err error) {
+	defer s.measure("Events", time.Now(), &err)
+	traceCtx, span := s.tracer.Start(ctx, "Events")
+	defer span.End()
+	return s.query.ListScoreboardEvents(traceCtx, ListScoreboardEventsParams{ScoreboardID: scoreboardID, ID: after, Limit: limit})
+}
+
+func (s Service) CreateEntry(ctx context.Context, arg CreateEntryParams) (_ ScoreboardEntry, err error) {
+	defer s.measure("CreateEntry", time.Now(), &err)
+	traceCtx, span := s.tracer.Start(ctx, "CreateEntry")
+	defer span.End()
+	var entry ScoreboardEntry
+	var change EntryChange
+	err = s.transactor.InTx(traceCtx, func(queries Querier) error {
+		var err error
+		entry, err = queries.CreateEntry(traceCtx, arg)
+		if err != nil {
+			return err
+		}
+		eventID, err := s.record(traceCtx, queries, EventEntryCreated, arg.ScoreboardID, arg.UserID, EventData{Score: entry.Score}, entry.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		change = EntryChange{ScoreboardID: arg.ScoreboardID, UserID: arg.UserID, After: &entry, EventID: eventID}
+		return s.stage(traceCtx, queries, OutboxMessage{Entry: &change})
+	})
+	if err != nil {
+		return ScoreboardEntry{}, translate(err)
+	}
+	s.notify(traceCtx, change)
+	return entry, nil
 }
 
-func (s Service) Update(ctx context.Context, arg UpdateParams) (Scoreboard, error) {
-	return s.query.Update(ctx, arg)
+// UpdateEntry replaces a player's score. The entry is locked while it is
+// read and written, so observers see the score it actually replaced.
+func (s Service) UpdateEntry(ctx context.Context, arg UpdateEntryParams) (_ ScoreboardEntry, err error) {
+	defer s.measure("UpdateEntry", time.Now(), &err)
+	traceCtx, span := s.tracer.Start(ctx, "UpdateEntry")
+	defer span.End()
+	var before, entry ScoreboardEntry
+	var change EntryChange
+	err = s.transactor.InTx(traceCtx, func(queries Querier) error {
+		var err error
+		before, err = queries.LockEntry(traceCtx, LockEntryParams{ScoreboardID: arg.ScoreboardID, UserID: arg.UserID})
+		if err != nil {
+			return err
+		}
+		entry, err = queries.UpdateEntry(traceCtx, arg)
+		if err != nil {
+			return err
+		}
+		eventID, err := s.record(traceCtx, queries, EventScoreSet, arg.ScoreboardID, arg.UserID, EventData{Score: entry.Score}, entry.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		change = EntryChange{ScoreboardID: arg.ScoreboardID, UserID: arg.UserID, Before: &before, After: &entry, EventID: eventID}
+		return s.stage(traceCtx, queries, OutboxMessage{Entry: &change})
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ScoreboardEntry{}, ErrEntryNotFound
+		}
+		return ScoreboardEntry{}, err
+	}
+	s.notify(traceCtx, change)
+	return entry, nil
+}
+
+func (s Service) DeleteEntry(ctx context.Context, scoreboardID, userID uuid.UUID) (err error) {
+	defer s.measure("DeleteEntry", time.Now(), &err)
+	traceCtx, span := s.tracer.Start(ctx, "DeleteEntry")
+	defer span.End()
+	var change EntryChange
+	err = s.transactor.InTx(traceCtx, func(queries Querier) error {
+		before, err := queries.LockEntry(traceCtx, LockEntryParams{ScoreboardID: scoreboardID, UserID: userID})
+		if err != nil {
+			return err
+		}
+		if err := queries.DeleteEntry(traceCtx, DeleteEntryParams{ScoreboardID: scoreboardID, UserID: userID}); err != nil {
+			return err
+		}
+		eventID, err := s.record(traceCtx, queries, EventEntryRemoved, scoreboardID, userID, EventData{}, pgtype.Timestamp{})
+		if err != nil {
+			return err
+		}
+		change = EntryChange{ScoreboardID: scoreboardID, UserID: userID, Before: &before, EventID: eventID}
+		return s.stage(traceCtx, queries, OutboxMessage{Entry: &change})
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrEntryNotFound
+		}
+		return err
+	}
+	s.notify(traceCtx, change)
+	return nil
+}
+
+// notify hands a committed change to every observer. The write already
+// happened, so observer failures are logged rather than returned.
+func (s Service) notify(ctx context.Context, change EntryChange) {
+	for _, observer := range s.observers {
+		if err := observer.ObserveEntry(ctx, change); err != nil {
+			s.logger.Error("Entry observer failed", zap.Error(err))
+		}
+	}
+}
+
+// notifyScoreboard hands a committed scoreboard change to the observers that
+// implement ScoreboardObserver.
+func (s Service) notifyScoreboard(ctx context.Context, change ScoreboardChange) {
+	for _, observer := range s.observers {
+		if observer, ok := observer.(ScoreboardObserver); ok {
+			if err := observer.ObserveScoreboard(ctx, change); err != nil {
+				s.logger.Error("Scoreboard observer failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// translate maps constraint violations onto the errors of this package.
+func translate(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case foreignKeyViolation:
+			return ErrUnknownEntity
+		case uniqueViolation:
+			return ErrEntryExists
+		}
+	}
+	return err
 }
@@ -0,0 +1,73 @@
+package scoreboard
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type SubmissionRule string
+
+const (
+	// SubmitKeepBest only changes an entry when the new score beats it.
+	SubmitKeepBest SubmissionRule = "keep_best"
+	// SubmitReplace always overwrites the entry with the new score.
+	SubmitReplace SubmissionRule = "replace"
+	// SubmitAdd adds the new score to the entry.
+	SubmitAdd SubmissionRule = "add"
+)
+
+// Submit records a score for a player according to the scoreboard's
+// submission rule, creating the entry when the player has none. The entry is
+// locked while the score is applied, so concurrent submissions for the same
+// player are applied one after the other.
+func (s Service) Submit(ctx context.Context, arg SubmitScoreParams) (_ ScoreboardEntry, err error) {
+	defer s.measure("Submit", time.Now(), &err)
+	traceCtx, span := s.tracer.Start(ctx, "Submit")
+	defer span.End()
+
+	settings, err := s.Settings(traceCtx, arg.ScoreboardID)
+	if err != nil {
+		return ScoreboardEntry{}, err
+	}
+	arg.Rule = settings.SubmissionRule
+
+	var entry ScoreboardEntry
+	var change *EntryChange
+	err = s.transactor.InTx(traceCtx, func(queries Querier) error {
+		change = nil
+		var before *ScoreboardEntry
+		current, err := queries.LockEntry(traceCtx, LockEntryParams{ScoreboardID: arg.ScoreboardID, UserID: arg.UserID})
+		switch {
+		case err == nil:
+			before = &current
+		case !errors.Is(err, pgx.ErrNoRows):
+			return err
+		}
+		entry, err = queries.SubmitScore(traceCtx, arg)
+		if err != nil {
+			return err
+		}
+		data := EventData{Score: entry.Score, Submitted: arg.Score, Rule: arg.Rule}
+		eventID, err := s.record(traceCtx, queries, EventScoreSubmitted, arg.ScoreboardID, arg.UserID, data, entry.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		// A submission that left the score as it was changes nothing.
+		if before != nil && before.Score == entry.Score {
+			return nil
+		}
+		change = &EntryChange{ScoreboardID: arg.ScoreboardID, UserID: arg.UserID, Before: before, After: &entry, EventID: eventID}
+		return s.stage(traceCtx, queries, OutboxMessage{Entry: change})
+	})
+	if err != nil {
+		return ScoreboardEntry{}, translate(err)
+	}
+
+	if change != nil {
+		s.notify(traceCtx, *change)
+	}
+	return entry, nil
+}
@@ -0,0 +1,94 @@
+package scoreboard
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// Transactor runs a function against a Querier whose queries share one
+// transaction, committing it when the function succeeds.
+type Transactor interface {
+	InTx(ctx context.Context, fn func(queries Querier) error) error
+}
+
+// Postgres error codes that mean a transaction lost a race with another one
+// and can safely be run again.
+const (
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+const (
+	maxTxAttempts = 5
+	txBackoff     = 10 * time.Millisecond
+)
+
+// PostgresTransactor runs transactions on a Postgres pool.
+type PostgresTransactor struct {
+	db      *pgxpool.Pool
+	queries *Queries
+	logger  *zap.Logger
+}
+
+func NewPostgresTransactor(logger *zap.Logger, db *pgxpool.Pool) PostgresTransactor {
+	return PostgresTransactor{
+		db:      db,
+		queries: New(db),
+		logger:  logger,
+	}
+}
+
+// InTx runs fn in a serializable transaction and commits it when fn
+// succeeds. When Postgres aborts the transaction because it conflicted with a
+// concurrent one, the whole transaction is run again with a short randomized
+// backoff, so fn must not have side effects outside the transaction.
+func (t PostgresTransactor) InTx(ctx context.Context, fn func(queries Querier) error) error {
+	var err error
+	for attempt := 1; attempt <= maxTxAttempts; attempt++ {
+		err = t.runTx(ctx, fn)
+		if !retryable(err) {
+			return err
+		}
+		t.logger.Debug("Retrying conflicting transaction", zap.Int("attempt", attempt), zap.Error(err))
+
+		backoff := txBackoff*time.Duration(attempt) + rand.N(txBackoff)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+	}
+	return err
+}
+
+func (t PostgresTransactor) runTx(ctx context.Context, fn func(queries Querier) error) error {
+	tx, err := t.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := fn(t.queries.WithTx(tx)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// retryable reports whether err aborted a transaction that may succeed when
+// run again.
+func retryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == serializationFailure || pgErr.Code == deadlockDetected
+	}
+	return false
+}
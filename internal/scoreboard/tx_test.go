@@ -0,0 +1,32 @@
+package scoreboard
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "Serialization failure", err: &pgconn.PgError{Code: "40001"}, want: true},
+		{name: "Deadlock", err: &pgconn.PgError{Code: "40P01"}, want: true},
+		{name: "Wrapped serialization failure", err: fmt.Errorf("commit: %w", &pgconn.PgError{Code: "40001"}), want: true},
+		{name: "Unique violation", err: &pgconn.PgError{Code: "23505"}, want: false},
+		{name: "Other error", err: errors.New("connection refused"), want: false},
+		{name: "No error", err: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryable(tt.err); got != tt.want {
+				t.Errorf("retryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,356 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"scoreboard-api/internal/scoreboard"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mattn/go-sqlite3"
+)
+
+// Scoreboards implements scoreboard.Querier and scoreboard.Transactor.
+type Scoreboards struct {
+	conn *sql.DB
+	db   dbtx
+}
+
+var (
+	_ scoreboard.Querier    = Scoreboards{}
+	_ scoreboard.Transactor = Scoreboards{}
+)
+
+// InTx runs fn in a transaction and commits it when fn succeeds. SQLite has
+// a single writer, so transactions are never aborted by a conflict.
+func (s Scoreboards) InTx(ctx context.Context, fn func(queries scoreboard.Querier) error) error {
+	tx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := fn(Scoreboards{conn: s.conn, db: tx}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+const scoreboardColumns = `id, name, created_at, updated_at`
+
+func scanScoreboard(row scanner) (scoreboard.Scoreboard, error) {
+	var board scoreboard.Scoreboard
+	err := row.Scan(&board.ID, &board.Name, &board.CreatedAt, &board.UpdatedAt)
+	return board, noRows(err)
+}
+
+func (s Scoreboards) List(ctx context.Context) ([]scoreboard.Scoreboard, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+scoreboardColumns+` FROM scoreboards ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var scoreboards []scoreboard.Scoreboard
+	for rows.Next() {
+		board, err := scanScoreboard(rows)
+		if err != nil {
+			return nil, err
+		}
+		scoreboards = append(scoreboards, board)
+	}
+	return scoreboards, rows.Err()
+}
+
+func (s Scoreboards) Get(ctx context.Context, id uuid.UUID) (scoreboard.Scoreboard, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+scoreboardColumns+` FROM scoreboards WHERE id = ?`, id)
+	return scanScoreboard(row)
+}
+
+func (s Scoreboards) Create(ctx context.Context, name pgtype.Text) (scoreboard.Scoreboard, error) {
+	row := s.db.QueryRowContext(ctx, `
+INSERT INTO scoreboards (id, name, created_at, updated_at)
+VALUES (?, ?, ?, ?)
+RETURNING `+scoreboardColumns, uuid.New(), name, now(), now())
+	return scanScoreboard(row)
+}
+
+func (s Scoreboards) Update(ctx context.Context, arg scoreboard.UpdateParams) (scoreboard.Scoreboard, error) {
+	row := s.db.QueryRowContext(ctx, `
+UPDATE scoreboards
+SET name = ?, updated_at = ?
+WHERE id = ?
+RETURNING `+scoreboardColumns, arg.Name, now(), arg.ID)
+	return scanScoreboard(row)
+}
+
+func (s Scoreboards) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM scoreboards WHERE id = ?`, id)
+	return err
+}
+
+const entryColumns = `scoreboard_id, user_id, score, created_at, updated_at`
+
+func scanEntry(row scanner) (scoreboard.ScoreboardEntry, error) {
+	var entry scoreboard.ScoreboardEntry
+	err := row.Scan(&entry.ScoreboardID, &entry.UserID, &entry.Score, &entry.CreatedAt, &entry.UpdatedAt)
+	return entry, noRows(err)
+}
+
+func (s Scoreboards) ListEntries(ctx context.Context, scoreboardID uuid.UUID) ([]scoreboard.ScoreboardEntry, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+entryColumns+` FROM scoreboard_entries WHERE scoreboard_id = ?`, scoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var entries []scoreboard.ScoreboardEntry
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+func (s Scoreboards) GetEntry(ctx context.Context, arg scoreboard.GetEntryParams) (scoreboard.ScoreboardEntry, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+entryColumns+` FROM scoreboard_entries WHERE scoreboard_id = ? AND user_id = ?`, arg.ScoreboardID, arg.UserID)
+	return scanEntry(row)
+}
+
+// LockEntry reads an entry. Transactions hold the database write lock from
+// the start, so no row lock is needed.
+func (s Scoreboards) LockEntry(ctx context.Context, arg scoreboard.LockEntryParams) (scoreboard.ScoreboardEntry, error) {
+	return s.GetEntry(ctx, scoreboard.GetEntryParams(arg))
+}
+
+func (s Scoreboards) CreateEntry(ctx context.Context, arg scoreboard.CreateEntryParams) (scoreboard.ScoreboardEntry, error) {
+	row := s.db.QueryRowContext(ctx, `
+INSERT INTO scoreboard_entries (scoreboard_id, user_id, score, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?)
+RETURNING `+entryColumns, arg.ScoreboardID, arg.UserID, arg.Score, now(), now())
+	entry, err := scanEntry(row)
+	if err != nil {
+		return scoreboard.ScoreboardEntry{}, translate(err)
+	}
+	return entry, nil
+}
+
+func (s Scoreboards) UpdateEntry(ctx context.Context, arg scoreboard.UpdateEntryParams) (scoreboard.ScoreboardEntry, error) {
+	row := s.db.QueryRowContext(ctx, `
+UPDATE scoreboard_entries
+SET score = ?, updated_at = ?
+WHERE scoreboard_id = ? AND user_id = ?
+RETURNING `+entryColumns, arg.Score, now(), arg.ScoreboardID, arg.UserID)
+	return scanEntry(row)
+}
+
+// SubmitScore creates an entry or applies the score to the existing one
+// following the submission rule.
+func (s Scoreboards) SubmitScore(ctx context.Context, arg scoreboard.SubmitScoreParams) (scoreboard.ScoreboardEntry, error) {
+	row := s.db.QueryRowContext(ctx, `
+INSERT INTO scoreboard_entries (scoreboard_id, user_id, score, created_at, updated_at)
+VALUES (?1, ?2, ?3, ?5, ?5)
+ON CONFLICT (scoreboard_id, user_id) DO UPDATE SET
+    score = CASE ?4
+        WHEN 'add' THEN scoreboard_entries.score + excluded.score
+        WHEN 'keep_best' THEN MAX(scoreboard_entries.score, excluded.score)
+        ELSE excluded.score
+    END,
+    updated_at = excluded.updated_at
+RETURNING `+entryColumns, arg.ScoreboardID, arg.UserID, arg.Score, arg.Rule, now())
+	entry, err := scanEntry(row)
+	if err != nil {
+		return scoreboard.ScoreboardEntry{}, translate(err)
+	}
+	return entry, nil
+}
+
+func (s Scoreboards) DeleteEntry(ctx context.Context, arg scoreboard.DeleteEntryParams) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM scoreboard_entries WHERE scoreboard_id = ? AND user_id = ?`, arg.ScoreboardID, arg.UserID)
+	return err
+}
+
+const settingsColumns = `scoreboard_id, decay, half_life_hours, decay_per_day, created_at, updated_at, submission_rule`
+
+func scanSettings(row scanner) (scoreboard.ScoreboardSetting, error) {
+	var settings scoreboard.ScoreboardSetting
+	err := row.Scan(
+		&settings.ScoreboardID,
+		&settings.Decay,
+		&settings.HalfLifeHours,
+		&settings.DecayPerDay,
+		&settings.CreatedAt,
+		&settings.UpdatedAt,
+		&settings.SubmissionRule,
+	)
+	return settings, noRows(err)
+}
+
+func (s Scoreboards) GetSettings(ctx context.Context, scoreboardID uuid.UUID) (scoreboard.ScoreboardSetting, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+settingsColumns+` FROM scoreboard_settings WHERE scoreboard_id = ?`, scoreboardID)
+	return scanSettings(row)
+}
+
+func (s Scoreboards) UpsertSettings(ctx context.Context, arg scoreboard.UpsertSettingsParams) (scoreboard.ScoreboardSetting, error) {
+	row := s.db.QueryRowContext(ctx, `
+INSERT INTO scoreboard_settings (
+    scoreboard_id, decay, half_life_hours, decay_per_day, submission_rule, created_at, updated_at
+) VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?6)
+ON CONFLICT (scoreboard_id) DO UPDATE SET
+    decay = excluded.decay,
+    half_life_hours = excluded.half_life_hours,
+    decay_per_day = excluded.decay_per_day,
+    submission_rule = excluded.submission_rule,
+    updated_at = excluded.updated_at
+RETURNING `+settingsColumns, arg.ScoreboardID, arg.Decay, arg.HalfLifeHours, arg.DecayPerDay, arg.SubmissionRule, now())
+	settings, err := scanSettings(row)
+	if err != nil {
+		return scoreboard.ScoreboardSetting{}, translate(err)
+	}
+	return settings, nil
+}
+
+const handicapColumns = `scoreboard_id, user_id, class, handicap, multiplier, created_at, updated_at`
+
+func scanHandicap(row scanner) (scoreboard.ScoreboardHandicap, error) {
+	var handicap scoreboard.ScoreboardHandicap
+	err := row.Scan(
+		&handicap.ScoreboardID,
+		&handicap.UserID,
+		&handicap.Class,
+		&handicap.Handicap,
+		&handicap.Multiplier,
+		&handicap.CreatedAt,
+		&handicap.UpdatedAt,
+	)
+	return handicap, noRows(err)
+}
+
+func (s Scoreboards) ListHandicaps(ctx context.Context, scoreboardID uuid.UUID) ([]scoreboard.ScoreboardHandicap, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+handicapColumns+` FROM scoreboard_handicaps WHERE scoreboard_id = ?`, scoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var handicaps []scoreboard.ScoreboardHandicap
+	for rows.Next() {
+		handicap, err := scanHandicap(rows)
+		if err != nil {
+			return nil, err
+		}
+		handicaps = append(handicaps, handicap)
+	}
+	return handicaps, rows.Err()
+}
+
+func (s Scoreboards) UpsertHandicap(ctx context.Context, arg scoreboard.UpsertHandicapParams) (scoreboard.ScoreboardHandicap, error) {
+	row := s.db.QueryRowContext(ctx, `
+INSERT INTO scoreboard_handicaps (
+    scoreboard_id, user_id, class, handicap, multiplier, created_at, updated_at
+) VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?6)
+ON CONFLICT (scoreboard_id, user_id) DO UPDATE SET
+    class = excluded.class,
+    handicap = excluded.handicap,
+    multiplier = excluded.multiplier,
+    updated_at = excluded.updated_at
+RETURNING `+handicapColumns, arg.ScoreboardID, arg.UserID, arg.Class, arg.Handicap, arg.Multiplier, now())
+	handicap, err := scanHandicap(row)
+	if err != nil {
+		return scoreboard.ScoreboardHandicap{}, translate(err)
+	}
+	return handicap, nil
+}
+
+func (s Scoreboards) DeleteHandicap(ctx context.Context, arg scoreboard.DeleteHandicapParams) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM scoreboard_handicaps WHERE scoreboard_id = ? AND user_id = ?`, arg.ScoreboardID, arg.UserID)
+	return err
+}
+
+const eventColumns = `id, scoreboard_id, user_id, type, payload, created_at`
+
+func scanEvent(row scanner) (scoreboard.ScoreboardEvent, error) {
+	var event scoreboard.ScoreboardEvent
+	var payload string
+	err := row.Scan(&event.ID, &event.ScoreboardID, &event.UserID, &event.Type, &payload, &event.CreatedAt)
+	event.Payload = []byte(payload)
+	return event, noRows(err)
+}
+
+func (s Scoreboards) listEvents(ctx context.Context, query string, args ...any) ([]scoreboard.ScoreboardEvent, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var events []scoreboard.ScoreboardEvent
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func (s Scoreboards) AppendEvent(ctx context.Context, arg scoreboard.AppendEventParams) (scoreboard.ScoreboardEvent, error) {
+	row := s.db.QueryRowContext(ctx, `
+INSERT INTO scoreboard_events (scoreboard_id, user_id, type, payload, created_at)
+VALUES (?, ?, ?, ?, ?)
+RETURNING `+eventColumns,
+		arg.ScoreboardID, arg.UserID, arg.Type, string(arg.Payload), format(arg.CreatedAt))
+	return scanEvent(row)
+}
+
+func (s Scoreboards) ListEvents(ctx context.Context, arg scoreboard.ListEventsParams) ([]scoreboard.ScoreboardEvent, error) {
+	return s.listEvents(ctx, `SELECT `+eventColumns+` FROM scoreboard_events WHERE id > ? ORDER BY id LIMIT ?`, arg.ID, arg.Limit)
+}
+
+func (s Scoreboards) ListScoreboardEvents(ctx context.Context, arg scoreboard.ListScoreboardEventsParams) ([]scoreboard.ScoreboardEvent, error) {
+	return s.listEvents(ctx, `SELECT `+eventColumns+` FROM scoreboard_events WHERE scoreboard_id = ? AND id > ? ORDER BY id LIMIT ?`, arg.ScoreboardID, arg.ID, arg.Limit)
+}
+
+func (s Scoreboards) ClearEntries(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM scoreboard_entries`)
+	return err
+}
+
+func (s Scoreboards) RestoreScoreboard(ctx context.Context, arg scoreboard.RestoreScoreboardParams) error {
+	_, err := s.db.ExecContext(ctx, `
+INSERT INTO scoreboards (id, name, created_at, updated_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET
+    name = excluded.name,
+    created_at = excluded.created_at,
+    updated_at = excluded.updated_at`,
+		arg.ID, arg.Name, format(arg.CreatedAt), format(arg.UpdatedAt))
+	return err
+}
+
+func (s Scoreboards) RestoreEntry(ctx context.Context, arg scoreboard.RestoreEntryParams) (int64, error) {
+	result, err := s.db.ExecContext(ctx, `
+INSERT INTO scoreboard_entries (scoreboard_id, user_id, score, created_at, updated_at)
+SELECT ?, ?, ?, ?, ?
+WHERE EXISTS (SELECT 1 FROM users WHERE id = ?)`,
+		arg.ScoreboardID, arg.UserID, arg.Score, format(arg.CreatedAt), format(arg.UpdatedAt), arg.UserID)
+	if err != nil {
+		return 0, translate(err)
+	}
+	return result.RowsAffected()
+}
+
+// translate maps constraint violations onto the errors of the scoreboard
+// package.
+func translate(err error) error {
+	switch constraint(err) {
+	case sqlite3.ErrConstraintForeignKey:
+		return scoreboard.ErrUnknownEntity
+	case sqlite3.ErrConstraintPrimaryKey, sqlite3.ErrConstraintUnique:
+		return scoreboard.ErrEntryExists
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+// Package sqlite stores scoreboards and users in a SQLite file, for single
+// binary deployments. The schema is created by the migrations in
+// internal/database/sqlite_migrations.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mattn/go-sqlite3"
+)
+
+// timestampLayout has a fixed width, so timestamps sort as text.
+const timestampLayout = "2006-01-02 15:04:05.000000"
+
+// DB is an open SQLite database.
+type DB struct {
+	conn *sql.DB
+}
+
+// dbtx is satisfied by both *sql.DB and *sql.Tx.
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// Open opens the database file at path, creating it when it does not exist.
+// Transactions take the write lock when they begin, so two of them never
+// deadlock upgrading a read lock.
+func Open(path string) (*DB, error) {
+	dsn := fmt.Sprintf("file:%s?_foreign_keys=on&_busy_timeout=5000&_journal_mode=WAL&_txlock=immediate", path)
+	conn, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.Ping(); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return &DB{conn: conn}, nil
+}
+
+func (db *DB) Close() error {
+	return db.conn.Close()
+}
+
+// Scoreboards returns the scoreboard queries of the database.
+func (db *DB) Scoreboards() Scoreboards {
+	return Scoreboards{conn: db.conn, db: db.conn}
+}
+
+// Users returns the user queries of the database.
+func (db *DB) Users() Users {
+	return Users{db: db.conn}
+}
+
+func now() string {
+	return time.Now().UTC().Format(timestampLayout)
+}
+
+func format(timestamp pgtype.Timestamp) string {
+	return timestamp.Time.UTC().Format(timestampLayout)
+}
+
+// noRows reports sql.ErrNoRows as pgx.ErrNoRows, which the services check
+// for whatever the backend.
+func noRows(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return pgx.ErrNoRows
+	}
+	return err
+}
+
+// constraint returns the extended code of a violated constraint, or zero when
+// err is not a constraint violation.
+func constraint(err error) sqlite3.ErrNoExtended {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code == sqlite3.ErrConstraint {
+		return sqliteErr.ExtendedCode
+	}
+	return 0
+}
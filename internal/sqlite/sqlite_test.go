@@ -0,0 +1,145 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"scoreboard-api/internal/database"
+	"scoreboard-api/internal/eventlog"
+	"scoreboard-api/internal/scoreboard"
+	"scoreboard-api/internal/user"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+func open(t *testing.T) *DB {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "scoreboard.db")
+	if err := database.MigrationUp("file://../database/sqlite_migrations", "sqlite3://"+path, zap.NewNop()); err != nil {
+		t.Fatalf("MigrationUp() error = %v", err)
+	}
+	db, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestScoreboardService(t *testing.T) {
+	ctx := context.Background()
+	db := open(t)
+	users := user.NewServiceWithQuerier(zap.NewNop(), db.Users())
+	service := scoreboard.NewServiceWithQuerier(zap.NewNop(), db.Scoreboards(), db.Scoreboards())
+
+	grinder, err := users.Create(ctx, "grinder@example.com")
+	if err != nil {
+		t.Fatalf("Create() user error = %v", err)
+	}
+	casual, err := users.Create(ctx, "casual@example.com")
+	if err != nil {
+		t.Fatalf("Create() user error = %v", err)
+	}
+	if _, err := users.Create(ctx, "casual@example.com"); !errors.Is(err, user.ErrDuplicateEmail) {
+		t.Errorf("Create() with a taken email error = %v, want ErrDuplicateEmail", err)
+	}
+	if found, err := users.ListByIDs(ctx, []uuid.UUID{grinder.ID, uuid.New(), casual.ID}); err != nil || len(found) != 2 {
+		t.Errorf("ListByIDs() = %d users, %v, want both players", len(found), err)
+	}
+
+	board, err := service.Create(ctx, pgtype.Text{String: "Arcade", Valid: true})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := service.UpdateSettings(ctx, scoreboard.UpsertSettingsParams{
+		ScoreboardID:   board.ID,
+		Decay:          string(scoreboard.DecayNone),
+		SubmissionRule: string(scoreboard.SubmitAdd),
+	}); err != nil {
+		t.Fatalf("UpdateSettings() error = %v", err)
+	}
+
+	// Concurrent submissions must all count towards the total.
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := service.Submit(ctx, scoreboard.SubmitScoreParams{ScoreboardID: board.ID, UserID: grinder.ID, Score: 5}); err != nil {
+				t.Errorf("Submit() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if _, err := service.CreateEntry(ctx, scoreboard.CreateEntryParams{ScoreboardID: board.ID, UserID: casual.ID, Score: 40}); err != nil {
+		t.Fatalf("CreateEntry() error = %v", err)
+	}
+	if _, err := service.CreateEntry(ctx, scoreboard.CreateEntryParams{ScoreboardID: board.ID, UserID: casual.ID}); !errors.Is(err, scoreboard.ErrEntryExists) {
+		t.Errorf("CreateEntry() twice error = %v, want ErrEntryExists", err)
+	}
+	if _, err := service.CreateEntry(ctx, scoreboard.CreateEntryParams{ScoreboardID: board.ID, UserID: uuid.New()}); !errors.Is(err, scoreboard.ErrUnknownEntity) {
+		t.Errorf("CreateEntry() for an absent user error = %v, want ErrUnknownEntity", err)
+	}
+
+	standings, err := service.Leaderboard(ctx, board.ID)
+	if err != nil {
+		t.Fatalf("Leaderboard() error = %v", err)
+	}
+	if len(standings) != 2 || standings[0].UserID != grinder.ID || standings[0].Score != 100 {
+		t.Fatalf("Leaderboard() = %+v, want the grinder first with 100", standings)
+	}
+
+	if _, err := service.UpdateEntry(ctx, scoreboard.UpdateEntryParams{ScoreboardID: board.ID, UserID: uuid.New(), Score: 1}); !errors.Is(err, scoreboard.ErrEntryNotFound) {
+		t.Errorf("UpdateEntry() of an absent player error = %v, want ErrEntryNotFound", err)
+	}
+	if _, err := service.SetHandicap(ctx, scoreboard.UpsertHandicapParams{ScoreboardID: board.ID, UserID: casual.ID, Handicap: 70, Multiplier: 1}); err != nil {
+		t.Fatalf("SetHandicap() error = %v", err)
+	}
+	standings, err = service.Leaderboard(ctx, board.ID)
+	if err != nil {
+		t.Fatalf("Leaderboard() error = %v", err)
+	}
+	if ordered := scoreboard.ByAdjusted(standings); ordered[0].UserID != casual.ID {
+		t.Errorf("ByAdjusted() put %v first, want the handicapped player", ordered[0].UserID)
+	}
+
+	events, err := service.Events(ctx, board.ID, 0, 100)
+	if err != nil {
+		t.Fatalf("Events() error = %v", err)
+	}
+	if page, err := service.Events(ctx, board.ID, events[1].ID, 3); err != nil || len(page) != 3 || page[0].ID != events[2].ID {
+		t.Errorf("Events() after the second event = %d events, %v, want the next 3", len(page), err)
+	}
+	if len(events) != 22 || events[len(events)-1].Type != string(scoreboard.EventEntryCreated) {
+		t.Fatalf("Events() returned %d events, want 22 ending with the casual entry", len(events))
+	}
+	if _, err := db.Scoreboards().UpdateEntry(ctx, scoreboard.UpdateEntryParams{ScoreboardID: board.ID, UserID: grinder.ID, Score: 0}); err != nil {
+		t.Fatalf("UpdateEntry() error = %v", err)
+	}
+	// Deleting a user removes their entries without logging it.
+	if err := db.Users().Delete(ctx, casual.ID); err != nil {
+		t.Fatalf("Delete() user error = %v", err)
+	}
+	projection, err := eventlog.Rebuild(ctx, db.Scoreboards(), false)
+	if err != nil {
+		t.Fatalf("Rebuild() error = %v", err)
+	}
+	if projection.Skipped != 1 {
+		t.Errorf("Rebuild() skipped %d entries, want the deleted player's", projection.Skipped)
+	}
+	if entry, _ := db.Scoreboards().GetEntry(ctx, scoreboard.GetEntryParams{ScoreboardID: board.ID, UserID: grinder.ID}); entry.Score != 100 {
+		t.Errorf("Rebuild() restored a score of %d, want 100", entry.Score)
+	}
+
+	if err := service.Delete(ctx, board.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if entries, _ := db.Scoreboards().ListEntries(ctx, board.ID); len(entries) != 0 {
+		t.Errorf("Delete() left %d entries behind", len(entries))
+	}
+}
@@ -0,0 +1,112 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+
+	"scoreboard-api/internal/user"
+
+	"github.com/google/uuid"
+	"github.com/mattn/go-sqlite3"
+)
+
+// Users implements user.Querier.
+type Users struct {
+	db dbtx
+}
+
+var _ user.Querier = Users{}
+
+const userColumns = `id, email, name, given_name, family_name, picture, email_verified, locale, created_at, updated_at`
+
+func scanUser(row scanner) (user.User, error) {
+	var found user.User
+	err := row.Scan(
+		&found.ID,
+		&found.Email,
+		&found.Name,
+		&found.GivenName,
+		&found.FamilyName,
+		&found.Picture,
+		&found.EmailVerified,
+		&found.Locale,
+		&found.CreatedAt,
+		&found.UpdatedAt,
+	)
+	return found, noRows(err)
+}
+
+func (u Users) GetByID(ctx context.Context, id uuid.UUID) (user.User, error) {
+	return scanUser(u.db.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE id = ?`, id))
+}
+
+func (u Users) GetByEmail(ctx context.Context, email string) (user.User, error) {
+	return scanUser(u.db.QueryRowContext(ctx, `SELECT `+userColumns+` FROM users WHERE email = ?`, email))
+}
+
+func (u Users) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]user.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	rows, err := u.db.QueryContext(ctx, `SELECT `+userColumns+` FROM users WHERE id IN (`+placeholders+`)`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var users []user.User
+	for rows.Next() {
+		found, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, found)
+	}
+	return users, rows.Err()
+}
+
+func (u Users) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	var exists bool
+	err := u.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE email = ?)`, email).Scan(&exists)
+	return exists, err
+}
+
+func (u Users) Create(ctx context.Context, arg user.CreateParams) (user.User, error) {
+	row := u.db.QueryRowContext(ctx, `
+INSERT INTO users (
+    id, email, name, given_name, family_name, picture, email_verified, locale, created_at, updated_at
+) VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?9)
+RETURNING `+userColumns,
+		uuid.New(), arg.Email, arg.Name, arg.GivenName, arg.FamilyName, arg.Picture, arg.EmailVerified, arg.Locale, now())
+	created, err := scanUser(row)
+	if constraint(err) == sqlite3.ErrConstraintUnique {
+		return user.User{}, user.ErrDuplicateEmail
+	}
+	return created, err
+}
+
+// Update changes the profile of the user with the given email.
+func (u Users) Update(ctx context.Context, arg user.UpdateParams) (user.User, error) {
+	row := u.db.QueryRowContext(ctx, `
+UPDATE users SET
+    name = ?2,
+    given_name = ?3,
+    family_name = ?4,
+    picture = ?5,
+    email_verified = ?6,
+    locale = ?7,
+    updated_at = ?8
+WHERE email = ?1
+RETURNING `+userColumns,
+		arg.Email, arg.Name, arg.GivenName, arg.FamilyName, arg.Picture, arg.EmailVerified, arg.Locale, now())
+	return scanUser(row)
+}
+
+func (u Users) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := u.db.ExecContext(ctx, `DELETE FROM users WHERE id = ?`, id)
+	return err
+}
@@ -0,0 +1,141 @@
+package stage
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+type Aggregation string
+
+const (
+	// AggregationWeightedSum adds up every stage score times its stage weight.
+	AggregationWeightedSum Aggregation = "weighted_sum"
+	// AggregationBestOf only counts the Count best weighted stage scores.
+	AggregationBestOf Aggregation = "best_of"
+	// AggregationDropLowest leaves out the Count worst weighted stage scores.
+	AggregationDropLowest Aggregation = "drop_lowest"
+)
+
+// DefaultSettings returns the aggregation of a scoreboard that was never
+// configured.
+func DefaultSettings(scoreboardID uuid.UUID) StageSetting {
+	return StageSetting{ScoreboardID: scoreboardID, Aggregation: string(AggregationWeightedSum)}
+}
+
+// Placing is a player's position in a single stage.
+type Placing struct {
+	Rank   int
+	UserID uuid.UUID
+	Score  int64
+}
+
+// StageResult is what one stage contributed to a player's overall standing.
+type StageResult struct {
+	StageID uuid.UUID
+	// Score is nil when the player did not take part in the stage.
+	Score    *int64
+	Weighted float64
+	// Counted is false when the aggregation left the stage out.
+	Counted bool
+}
+
+// Standing is a player's overall position across the stages.
+type Standing struct {
+	Rank   int
+	UserID uuid.UUID
+	Total  float64
+	Stages []StageResult
+}
+
+// Place ranks the entries of a single stage, highest score first. Players
+// with equal scores share a rank.
+func Place(entries []StageEntry) []Placing {
+	ordered := make([]StageEntry, len(entries))
+	copy(ordered, entries)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Score != ordered[j].Score {
+			return ordered[i].Score > ordered[j].Score
+		}
+		return ordered[i].UpdatedAt.Time.Before(ordered[j].UpdatedAt.Time)
+	})
+
+	placings := make([]Placing, len(ordered))
+	for index, entry := range ordered {
+		placings[index] = Placing{Rank: index + 1, UserID: entry.UserID, Score: entry.Score}
+		if index > 0 && entry.Score == ordered[index-1].Score {
+			placings[index].Rank = placings[index-1].Rank
+		}
+	}
+	return placings
+}
+
+// Aggregate computes the overall standings from the entries of every stage.
+// A stage a player skipped counts as zero, so it is the first to be dropped.
+func Aggregate(stages []Stage, entries []StageEntry, settings StageSetting) []Standing {
+	stageIndex := make(map[uuid.UUID]int, len(stages))
+	for index, stage := range stages {
+		stageIndex[stage.ID] = index
+	}
+
+	var standings []Standing
+	playerIndex := map[uuid.UUID]int{}
+	for _, entry := range entries {
+		stage, ok := stageIndex[entry.StageID]
+		if !ok {
+			continue
+		}
+		player, ok := playerIndex[entry.UserID]
+		if !ok {
+			player = len(standings)
+			playerIndex[entry.UserID] = player
+			results := make([]StageResult, len(stages))
+			for index, detail := range stages {
+				results[index] = StageResult{StageID: detail.ID}
+			}
+			standings = append(standings, Standing{UserID: entry.UserID, Stages: results})
+		}
+		score := entry.Score
+		result := &standings[player].Stages[stage]
+		result.Score = &score
+		result.Weighted = float64(score) * stages[stage].Weight
+	}
+
+	counted := len(stages)
+	switch Aggregation(settings.Aggregation) {
+	case AggregationBestOf:
+		counted = min(int(settings.Count), len(stages))
+	case AggregationDropLowest:
+		counted = max(len(stages)-int(settings.Count), 0)
+	}
+
+	for index := range standings {
+		standing := &standings[index]
+		order := make([]int, len(standing.Stages))
+		for stage := range order {
+			order[stage] = stage
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return standing.Stages[order[i]].Weighted > standing.Stages[order[j]].Weighted
+		})
+		for _, stage := range order[:counted] {
+			standing.Stages[stage].Counted = true
+			standing.Total += standing.Stages[stage].Weighted
+		}
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Total != standings[j].Total {
+			return standings[i].Total > standings[j].Total
+		}
+		return standings[i].UserID.String() < standings[j].UserID.String()
+	})
+	for index := range standings {
+		if index > 0 && standings[index].Total == standings[index-1].Total {
+			standings[index].Rank = standings[index-1].Rank
+			continue
+		}
+		standings[index].Rank = index + 1
+	}
+	return standings
+}
@@ -0,0 +1,86 @@
+package stage
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestAggregate(t *testing.T) {
+	stages := []Stage{
+		{ID: uuid.New(), Name: "Qualifier 1", Position: 1, Weight: 1},
+		{ID: uuid.New(), Name: "Qualifier 2", Position: 2, Weight: 1},
+		{ID: uuid.New(), Name: "Final", Position: 3, Weight: 2},
+	}
+	steady, spiky := uuid.New(), uuid.New()
+	entries := []StageEntry{
+		{StageID: stages[0].ID, UserID: steady, Score: 50},
+		{StageID: stages[1].ID, UserID: steady, Score: 50},
+		{StageID: stages[2].ID, UserID: steady, Score: 30},
+		{StageID: stages[0].ID, UserID: spiky, Score: 100},
+		{StageID: stages[2].ID, UserID: spiky, Score: 20},
+	}
+
+	tests := []struct {
+		name       string
+		settings   StageSetting
+		wantFirst  uuid.UUID
+		wantTotals map[uuid.UUID]float64
+	}{
+		{
+			// Steady: 50 + 50 + 60 = 160. Spiky: 100 + 0 + 40 = 140.
+			name:       "Weighted sum",
+			settings:   StageSetting{Aggregation: "weighted_sum"},
+			wantFirst:  steady,
+			wantTotals: map[uuid.UUID]float64{steady: 160, spiky: 140},
+		},
+		{
+			name:       "Best one of three",
+			settings:   StageSetting{Aggregation: "best_of", Count: 1},
+			wantFirst:  spiky,
+			wantTotals: map[uuid.UUID]float64{steady: 60, spiky: 100},
+		},
+		{
+			// The skipped qualifier is Spiky's lowest stage.
+			name:       "Drop the lowest",
+			settings:   StageSetting{Aggregation: "drop_lowest", Count: 1},
+			wantFirst:  spiky,
+			wantTotals: map[uuid.UUID]float64{steady: 110, spiky: 140},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standings := Aggregate(stages, entries, tt.settings)
+			if len(standings) != 2 {
+				t.Fatalf("Aggregate() returned %d standings, want 2", len(standings))
+			}
+			if standings[0].UserID != tt.wantFirst || standings[0].Rank != 1 {
+				t.Errorf("Aggregate()[0] = %v ranked %d, want %v ranked 1", standings[0].UserID, standings[0].Rank, tt.wantFirst)
+			}
+			for _, standing := range standings {
+				if standing.Total != tt.wantTotals[standing.UserID] {
+					t.Errorf("Aggregate() total for %v = %v, want %v", standing.UserID, standing.Total, tt.wantTotals[standing.UserID])
+				}
+			}
+		})
+	}
+}
+
+func TestPlace(t *testing.T) {
+	entries := []StageEntry{
+		{UserID: uuid.New(), Score: 10},
+		{UserID: uuid.New(), Score: 30},
+		{UserID: uuid.New(), Score: 30},
+	}
+	placings := Place(entries)
+	wantRanks := []int{1, 1, 3}
+	for index, placing := range placings {
+		if placing.Rank != wantRanks[index] {
+			t.Errorf("Place()[%d].Rank = %d, want %d", index, placing.Rank, wantRanks[index])
+		}
+	}
+	if placings[2].Score != 10 {
+		t.Errorf("Place()[2].Score = %d, want 10", placings[2].Score)
+	}
+}
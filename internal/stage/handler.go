@@ -0,0 +1,404 @@
+package stage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"scoreboard-api/internal/scoreboard"
+)
+
+type Store interface {
+	Settings(ctx context.Context, scoreboardID uuid.UUID) (StageSetting, error)
+	UpdateSettings(ctx context.Context, arg UpsertSettingsParams) (StageSetting, error)
+	ListStages(ctx context.Context, scoreboardID uuid.UUID) ([]Stage, error)
+	CreateStage(ctx context.Context, arg CreateStageParams) (Stage, error)
+	DeleteStage(ctx context.Context, scoreboardID, stageID uuid.UUID) error
+	RecordEntry(ctx context.Context, arg UpsertEntryParams) (StageEntry, error)
+	DeleteEntry(ctx context.Context, scoreboardID, stageID, userID uuid.UUID) error
+	StageStandings(ctx context.Context, scoreboardID, stageID uuid.UUID) ([]Placing, error)
+	Standings(ctx context.Context, scoreboardID uuid.UUID) ([]Stage, []Standing, error)
+}
+
+// SettingsPayload defines the expected request body for choosing how stages are combined.
+type SettingsPayload struct {
+	Aggregation string `json:"aggregation" validate:"required,oneof=weighted_sum best_of drop_lowest"`
+	Count       int32  `json:"count" validate:"min=0"`
+}
+
+// StagePayload defines the expected request body for adding a stage.
+type StagePayload struct {
+	Name     string   `json:"name" validate:"required,max=255"`
+	Position int32    `json:"position" validate:"min=0"`
+	Weight   *float64 `json:"weight" validate:"omitempty,min=0"`
+}
+
+// EntryPayload defines the expected request body for a player's stage score.
+type EntryPayload struct {
+	Score *int64 `json:"score" validate:"required"`
+}
+
+type SettingsResponse struct {
+	Aggregation string `json:"aggregation"`
+	Count       int32  `json:"count"`
+}
+
+type StageResponse struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	Position  int32   `json:"position"`
+	Weight    float64 `json:"weight"`
+	CreatedAt string  `json:"createdAt"`
+}
+
+type EntryResponse struct {
+	StageID   string `json:"stageId"`
+	UserID    string `json:"userId"`
+	Score     int64  `json:"score"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+type PlacingResponse struct {
+	Rank   int    `json:"rank"`
+	UserID string `json:"userId"`
+	Score  int64  `json:"score"`
+}
+
+type StageResultResponse struct {
+	StageID  string  `json:"stageId"`
+	Score    *int64  `json:"score"`
+	Weighted float64 `json:"weighted"`
+	Counted  bool    `json:"counted"`
+}
+
+type StandingResponse struct {
+	Rank   int                   `json:"rank"`
+	UserID string                `json:"userId"`
+	Total  float64               `json:"total"`
+	Stages []StageResultResponse `json:"stages"`
+}
+
+type StandingsResponse struct {
+	Stages    []StageResponse    `json:"stages"`
+	Standings []StandingResponse `json:"standings"`
+}
+
+type Handler struct {
+	validator *validator.Validate
+	tracer    trace.Tracer
+	logger    *zap.Logger
+	store     Store
+}
+
+func NewHandler(v *validator.Validate, logger *zap.Logger, s Store) Handler {
+	return Handler{
+		validator: v,
+		tracer:    otel.Tracer("stage/handler"),
+		logger:    logger,
+		store:     s,
+	}
+}
+
+func (h Handler) GetSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	settings, err := h.store.Settings(ctx, scoreboardID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, GenerateSettingsResponse(settings))
+}
+
+func (h Handler) UpdateSettingsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+
+	var payload SettingsPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validator.Struct(payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if Aggregation(payload.Aggregation) == AggregationBestOf && payload.Count == 0 {
+		http.Error(w, "count must be positive for best_of", http.StatusBadRequest)
+		return
+	}
+
+	settings, err := h.store.UpdateSettings(ctx, UpsertSettingsParams{
+		ScoreboardID: scoreboardID,
+		Aggregation:  payload.Aggregation,
+		Count:        payload.Count,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, GenerateSettingsResponse(settings))
+}
+
+func (h Handler) ListStagesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	stages, err := h.store.ListStages(ctx, scoreboardID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	response := make([]StageResponse, len(stages))
+	for index, stage := range stages {
+		response[index] = GenerateStageResponse(stage)
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+func (h Handler) CreateStageHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+
+	var payload StagePayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validator.Struct(payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	params := CreateStageParams{
+		ScoreboardID: scoreboardID,
+		Name:         payload.Name,
+		Position:     payload.Position,
+		Weight:       1,
+	}
+	if payload.Weight != nil {
+		params.Weight = *payload.Weight
+	}
+	stage, err := h.store.CreateStage(ctx, params)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusCreated, GenerateStageResponse(stage))
+}
+
+func (h Handler) DeleteStageHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	stageID, err := uuid.Parse(r.PathValue("stageID"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	if err := h.store.DeleteStage(ctx, scoreboardID, stageID); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h Handler) RecordEntryHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	stageID, err := uuid.Parse(r.PathValue("stageID"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	userID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+
+	var payload EntryPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validator.Struct(payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entry, err := h.store.RecordEntry(ctx, UpsertEntryParams{
+		ScoreboardID: scoreboardID,
+		StageID:      stageID,
+		UserID:       userID,
+		Score:        *payload.Score,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, GenerateEntryResponse(entry))
+}
+
+func (h Handler) DeleteEntryHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	stageID, err := uuid.Parse(r.PathValue("stageID"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	userID, err := uuid.Parse(r.PathValue("userID"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	if err := h.store.DeleteEntry(ctx, scoreboardID, stageID, userID); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h Handler) StageStandingsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	stageID, err := uuid.Parse(r.PathValue("stageID"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	placings, err := h.store.StageStandings(ctx, scoreboardID, stageID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	response := make([]PlacingResponse, len(placings))
+	for index, placing := range placings {
+		response[index] = PlacingResponse{
+			Rank:   placing.Rank,
+			UserID: placing.UserID.String(),
+			Score:  placing.Score,
+		}
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+func (h Handler) StandingsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	stages, standings, err := h.store.Standings(ctx, scoreboardID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, GenerateStandingsResponse(stages, standings))
+}
+
+func (h Handler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrUnknownEntity):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrDuplicate):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		h.logger.Error("Stage request failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func GenerateSettingsResponse(settings StageSetting) SettingsResponse {
+	return SettingsResponse{
+		Aggregation: settings.Aggregation,
+		Count:       settings.Count,
+	}
+}
+
+func GenerateStageResponse(stage Stage) StageResponse {
+	return StageResponse{
+		ID:        stage.ID.String(),
+		Name:      stage.Name,
+		Position:  stage.Position,
+		Weight:    stage.Weight,
+		CreatedAt: stage.CreatedAt.Time.Format(time.RFC3339),
+	}
+}
+
+func GenerateEntryResponse(entry StageEntry) EntryResponse {
+	return EntryResponse{
+		StageID:   entry.StageID.String(),
+		UserID:    entry.UserID.String(),
+		Score:     entry.Score,
+		UpdatedAt: entry.UpdatedAt.Time.Format(time.RFC3339),
+	}
+}
+
+func GenerateStandingsResponse(stages []Stage, standings []Standing) StandingsResponse {
+	response := StandingsResponse{
+		Stages:    make([]StageResponse, len(stages)),
+		Standings: make([]StandingResponse, len(standings)),
+	}
+	for index, stage := range stages {
+		response.Stages[index] = GenerateStageResponse(stage)
+	}
+	for index, standing := range standings {
+		results := make([]StageResultResponse, len(standing.Stages))
+		for stage, result := range standing.Stages {
+			results[stage] = StageResultResponse{
+				StageID:  result.StageID.String(),
+				Score:    result.Score,
+				Weighted: result.Weighted,
+				Counted:  result.Counted,
+			}
+		}
+		response.Standings[index] = StandingResponse{
+			Rank:   standing.Rank,
+			UserID: standing.UserID.String(),
+			Total:  standing.Total,
+			Stages: results,
+		}
+	}
+	return response
+}
@@ -0,0 +1,58 @@
+-- name: GetSettings :one
+SELECT * FROM stage_settings WHERE scoreboard_id = $1;
+
+-- name: UpsertSettings :one
+INSERT INTO stage_settings (
+    scoreboard_id, aggregation, count, created_at, updated_at
+) VALUES (
+    $1, $2, $3,
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+)
+ON CONFLICT (scoreboard_id) DO UPDATE SET
+    aggregation = EXCLUDED.aggregation,
+    count = EXCLUDED.count,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ListStages :many
+SELECT * FROM stages
+WHERE scoreboard_id = $1
+ORDER BY position;
+
+-- name: CreateStage :one
+INSERT INTO stages (
+    id, scoreboard_id, name, position, weight, created_at
+) VALUES (
+    gen_random_uuid(),
+    $1, $2, $3, $4,
+    CURRENT_TIMESTAMP
+)
+RETURNING *;
+
+-- name: DeleteStage :exec
+DELETE FROM stages WHERE id = $1 AND scoreboard_id = $2;
+
+-- name: ListEntries :many
+SELECT * FROM stage_entries
+WHERE scoreboard_id = $1;
+
+-- name: ListStageEntries :many
+SELECT * FROM stage_entries
+WHERE scoreboard_id = $1 AND stage_id = $2;
+
+-- name: UpsertEntry :one
+INSERT INTO stage_entries (
+    scoreboard_id, stage_id, user_id, score, created_at, updated_at
+) VALUES (
+    $1, $2, $3, $4,
+    CURRENT_TIMESTAMP,
+    CURRENT_TIMESTAMP
+)
+ON CONFLICT (stage_id, user_id) DO UPDATE SET
+    score = EXCLUDED.score,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: DeleteEntry :exec
+DELETE FROM stage_entries WHERE scoreboard_id = $1 AND stage_id = $2 AND user_id = $3;
@@ -0,0 +1,29 @@
+CREATE TABLE stage_settings (
+    scoreboard_id UUID PRIMARY KEY,
+    aggregation VARCHAR(16) NOT NULL DEFAULT 'weighted_sum',
+    count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE stages (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    scoreboard_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    position INT NOT NULL,
+    weight DOUBLE PRECISION NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (scoreboard_id, position),
+    UNIQUE (scoreboard_id, id)
+);
+
+CREATE TABLE stage_entries (
+    scoreboard_id UUID NOT NULL,
+    stage_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    score BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (stage_id, user_id),
+    FOREIGN KEY (scoreboard_id, stage_id) REFERENCES stages (scoreboard_id, id) ON DELETE CASCADE
+);
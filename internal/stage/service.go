@@ -0,0 +1,161 @@
+package stage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrUnknownEntity = errors.New("scoreboard, stage or player does not exist")
+	ErrDuplicate     = errors.New("another stage already has this position")
+)
+
+// Postgres error codes raised by the stage constraints.
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+)
+
+type Service struct {
+	queries *Queries
+	logger  *zap.Logger
+	tracer  trace.Tracer
+}
+
+func NewService(logger *zap.Logger, db DBTX) *Service {
+	return &Service{
+		queries: New(db),
+		logger:  logger,
+		tracer:  otel.Tracer("stage/service"),
+	}
+}
+
+// Settings returns how stage results are combined, falling back to
+// DefaultSettings when none has been stored.
+func (s *Service) Settings(ctx context.Context, scoreboardID uuid.UUID) (StageSetting, error) {
+	traceCtx, span := s.tracer.Start(ctx, "Settings")
+	defer span.End()
+	settings, err := s.queries.GetSettings(traceCtx, scoreboardID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return DefaultSettings(scoreboardID), nil
+		}
+		return StageSetting{}, err
+	}
+	return settings, nil
+}
+
+func (s *Service) UpdateSettings(ctx context.Context, arg UpsertSettingsParams) (StageSetting, error) {
+	traceCtx, span := s.tracer.Start(ctx, "UpdateSettings")
+	defer span.End()
+	settings, err := s.queries.UpsertSettings(traceCtx, arg)
+	if err != nil {
+		return StageSetting{}, translate(err)
+	}
+	return settings, nil
+}
+
+func (s *Service) ListStages(ctx context.Context, scoreboardID uuid.UUID) ([]Stage, error) {
+	traceCtx, span := s.tracer.Start(ctx, "ListStages")
+	defer span.End()
+	stages, err := s.queries.ListStages(traceCtx, scoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	return stages, nil
+}
+
+func (s *Service) CreateStage(ctx context.Context, arg CreateStageParams) (Stage, error) {
+	traceCtx, span := s.tracer.Start(ctx, "CreateStage")
+	defer span.End()
+	stage, err := s.queries.CreateStage(traceCtx, arg)
+	if err != nil {
+		return Stage{}, translate(err)
+	}
+	return stage, nil
+}
+
+func (s *Service) DeleteStage(ctx context.Context, scoreboardID, stageID uuid.UUID) error {
+	traceCtx, span := s.tracer.Start(ctx, "DeleteStage")
+	defer span.End()
+	return s.queries.DeleteStage(traceCtx, DeleteStageParams{
+		ID:           stageID,
+		ScoreboardID: scoreboardID,
+	})
+}
+
+// RecordEntry sets a player's score in a stage, replacing an earlier one.
+func (s *Service) RecordEntry(ctx context.Context, arg UpsertEntryParams) (StageEntry, error) {
+	traceCtx, span := s.tracer.Start(ctx, "RecordEntry")
+	defer span.End()
+	entry, err := s.queries.UpsertEntry(traceCtx, arg)
+	if err != nil {
+		return StageEntry{}, translate(err)
+	}
+	return entry, nil
+}
+
+func (s *Service) DeleteEntry(ctx context.Context, scoreboardID, stageID, userID uuid.UUID) error {
+	traceCtx, span := s.tracer.Start(ctx, "DeleteEntry")
+	defer span.End()
+	return s.queries.DeleteEntry(traceCtx, DeleteEntryParams{
+		ScoreboardID: scoreboardID,
+		StageID:      stageID,
+		UserID:       userID,
+	})
+}
+
+// StageStandings ranks the players of a single stage.
+func (s *Service) StageStandings(ctx context.Context, scoreboardID, stageID uuid.UUID) ([]Placing, error) {
+	traceCtx, span := s.tracer.Start(ctx, "StageStandings")
+	defer span.End()
+	entries, err := s.queries.ListStageEntries(traceCtx, ListStageEntriesParams{
+		ScoreboardID: scoreboardID,
+		StageID:      stageID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return Place(entries), nil
+}
+
+// Standings combines every stage into the overall standings of the scoreboard.
+func (s *Service) Standings(ctx context.Context, scoreboardID uuid.UUID) ([]Stage, []Standing, error) {
+	traceCtx, span := s.tracer.Start(ctx, "Standings")
+	defer span.End()
+
+	settings, err := s.Settings(traceCtx, scoreboardID)
+	if err != nil {
+		return nil, nil, err
+	}
+	stages, err := s.queries.ListStages(traceCtx, scoreboardID)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries, err := s.queries.ListEntries(traceCtx, scoreboardID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stages, Aggregate(stages, entries, settings), nil
+}
+
+// translate maps constraint violations onto the errors of this package.
+func translate(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case foreignKeyViolation:
+			return ErrUnknownEntity
+		case uniqueViolation:
+			return ErrDuplicate
+		}
+	}
+	return err
+}
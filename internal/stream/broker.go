@@ -0,0 +1,263 @@
+// Package stream pushes leaderboard changes to clients as they happen, so
+// overlays and lobby screens do not have to poll.
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"sync"
+
+	"scoreboard-api/internal/scoreboard"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// bufferSize is the number of updates a subscriber may fall behind before it
+// is dropped. Dropped clients reconnect and resume from the event log.
+const bufferSize = 64
+
+// maxMoved is how deep into the board players pushed up or down by another
+// player's change are told of their new rank. Players below only learn it
+// from their own changes or a new snapshot.
+const maxMoved = 100
+
+// Store is the part of the scoreboard service the stream uses.
+type Store interface {
+	Get(ctx context.Context, id uuid.UUID) (scoreboard.Scoreboard, error)
+	Top(ctx context.Context, scoreboardID uuid.UUID, limit int) ([]scoreboard.Standing, error)
+	Standing(ctx context.Context, scoreboardID, userID uuid.UUID) (scoreboard.Standing, error)
+	Events(ctx context.Context, scoreboardID uuid.UUID, after int64, limit int32) ([]scoreboard.ScoreboardEvent, error)
+	Submit(ctx context.Context, arg scoreboard.SubmitScoreParams) (scoreboard.ScoreboardEntry, error)
+}
+
+// Update is one change pushed to subscribers. ID is the position of the
+// change in the event log, or zero for a snapshot of the whole board.
+type Update struct {
+	ID     int64
+	Event  string
+	UserID uuid.UUID
+	Data   []byte
+}
+
+const (
+	// EventEntry carries the new standing of a player.
+	EventEntry = "entry"
+	// EventMoved carries the new standings of the players another player's
+	// change moved up or down the board, in leaderboard order.
+	EventMoved = "moved"
+	// EventRemoved carries the player whose entry was deleted.
+	EventRemoved = "removed"
+	// EventLeaderboard carries the top of the board.
+	EventLeaderboard = "leaderboard"
+	// EventRenamed carries the scoreboard with its new name.
+	EventRenamed = "renamed"
+	// EventDeleted is the last update of a deleted scoreboard.
+	EventDeleted = "deleted"
+)
+
+type removedData struct {
+	UserID string `json:"userId"`
+}
+
+type deletedData struct {
+	ScoreboardID string `json:"scoreboardId"`
+}
+
+// Subscription receives the updates of one scoreboard. Updates is closed
+// when the subscriber falls too far behind.
+type Subscription struct {
+	Updates      chan Update
+	scoreboardID uuid.UUID
+}
+
+// Broker fans entry and scoreboard changes out to the subscribers of each
+// scoreboard. It observes the scoreboard service.
+type Broker struct {
+	logger *zap.Logger
+	store  Store
+
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[*Subscription]struct{}
+}
+
+var (
+	_ scoreboard.Observer           = (*Broker)(nil)
+	_ scoreboard.ScoreboardObserver = (*Broker)(nil)
+)
+
+func NewBroker(logger *zap.Logger, store Store) *Broker {
+	return &Broker{
+		logger:      logger,
+		store:       store,
+		subscribers: make(map[uuid.UUID]map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe starts delivering the updates of a scoreboard. Callers must
+// Unsubscribe when they are done.
+func (b *Broker) Subscribe(scoreboardID uuid.UUID) *Subscription {
+	subscription := &Subscription{
+		Updates:      make(chan Update, bufferSize),
+		scoreboardID: scoreboardID,
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subscribers[scoreboardID] == nil {
+		b.subscribers[scoreboardID] = make(map[*Subscription]struct{})
+	}
+	b.subscribers[scoreboardID][subscription] = struct{}{}
+	return subscription
+}
+
+func (b *Broker) Unsubscribe(subscription *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.remove(subscription)
+}
+
+// remove must be called with the lock held.
+func (b *Broker) remove(subscription *Subscription) {
+	subscribers := b.subscribers[subscription.scoreboardID]
+	if _, ok := subscribers[subscription]; !ok {
+		return
+	}
+	delete(subscribers, subscription)
+	if len(subscribers) == 0 {
+		delete(b.subscribers, subscription.scoreboardID)
+	}
+	close(subscription.Updates)
+}
+
+func (b *Broker) watched(scoreboardID uuid.UUID) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers[scoreboardID]) > 0
+}
+
+// ObserveEntry publishes the standing a change left the player in, followed
+// by the players it moved. Boards nobody watches are skipped without reading
+// the ranking.
+func (b *Broker) ObserveEntry(ctx context.Context, change scoreboard.EntryChange) error {
+	if !b.watched(change.ScoreboardID) {
+		return nil
+	}
+	var update Update
+	var err error
+	if change.After == nil {
+		update, err = removed(change.EventID, change.UserID)
+	} else {
+		update, err = b.entry(ctx, change.EventID, change.ScoreboardID, change.UserID)
+	}
+	if err != nil {
+		return err
+	}
+	b.Publish(change.ScoreboardID, update)
+
+	moved, ok, err := b.moved(ctx, change)
+	if err != nil || !ok {
+		return err
+	}
+	b.Publish(change.ScoreboardID, moved)
+	return nil
+}
+
+// ObserveScoreboard publishes renamed and deleted scoreboards.
+func (b *Broker) ObserveScoreboard(_ context.Context, change scoreboard.ScoreboardChange) error {
+	if !b.watched(change.ScoreboardID) {
+		return nil
+	}
+	update := Update{ID: change.EventID, Event: EventDeleted}
+	var data any = deletedData{ScoreboardID: change.ScoreboardID.String()}
+	if change.After != nil {
+		update.Event = EventRenamed
+		data = scoreboard.GenerateResponse(*change.After)
+	}
+	var err error
+	update.Data, err = json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	b.Publish(change.ScoreboardID, update)
+	return nil
+}
+
+// Publish hands an update to every subscriber of a scoreboard. Subscribers
+// whose buffer is full are dropped instead of blocking the writer.
+func (b *Broker) Publish(scoreboardID uuid.UUID, update Update) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for subscription := range b.subscribers[scoreboardID] {
+		select {
+		case subscription.Updates <- update:
+		default:
+			b.logger.Warn("Dropping a slow stream subscriber", zap.String("scoreboard_id", scoreboardID.String()))
+			b.remove(subscription)
+		}
+	}
+}
+
+// entry reads the current standing of a player. A player removed since the
+// event is reported as removed.
+func (b *Broker) entry(ctx context.Context, eventID int64, scoreboardID, userID uuid.UUID) (Update, error) {
+	standing, err := b.store.Standing(ctx, scoreboardID, userID)
+	if errors.Is(err, scoreboard.ErrEntryNotFound) {
+		return removed(eventID, userID)
+	}
+	if err != nil {
+		return Update{}, err
+	}
+	data, err := json.Marshal(scoreboard.GenerateLeaderboardResponse(standing))
+	if err != nil {
+		return Update{}, err
+	}
+	return Update{ID: eventID, Event: EventEntry, UserID: userID, Data: data}, nil
+}
+
+// moved reads the standings of the players a change moved. A player's rank
+// counts the players scoring more, so it changed exactly when their score
+// lies between the changed player's old and new score. A missing entry, or
+// the unknown old entry of a remote change, scores below everyone. Decaying
+// boards are ranked by effective scores, so there the window is approximate.
+// It reports false when nobody in the top maxMoved places moved.
+func (b *Broker) moved(ctx context.Context, change scoreboard.EntryChange) (Update, bool, error) {
+	before, after := math.Inf(-1), math.Inf(-1)
+	if change.Before != nil {
+		before = float64(change.Before.Score)
+	}
+	if change.After != nil {
+		after = float64(change.After.Score)
+	}
+	low, high := min(before, after), max(before, after)
+	if low == high {
+		return Update{}, false, nil
+	}
+	standings, err := b.store.Top(ctx, change.ScoreboardID, maxMoved)
+	if err != nil {
+		return Update{}, false, err
+	}
+	var response []scoreboard.EntryResponse
+	for _, standing := range standings {
+		if standing.UserID != change.UserID && standing.Effective >= low && standing.Effective < high {
+			response = append(response, scoreboard.GenerateLeaderboardResponse(standing))
+		}
+	}
+	if len(response) == 0 {
+		return Update{}, false, nil
+	}
+	data, err := json.Marshal(response)
+	if err != nil {
+		return Update{}, false, err
+	}
+	return Update{ID: change.EventID, Event: EventMoved, Data: data}, true, nil
+}
+
+func removed(eventID int64, userID uuid.UUID) (Update, error) {
+	data, err := json.Marshal(removedData{UserID: userID.String()})
+	if err != nil {
+		return Update{}, err
+	}
+	return Update{ID: eventID, Event: EventRemoved, UserID: userID, Data: data}, nil
+}
@@ -0,0 +1,201 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"scoreboard-api/internal/scoreboard"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultLimit is the size of the snapshot sent to new clients.
+	defaultLimit = 10
+	// keepaliveInterval keeps idle connections open through proxies.
+	keepaliveInterval = 15 * time.Second
+)
+
+type Handler struct {
+	validator *validator.Validate
+	logger    *zap.Logger
+	store     Store
+	broker    *Broker
+}
+
+func NewHandler(v *validator.Validate, logger *zap.Logger, store Store, broker *Broker) Handler {
+	return Handler{
+		validator: v,
+		logger:    logger,
+		store:     store,
+		broker:    broker,
+	}
+}
+
+// StreamHandler streams the changes of a scoreboard as Server-Sent Events.
+// A new client first gets a leaderboard snapshot of the top ?limit= players.
+// A client reconnecting with Last-Event-ID instead gets the current standing
+// of every player changed since that event. Each change is pushed as the
+// player's new standing, followed by a moved event with the players it
+// pushed up or down. The stream ends when the scoreboard is deleted.
+func (h Handler) StreamHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	limit := defaultLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			http.Error(w, "limit must be a positive number", http.StatusBadRequest)
+			return
+		}
+	}
+	lastEventID := int64(-1)
+	if value := r.Header.Get("Last-Event-ID"); value != "" {
+		lastEventID, err = strconv.ParseInt(value, 10, 64)
+		if err != nil || lastEventID < 0 {
+			http.Error(w, "Last-Event-ID must be an event ID", http.StatusBadRequest)
+			return
+		}
+	}
+	if _, err := h.store.Get(ctx, scoreboardID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			http.Error(w, "Scoreboard not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Subscribe before reading the board, so no change falls in between.
+	subscription := h.broker.Subscribe(scoreboardID)
+	defer h.broker.Unsubscribe(subscription)
+
+	var initial []Update
+	if lastEventID < 0 {
+		initial, err = h.snapshot(ctx, scoreboardID, limit)
+	} else {
+		initial, err = h.missed(ctx, scoreboardID, lastEventID, limit)
+	}
+	if err != nil {
+		h.logger.Error("Failed to start stream", zap.Error(err))
+		http.Error(w, "Failed to start stream", http.StatusInternalServerError)
+		return
+	}
+
+	controller := http.NewResponseController(w)
+	// Streams outlive any write timeout of the server.
+	_ = controller.SetWriteDeadline(time.Time{})
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// sent holds the last event sent for each player. Changes to a player
+	// can be published out of order, and the replay may already cover them.
+	sent := make(map[uuid.UUID]int64)
+	for _, update := range initial {
+		if err := write(w, update); err != nil {
+			return
+		}
+		sent[update.UserID] = update.ID
+	}
+	if err := controller.Flush(); err != nil {
+		return
+	}
+
+	keepalive := time.NewTicker(keepaliveInterval)
+	defer keepalive.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case update, ok := <-subscription.Updates:
+			if !ok {
+				// Dropped for falling behind; the client resumes.
+				return
+			}
+			if update.ID <= sent[update.UserID] {
+				continue
+			}
+			sent[update.UserID] = update.ID
+			if err := write(w, update); err != nil {
+				return
+			}
+			if update.Event == EventDeleted {
+				_ = controller.Flush()
+				return
+			}
+		case <-keepalive.C:
+			if _, err := io.WriteString(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		}
+		if err := controller.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// snapshot returns the top of the board. It has no event ID, so a client
+// that reconnects before any change gets a new snapshot.
+func (h Handler) snapshot(ctx context.Context, scoreboardID uuid.UUID, limit int) ([]Update, error) {
+	standings, err := h.store.Top(ctx, scoreboardID, limit)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]scoreboard.EntryResponse, len(standings))
+	for index, standing := range standings {
+		response[index] = scoreboard.GenerateLeaderboardResponse(standing)
+	}
+	data, err := json.Marshal(response)
+	if err != nil {
+		return nil, err
+	}
+	return []Update{{Event: EventLeaderboard, Data: data}}, nil
+}
+
+// missed returns the current standing of every player changed after the
+// event after, in the order of their last change.
+func (h Handler) missed(ctx context.Context, scoreboardID uuid.UUID, after int64, limit int) ([]Update, error) {
+	events, ok, err := Missed(ctx, h.store, scoreboardID, after)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return h.snapshot(ctx, scoreboardID, limit)
+	}
+
+	var updates []Update
+	for _, event := range events {
+		update, err := h.broker.entry(ctx, event.ID, scoreboardID, event.UserID.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		updates = append(updates, update)
+	}
+	return updates, nil
+}
+
+func write(w io.Writer, update Update) error {
+	if update.ID > 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", update.ID); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", update.Event, update.Data)
+	return err
+}
@@ -0,0 +1,200 @@
+package stream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"scoreboard-api/internal/memory"
+	"scoreboard-api/internal/scoreboard"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+type message struct {
+	id    string
+	event string
+	data  string
+}
+
+// connect opens a stream and returns a function reading its next message.
+func connect(t *testing.T, ctx context.Context, url, lastEventID string) func() message {
+	t.Helper()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	if lastEventID != "" {
+		request.Header.Set("Last-Event-ID", lastEventID)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("GET stream error = %v", err)
+	}
+	t.Cleanup(func() { _ = response.Body.Close() })
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("GET stream status = %d, want 200", response.StatusCode)
+	}
+
+	reader := bufio.NewReader(response.Body)
+	return func() message {
+		t.Helper()
+		var m message
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				t.Fatalf("reading stream error = %v", err)
+			}
+			line = strings.TrimSuffix(line, "\n")
+			switch {
+			case line == "" && m.event != "":
+				return m
+			case strings.HasPrefix(line, "id: "):
+				m.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				m.event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				m.data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}
+}
+
+func TestStreamHandler(t *testing.T) {
+	store := memory.New().Scoreboards()
+	service := scoreboard.NewServiceWithQuerier(zap.NewNop(), store, store)
+	broker := NewBroker(zap.NewNop(), service)
+	service.Observe(broker)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/scoreboards/{id}/stream", NewHandler(validator.New(), zap.NewNop(), service, broker).StreamHandler)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	// Cancelled before the server closes, which waits for open streams.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	board, err := service.Create(ctx, pgtype.Text{String: "Arcade", Valid: true})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	alice, bob, carol := uuid.New(), uuid.New(), uuid.New()
+	if _, err := service.CreateEntry(ctx, scoreboard.CreateEntryParams{ScoreboardID: board.ID, UserID: alice, Score: 10}); err != nil {
+		t.Fatalf("CreateEntry() error = %v", err)
+	}
+	url := server.URL + "/api/scoreboards/" + board.ID.String() + "/stream"
+
+	streamCtx, closeStream := context.WithCancel(ctx)
+	next := connect(t, streamCtx, url, "")
+	snapshot := next()
+	var top []scoreboard.EntryResponse
+	if err := json.Unmarshal([]byte(snapshot.data), &top); err != nil {
+		t.Fatalf("snapshot data %q: %v", snapshot.data, err)
+	}
+	if snapshot.event != EventLeaderboard || snapshot.id != "" || len(top) != 1 || top[0].UserID != alice.String() {
+		t.Fatalf("first message = %+v, want a snapshot holding alice", snapshot)
+	}
+
+	if _, err := service.Submit(ctx, scoreboard.SubmitScoreParams{ScoreboardID: board.ID, UserID: bob, Score: 20}); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	update := next()
+	var entry scoreboard.EntryResponse
+	if err := json.Unmarshal([]byte(update.data), &entry); err != nil {
+		t.Fatalf("update data %q: %v", update.data, err)
+	}
+	if update.event != EventEntry || entry.UserID != bob.String() || entry.Rank == nil || *entry.Rank != 1 {
+		t.Fatalf("update = %+v, want bob ranked first", update)
+	}
+	if _, err := strconv.ParseInt(update.id, 10, 64); err != nil {
+		t.Fatalf("update id = %q, want an event ID", update.id)
+	}
+	// Bob pushed alice down, so she is told of her new rank too.
+	moved := next()
+	var displaced []scoreboard.EntryResponse
+	if err := json.Unmarshal([]byte(moved.data), &displaced); err != nil {
+		t.Fatalf("moved data %q: %v", moved.data, err)
+	}
+	if moved.event != EventMoved || moved.id != update.id || len(displaced) != 1 || displaced[0].UserID != alice.String() || *displaced[0].Rank != 2 {
+		t.Fatalf("update after bob's = %+v, want alice moved to second", moved)
+	}
+	closeStream()
+
+	// Changes made while disconnected are replayed once per player.
+	for _, score := range []int64{25, 30} {
+		if _, err := service.UpdateEntry(ctx, scoreboard.UpdateEntryParams{ScoreboardID: board.ID, UserID: alice, Score: score}); err != nil {
+			t.Fatalf("UpdateEntry() error = %v", err)
+		}
+	}
+	if _, err := service.CreateEntry(ctx, scoreboard.CreateEntryParams{ScoreboardID: board.ID, UserID: carol, Score: 1}); err != nil {
+		t.Fatalf("CreateEntry() error = %v", err)
+	}
+	if err := service.DeleteEntry(ctx, board.ID, carol); err != nil {
+		t.Fatalf("DeleteEntry() error = %v", err)
+	}
+
+	next = connect(t, ctx, url, update.id)
+	resumed := next()
+	if err := json.Unmarshal([]byte(resumed.data), &entry); err != nil {
+		t.Fatalf("resumed data %q: %v", resumed.data, err)
+	}
+	if resumed.event != EventEntry || entry.UserID != alice.String() || entry.Score != 30 || *entry.Rank != 1 {
+		t.Errorf("first resumed message = %+v, want alice first with 30", resumed)
+	}
+	if removed := next(); removed.event != EventRemoved || !strings.Contains(removed.data, carol.String()) {
+		t.Errorf("second resumed message = %+v, want carol removed", removed)
+	}
+}
+
+// eventLog serves made-up events and records the limits it is asked for.
+type eventLog struct {
+	events []scoreboard.ScoreboardEvent
+	limits []int32
+}
+
+func (l *eventLog) Events(_ context.Context, _ uuid.UUID, after int64, limit int32) ([]scoreboard.ScoreboardEvent, error) {
+	l.limits = append(l.limits, limit)
+	var events []scoreboard.ScoreboardEvent
+	for _, event := range l.events {
+		if event.ID > after && len(events) < int(limit) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func TestMissed(t *testing.T) {
+	ctx := context.Background()
+	player, rival := uuid.New(), uuid.New()
+	log := &eventLog{}
+	for id := int64(1); id <= 2*MaxReplay; id++ {
+		userID := player
+		if id%2 == 0 {
+			userID = rival
+		}
+		log.events = append(log.events, scoreboard.ScoreboardEvent{ID: id, UserID: pgtype.UUID{Bytes: userID, Valid: true}})
+	}
+
+	if _, ok, err := Missed(ctx, log, uuid.New(), 0); ok || err != nil {
+		t.Errorf("Missed() from the start = %v, %v, want a new snapshot", ok, err)
+	}
+	if log.limits[0] != MaxReplay+1 {
+		t.Errorf("Missed() read up to %d events, want %d", log.limits[0], MaxReplay+1)
+	}
+
+	events, ok, err := Missed(ctx, log, uuid.New(), 2*MaxReplay-3)
+	if !ok || err != nil {
+		t.Fatalf("Missed() near the end = %v, %v, want a replay", ok, err)
+	}
+	if len(events) != 2 || events[0].ID != 2*MaxReplay-1 || events[1].ID != 2*MaxReplay {
+		t.Errorf("Missed() = %v, want the last event of each player", events)
+	}
+}
@@ -0,0 +1,46 @@
+package stream
+
+import (
+	"context"
+
+	"scoreboard-api/internal/scoreboard"
+
+	"github.com/google/uuid"
+)
+
+// MaxReplay is the most events a resuming client is caught up on player by
+// player. Clients further behind get a new snapshot.
+const MaxReplay = 1000
+
+// EventLog is the part of the scoreboard service replays read.
+type EventLog interface {
+	Events(ctx context.Context, scoreboardID uuid.UUID, after int64, limit int32) ([]scoreboard.ScoreboardEvent, error)
+}
+
+// Missed returns the last event of every player changed after the event
+// after, in the order of those events. It reads at most MaxReplay+1 events,
+// and reports false when the client missed more than MaxReplay and should get
+// a new snapshot instead.
+func Missed(ctx context.Context, log EventLog, scoreboardID uuid.UUID, after int64) ([]scoreboard.ScoreboardEvent, bool, error) {
+	events, err := log.Events(ctx, scoreboardID, after, MaxReplay+1)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(events) > MaxReplay {
+		return nil, false, nil
+	}
+
+	last := make(map[uuid.UUID]int64)
+	for _, event := range events {
+		if event.UserID.Valid {
+			last[event.UserID.Bytes] = event.ID
+		}
+	}
+	var missed []scoreboard.ScoreboardEvent
+	for _, event := range events {
+		if event.UserID.Valid && last[event.UserID.Bytes] == event.ID {
+			missed = append(missed, event)
+		}
+	}
+	return missed, true, nil
+}
@@ -0,0 +1,252 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+
+	"scoreboard-api/internal/scoreboard"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+// maxSubscriptions is the most scoreboards one socket can watch.
+const maxSubscriptions = 32
+
+const (
+	messageSubscribe    = "subscribe"
+	messageUnsubscribe  = "unsubscribe"
+	messageSubmit       = "submit"
+	messageSubscribed   = "subscribed"
+	messageUnsubscribed = "unsubscribed"
+	messageSubmitted    = "submitted"
+	messageError        = "error"
+)
+
+// clientMessage is a request sent over a socket. Ref is echoed in the reply,
+// so clients can match replies to their requests.
+type clientMessage struct {
+	Type         string `json:"type" validate:"required,oneof=subscribe unsubscribe submit"`
+	Ref          string `json:"ref" validate:"max=64"`
+	ScoreboardID string `json:"scoreboardId" validate:"required,uuid"`
+	UserID       string `json:"userId" validate:"required_if=Type submit,omitempty,uuid"`
+	Score        *int64 `json:"score" validate:"required_if=Type submit"`
+}
+
+// serverMessage is a reply or an update sent over a socket. Updates use the
+// event names and data of the SSE stream.
+type serverMessage struct {
+	Type         string          `json:"type"`
+	Ref          string          `json:"ref,omitempty"`
+	ScoreboardID string          `json:"scoreboardId,omitempty"`
+	EventID      int64           `json:"eventId,omitempty"`
+	Data         json.RawMessage `json:"data,omitempty"`
+	Error        string          `json:"error,omitempty"`
+}
+
+// SocketHandler serves a WebSocket over which a client subscribes to any
+// number of scoreboards and submits scores. Subscribing replies with a
+// leaderboard snapshot, after which the board's updates follow: a changed
+// entry, the entries it moved, a rename or the deletion of the board. A client
+// that falls too far behind is disconnected and should subscribe again.
+//
+// Game clients send no Origin header, so every origin is accepted.
+func (h Handler) SocketHandler(w http.ResponseWriter, r *http.Request) {
+	websocket.Server{Handler: h.serveSocket}.ServeHTTP(w, r)
+}
+
+// socket is the state of one WebSocket connection. Replies and updates are
+// queued on out and written by a single goroutine.
+type socket struct {
+	Handler
+	ctx    context.Context
+	cancel context.CancelFunc
+	out    chan serverMessage
+
+	mu            sync.Mutex
+	subscriptions map[uuid.UUID]*Subscription
+}
+
+func (h Handler) serveSocket(conn *websocket.Conn) {
+	ctx, cancel := context.WithCancel(conn.Request().Context())
+	defer cancel()
+	s := &socket{
+		Handler:       h,
+		ctx:           ctx,
+		cancel:        cancel,
+		out:           make(chan serverMessage, bufferSize),
+		subscriptions: make(map[uuid.UUID]*Subscription),
+	}
+	defer s.unsubscribeAll()
+
+	go func() {
+		defer cancel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message := <-s.out:
+				if err := websocket.JSON.Send(conn, message); err != nil {
+					return
+				}
+			}
+		}
+	}()
+	// Closing the connection ends the read loop when the writer gives up.
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	for {
+		var message clientMessage
+		if err := websocket.JSON.Receive(conn, &message); err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+				s.send(serverMessage{Type: messageError, Error: "Invalid message"})
+				continue
+			}
+			return
+		}
+		s.handle(message)
+	}
+}
+
+func (s *socket) handle(message clientMessage) {
+	if err := s.validator.Struct(message); err != nil {
+		s.send(serverMessage{Type: messageError, Ref: message.Ref, Error: err.Error()})
+		return
+	}
+	scoreboardID := uuid.MustParse(message.ScoreboardID)
+
+	switch message.Type {
+	case messageSubscribe:
+		s.subscribe(message.Ref, scoreboardID)
+	case messageUnsubscribe:
+		s.mu.Lock()
+		subscription, ok := s.subscriptions[scoreboardID]
+		delete(s.subscriptions, scoreboardID)
+		s.mu.Unlock()
+		if ok {
+			s.broker.Unsubscribe(subscription)
+		}
+		s.send(serverMessage{Type: messageUnsubscribed, Ref: message.Ref, ScoreboardID: message.ScoreboardID})
+	case messageSubmit:
+		s.submit(message.Ref, scoreboard.SubmitScoreParams{
+			ScoreboardID: scoreboardID,
+			UserID:       uuid.MustParse(message.UserID),
+			Score:        *message.Score,
+		})
+	}
+}
+
+// subscribe replies with a snapshot of the board and then forwards its
+// updates. Subscribing again only sends a new snapshot.
+func (s *socket) subscribe(ref string, scoreboardID uuid.UUID) {
+	s.mu.Lock()
+	_, subscribed := s.subscriptions[scoreboardID]
+	full := len(s.subscriptions) >= maxSubscriptions
+	s.mu.Unlock()
+	if !subscribed && full {
+		s.send(serverMessage{Type: messageError, Ref: ref, Error: "Too many subscriptions"})
+		return
+	}
+	if _, err := s.store.Get(s.ctx, scoreboardID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.send(serverMessage{Type: messageError, Ref: ref, Error: "Scoreboard not found"})
+			return
+		}
+		s.fail(ref, "Failed to subscribe", err)
+		return
+	}
+
+	var subscription *Subscription
+	if !subscribed {
+		// Subscribe before reading the board, so no change falls in between.
+		subscription = s.broker.Subscribe(scoreboardID)
+		s.mu.Lock()
+		s.subscriptions[scoreboardID] = subscription
+		s.mu.Unlock()
+	}
+	snapshot, err := s.snapshot(s.ctx, scoreboardID, defaultLimit)
+	if err != nil {
+		if subscription != nil {
+			s.mu.Lock()
+			delete(s.subscriptions, scoreboardID)
+			s.mu.Unlock()
+			s.broker.Unsubscribe(subscription)
+		}
+		s.fail(ref, "Failed to subscribe", err)
+		return
+	}
+	s.send(serverMessage{Type: messageSubscribed, Ref: ref, ScoreboardID: scoreboardID.String(), Data: snapshot[0].Data})
+	if subscription != nil {
+		go s.forward(scoreboardID, subscription)
+	}
+}
+
+// forward queues the updates of a subscription until it ends. A subscription
+// the broker dropped closes the socket.
+func (s *socket) forward(scoreboardID uuid.UUID, subscription *Subscription) {
+	for update := range subscription.Updates {
+		s.send(serverMessage{
+			Type:         update.Event,
+			ScoreboardID: scoreboardID.String(),
+			EventID:      update.ID,
+			Data:         update.Data,
+		})
+	}
+	s.mu.Lock()
+	dropped := s.subscriptions[scoreboardID] == subscription
+	s.mu.Unlock()
+	if dropped {
+		s.cancel()
+	}
+}
+
+func (s *socket) submit(ref string, arg scoreboard.SubmitScoreParams) {
+	entry, err := s.store.Submit(s.ctx, arg)
+	if err != nil {
+		if errors.Is(err, scoreboard.ErrUnknownEntity) {
+			s.send(serverMessage{Type: messageError, Ref: ref, Error: err.Error()})
+			return
+		}
+		s.fail(ref, "Failed to submit score", err)
+		return
+	}
+	data, err := json.Marshal(scoreboard.GenerateEntryResponse(entry))
+	if err != nil {
+		s.fail(ref, "Failed to submit score", err)
+		return
+	}
+	s.send(serverMessage{Type: messageSubmitted, Ref: ref, ScoreboardID: arg.ScoreboardID.String(), Data: data})
+}
+
+// fail logs an unexpected error and reports it to the client without details.
+func (s *socket) fail(ref, message string, err error) {
+	s.logger.Error(message, zap.Error(err))
+	s.send(serverMessage{Type: messageError, Ref: ref, Error: message})
+}
+
+func (s *socket) send(message serverMessage) {
+	select {
+	case s.out <- message:
+	case <-s.ctx.Done():
+	}
+}
+
+func (s *socket) unsubscribeAll() {
+	s.mu.Lock()
+	subscriptions := s.subscriptions
+	s.subscriptions = make(map[uuid.UUID]*Subscription)
+	s.mu.Unlock()
+	for _, subscription := range subscriptions {
+		s.broker.Unsubscribe(subscription)
+	}
+}
@@ -0,0 +1,127 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"scoreboard-api/internal/memory"
+	"scoreboard-api/internal/scoreboard"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+func TestSocketHandler(t *testing.T) {
+	ctx := context.Background()
+	store := memory.New().Scoreboards()
+	service := scoreboard.NewServiceWithQuerier(zap.NewNop(), store, store)
+	broker := NewBroker(zap.NewNop(), service)
+	service.Observe(broker)
+	server := httptest.NewServer(http.HandlerFunc(NewHandler(validator.New(), zap.NewNop(), service, broker).SocketHandler))
+	defer server.Close()
+
+	arcade, err := service.Create(ctx, pgtype.Text{String: "Arcade", Valid: true})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	darts, err := service.Create(ctx, pgtype.Text{String: "Darts", Valid: true})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http"), "", server.URL)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(10 * time.Second))
+	send := func(message clientMessage) {
+		t.Helper()
+		if err := websocket.JSON.Send(conn, message); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+	receive := func() serverMessage {
+		t.Helper()
+		var message serverMessage
+		if err := websocket.JSON.Receive(conn, &message); err != nil {
+			t.Fatalf("Receive() error = %v", err)
+		}
+		return message
+	}
+
+	for _, board := range []scoreboard.Scoreboard{arcade, darts} {
+		send(clientMessage{Type: messageSubscribe, Ref: board.Name.String, ScoreboardID: board.ID.String()})
+		if reply := receive(); reply.Type != messageSubscribed || reply.Ref != board.Name.String || string(reply.Data) != "[]" {
+			t.Fatalf("subscribe reply = %+v, want an empty snapshot", reply)
+		}
+	}
+	send(clientMessage{Type: messageSubscribe, ScoreboardID: uuid.NewString()})
+	if reply := receive(); reply.Type != messageError || reply.Error != "Scoreboard not found" {
+		t.Errorf("subscribe to an absent board reply = %+v, want an error", reply)
+	}
+	send(clientMessage{Type: messageSubmit, ScoreboardID: arcade.ID.String()})
+	if reply := receive(); reply.Type != messageError {
+		t.Errorf("submit without a player reply = %+v, want an error", reply)
+	}
+
+	// A submission is answered and also shows up as an update of the board.
+	player, score := uuid.New(), int64(42)
+	send(clientMessage{Type: messageSubmit, Ref: "first", ScoreboardID: darts.ID.String(), UserID: player.String(), Score: &score})
+	seen := make(map[string]serverMessage)
+	for range 2 {
+		message := receive()
+		seen[message.Type] = message
+	}
+	if reply := seen[messageSubmitted]; reply.Ref != "first" || reply.ScoreboardID != darts.ID.String() {
+		t.Errorf("submit reply = %+v, want the submitted entry", reply)
+	}
+	var entry scoreboard.EntryResponse
+	update := seen[EventEntry]
+	if err := json.Unmarshal(update.Data, &entry); err != nil || update.EventID == 0 || entry.Score != 42 || *entry.Rank != 1 {
+		t.Errorf("entry update = %+v, want the player ranked first with 42", update)
+	}
+
+	// Overtaking a player moves them down, and the socket says so.
+	rival := uuid.New()
+	if _, err := service.Submit(ctx, scoreboard.SubmitScoreParams{ScoreboardID: darts.ID, UserID: rival, Score: 60}); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if update := receive(); update.Type != EventEntry || !strings.Contains(string(update.Data), rival.String()) {
+		t.Errorf("overtaking update = %+v, want the rival's entry", update)
+	}
+	var moved []scoreboard.EntryResponse
+	update = receive()
+	if err := json.Unmarshal(update.Data, &moved); err != nil || update.Type != EventMoved || len(moved) != 1 || moved[0].UserID != player.String() || *moved[0].Rank != 2 {
+		t.Errorf("update after overtaking = %+v, want the player moved to second", update)
+	}
+
+	if _, err := service.Update(ctx, scoreboard.UpdateParams{ID: arcade.ID, Name: pgtype.Text{String: "Pinball", Valid: true}}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if update := receive(); update.Type != EventRenamed || update.ScoreboardID != arcade.ID.String() || !strings.Contains(string(update.Data), "Pinball") {
+		t.Errorf("rename update = %+v, want the new name", update)
+	}
+
+	send(clientMessage{Type: messageUnsubscribe, ScoreboardID: darts.ID.String()})
+	if reply := receive(); reply.Type != messageUnsubscribed {
+		t.Fatalf("unsubscribe reply = %+v", reply)
+	}
+	if _, err := service.Submit(ctx, scoreboard.SubmitScoreParams{ScoreboardID: darts.ID, UserID: player, Score: 50}); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if err := service.Delete(ctx, arcade.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if update := receive(); update.Type != EventDeleted || update.ScoreboardID != arcade.ID.String() {
+		t.Errorf("update after unsubscribing = %+v, want only the deleted arcade", update)
+	}
+}
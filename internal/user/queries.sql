@@ -4,6 +4,9 @@ SELECT * FROM users WHERE id = $1;
 -- name: GetByEmail :one
 SELECT * FROM users WHERE email = $1;
 
+-- name: ListByIDs :many
+SELECT * FROM users WHERE id = ANY(sqlc.arg(ids)::uuid[]);
+
 -- name: ExistsByEmail :one
 SELECT EXISTS(
     SELECT 1 FROM users WHERE email = $1
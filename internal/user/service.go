@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -10,15 +11,32 @@ import (
 	"go.uber.org/zap"
 )
 
+var ErrDuplicateEmail = errors.New("another user already has this email")
+
 type Service struct {
-	queries *Queries
+	queries Querier
 	logger  *zap.Logger
 	tracer  trace.Tracer
 }
 
+type Querier interface {
+	GetByID(ctx context.Context, id uuid.UUID) (User, error)
+	GetByEmail(ctx context.Context, email string) (User, error)
+	ListByIDs(ctx context.Context, ids []uuid.UUID) ([]User, error)
+	ExistsByEmail(ctx context.Context, email string) (bool, error)
+	Create(ctx context.Context, arg CreateParams) (User, error)
+	Update(ctx context.Context, arg UpdateParams) (User, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
 func NewService(logger *zap.Logger, db DBTX) *Service {
+	return NewServiceWithQuerier(logger, New(db))
+}
+
+// NewServiceWithQuerier builds a service on any storage backend.
+func NewServiceWithQuerier(logger *zap.Logger, queries Querier) *Service {
 	return &Service{
-		queries: New(db),
+		queries: queries,
 		logger:  logger,
 		tracer:  otel.Tracer("user/service"),
 	}
@@ -34,6 +52,14 @@ func (s *Service) GetByID(ctx context.Context, id uuid.UUID) (User, error) {
 	return user, nil
 }
 
+// ListByIDs returns the users with the given IDs in no particular order.
+// IDs without a user are left out.
+func (s *Service) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]User, error) {
+	traceCtx, span := s.tracer.Start(ctx, "ListByIDs")
+	defer span.End()
+	return s.queries.ListByIDs(traceCtx, ids)
+}
+
 func (s *Service) GetByEmail(ctx context.Context, email string) (User, error) {
 	traceCtx, span := s.tracer.Start(ctx, "GetByEmail")
 	defer span.End()
@@ -0,0 +1,216 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+const (
+	// pollInterval is how often due retries and frozen boards are looked for.
+	pollInterval = 5 * time.Second
+	// claimBatch is the number of deliveries attempted at once.
+	claimBatch = 20
+	// deliveryTimeout bounds one attempt, and lease keeps other instances
+	// from claiming a delivery while it is attempted.
+	deliveryTimeout = 10 * time.Second
+	lease           = time.Minute
+	// A failed delivery is retried after retryDelay, doubling each time up to
+	// maxRetryDelay, and given up after maxAttempts.
+	retryDelay    = 30 * time.Second
+	maxRetryDelay = time.Hour
+	maxAttempts   = 8
+	// maxErrorLength caps the response body kept in the delivery log.
+	maxErrorLength = 512
+)
+
+// Delivery statuses.
+const (
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	StatusFailed    = "failed"
+)
+
+// Headers sent with every delivery. The signature is
+// "sha256=" followed by the hex HMAC-SHA256 of the timestamp, a dot and the
+// body, keyed with the webhook secret.
+const (
+	HeaderEvent     = "X-Scoreboard-Event"
+	HeaderDelivery  = "X-Scoreboard-Delivery"
+	HeaderTimestamp = "X-Scoreboard-Timestamp"
+	HeaderSignature = "X-Scoreboard-Signature"
+)
+
+// Sign returns the signature header value of a delivery body.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff returns how long to wait before the next attempt after the given
+// number of failed ones.
+func backoff(attempts int32) time.Duration {
+	delay := retryDelay
+	for i := int32(1); i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
+}
+
+// Run delivers queued webhooks until ctx is done. Deliveries are claimed
+// with a lease, so any number of instances can run it.
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		if err := s.queueFrozen(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Error("Failed to queue board_frozen webhooks", zap.Error(err))
+		}
+		if err := s.deliverDue(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Error("Failed to deliver webhooks", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// queueFrozen queues board_frozen for the contests that reached their
+// freeze time since the hook was registered. Freezes happen by the clock,
+// so they are found by polling and queued once per freeze time.
+func (s *Service) queueFrozen(ctx context.Context) error {
+	now := time.Now().UTC()
+	boards, err := s.queries.ListFrozenBoards(ctx, timestamp(now))
+	if err != nil {
+		return err
+	}
+	for _, board := range boards {
+		body, err := json.Marshal(Payload{
+			Event:        EventBoardFrozen,
+			ScoreboardID: board.ScoreboardID.String(),
+			FreezeAt:     board.FreezeAt.Time.Format(time.RFC3339),
+			OccurredAt:   board.FreezeAt.Time.Format(time.RFC3339),
+		})
+		if err != nil {
+			return err
+		}
+		_, err = s.queries.EnqueueDelivery(ctx, EnqueueDeliveryParams{
+			WebhookID:     board.WebhookID,
+			Event:         string(EventBoardFrozen),
+			DedupeKey:     pgtype.Text{String: board.DedupeKey, Valid: true},
+			Payload:       body,
+			NextAttemptAt: timestamp(now),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliverDue attempts the due deliveries a batch at a time until none are
+// left.
+func (s *Service) deliverDue(ctx context.Context) error {
+	for {
+		now := time.Now().UTC()
+		deliveries, err := s.queries.ClaimDeliveries(ctx, ClaimDeliveriesParams{
+			LeaseUntil: timestamp(now.Add(lease)),
+			Now:        timestamp(now),
+			Batch:      claimBatch,
+		})
+		if err != nil {
+			return err
+		}
+		var wg sync.WaitGroup
+		for _, delivery := range deliveries {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.attempt(ctx, delivery)
+			}()
+		}
+		wg.Wait()
+		if len(deliveries) < claimBatch {
+			return nil
+		}
+	}
+}
+
+// attempt posts one delivery and records the outcome in the delivery log.
+func (s *Service) attempt(ctx context.Context, delivery ClaimDeliveriesRow) {
+	traceCtx, span := s.tracer.Start(ctx, "Deliver")
+	defer span.End()
+
+	status, err := send(traceCtx, s.client, delivery)
+	now := time.Now().UTC()
+	arg := RecordAttemptParams{
+		ID:             delivery.ID,
+		Status:         StatusDelivered,
+		NextAttemptAt:  timestamp(now),
+		ResponseStatus: pgtype.Int4{Int32: int32(status), Valid: status != 0},
+		DeliveredAt:    timestamp(now),
+	}
+	if err != nil {
+		arg.Status = StatusPending
+		arg.NextAttemptAt = timestamp(now.Add(backoff(delivery.Attempts + 1)))
+		arg.LastError = pgtype.Text{String: err.Error(), Valid: true}
+		arg.DeliveredAt = pgtype.Timestamp{}
+		if delivery.Attempts+1 >= maxAttempts {
+			arg.Status = StatusFailed
+		}
+		s.logger.Warn("Webhook delivery failed",
+			zap.String("delivery_id", delivery.ID.String()),
+			zap.String("url", delivery.Url),
+			zap.Int32("attempt", delivery.Attempts+1),
+			zap.Error(err))
+	}
+	// The outcome is recorded even when ctx ends during shutdown.
+	if _, err := s.queries.RecordAttempt(context.WithoutCancel(traceCtx), arg); err != nil {
+		s.logger.Error("Failed to record a webhook delivery", zap.Error(err))
+	}
+}
+
+// send posts a signed delivery and returns the response status. Any status
+// outside 2xx is an error.
+func send(ctx context.Context, client *http.Client, delivery ClaimDeliveriesRow) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "scoreboard-api-webhook")
+	request.Header.Set(HeaderEvent, delivery.Event)
+	request.Header.Set(HeaderDelivery, delivery.ID.String())
+	request.Header.Set(HeaderTimestamp, timestamp)
+	request.Header.Set(HeaderSignature, Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	response, err := client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorLength))
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("%s: %s", response.Status, bytes.TrimSpace(body))
+	}
+	return response.StatusCode, nil
+}
@@ -0,0 +1,72 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestSign(t *testing.T) {
+	got := Sign("secret", "1700000000", []byte(`{"event":"new_leader"}`))
+	want := "sha256=c52f20117f5031210b99e5a9a2a11ed3e88a3cef4070e06a99344fd11d13a7a8"
+	if got != want {
+		t.Errorf("Sign() = %q, want %q", got, want)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int32
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{4, 4 * time.Minute},
+		{8, time.Hour},
+		{30, time.Hour},
+	}
+	for _, tt := range tests {
+		if got := backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestSend(t *testing.T) {
+	delivery := ClaimDeliveriesRow{
+		ID:      uuid.New(),
+		Event:   string(EventScoreSubmitted),
+		Payload: []byte(`{"event":"score_submitted"}`),
+		Secret:  "secret",
+	}
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp := r.Header.Get(HeaderTimestamp)
+		if r.Header.Get(HeaderSignature) != Sign("secret", timestamp, body) {
+			t.Errorf("signature %q does not match the body", r.Header.Get(HeaderSignature))
+		}
+		if r.Header.Get(HeaderEvent) != delivery.Event || r.Header.Get(HeaderDelivery) != delivery.ID.String() {
+			t.Errorf("headers = %v, want the event and delivery ID", r.Header)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("try later\n"))
+	}))
+	defer server.Close()
+	delivery.Url = server.URL
+
+	if got, err := send(context.Background(), server.Client(), delivery); err != nil || got != http.StatusNoContent {
+		t.Errorf("send() = %d, %v, want 204", got, err)
+	}
+	status = http.StatusServiceUnavailable
+	got, err := send(context.Background(), server.Client(), delivery)
+	if err == nil || got != http.StatusServiceUnavailable || !strings.Contains(err.Error(), "try later") {
+		t.Errorf("send() = %d, %v, want a 503 error with the response body", got, err)
+	}
+}
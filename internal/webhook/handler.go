@@ -0,0 +1,266 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"scoreboard-api/internal"
+	"scoreboard-api/internal/scoreboard"
+)
+
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 200
+)
+
+type Store interface {
+	List(ctx context.Context, scoreboardID uuid.UUID) ([]Webhook, error)
+	Create(ctx context.Context, scoreboardID uuid.UUID, url string, events []Event) (Webhook, error)
+	Delete(ctx context.Context, scoreboardID, webhookID uuid.UUID) error
+	Deliveries(ctx context.Context, scoreboardID, webhookID uuid.UUID, limit int32) ([]WebhookDelivery, error)
+	Redeliver(ctx context.Context, scoreboardID, webhookID, deliveryID uuid.UUID) (WebhookDelivery, error)
+}
+
+// WebhookPayload defines the expected request body for registering a webhook.
+type WebhookPayload struct {
+	URL    string   `json:"url" validate:"required,http_url,max=2048"`
+	Events []string `json:"events" validate:"required,min=1,unique,dive,oneof=score_submitted new_leader board_frozen"`
+}
+
+type Response struct {
+	ID     string   `json:"id"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	// Secret is only returned when the webhook is created.
+	Secret    string `json:"secret,omitempty"`
+	CreatedAt string `json:"createdAt"`
+}
+
+type DeliveryResponse struct {
+	ID             string          `json:"id"`
+	Event          string          `json:"event"`
+	Status         string          `json:"status"`
+	Attempts       int32           `json:"attempts"`
+	ResponseStatus *int32          `json:"responseStatus"`
+	LastError      *string         `json:"lastError"`
+	NextAttemptAt  *string         `json:"nextAttemptAt"`
+	DeliveredAt    *string         `json:"deliveredAt"`
+	CreatedAt      string          `json:"createdAt"`
+	Payload        json.RawMessage `json:"payload"`
+}
+
+type Handler struct {
+	validator  *validator.Validate
+	tracer     trace.Tracer
+	logger     *zap.Logger
+	store      Store
+	adminToken string
+}
+
+// NewHandler serves webhook management. Webhook URLs and secrets belong to
+// the board's owner, so every route needs the admin token.
+func NewHandler(v *validator.Validate, logger *zap.Logger, s Store, adminToken string) Handler {
+	return Handler{
+		validator:  v,
+		tracer:     otel.Tracer("webhook/handler"),
+		logger:     logger,
+		store:      s,
+		adminToken: adminToken,
+	}
+}
+
+func (h Handler) ListHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	if !internal.IsPrivileged(r, h.adminToken) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+	webhooks, err := h.store.List(ctx, scoreboardID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	response := make([]Response, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		response = append(response, GenerateResponse(webhook))
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+func (h Handler) CreateHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	if !internal.IsPrivileged(r, h.adminToken) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	var payload WebhookPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.validator.Struct(payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	events := make([]Event, len(payload.Events))
+	for i, event := range payload.Events {
+		events[i] = Event(event)
+	}
+	webhook, err := h.store.Create(ctx, scoreboardID, payload.URL, events)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	response := GenerateResponse(webhook)
+	response.Secret = webhook.Secret
+	scoreboard.WriteJSONResponse(w, http.StatusCreated, response)
+}
+
+func (h Handler) DeleteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, webhookID, ok := h.parseIDs(w, r)
+	if !ok {
+		return
+	}
+	if err := h.store.Delete(ctx, scoreboardID, webhookID); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeliveriesHandler lists the latest deliveries of a webhook, newest first.
+// ?limit= caps how many are returned.
+func (h Handler) DeliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, webhookID, ok := h.parseIDs(w, r)
+	if !ok {
+		return
+	}
+	limit := defaultDeliveryLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxDeliveryLimit {
+			http.Error(w, "limit must be between 1 and 200", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+	deliveries, err := h.store.Deliveries(ctx, scoreboardID, webhookID, int32(limit))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	response := make([]DeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		response = append(response, GenerateDeliveryResponse(delivery))
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusOK, response)
+}
+
+// RedeliverHandler queues the payload of an earlier delivery again.
+func (h Handler) RedeliverHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, webhookID, ok := h.parseIDs(w, r)
+	if !ok {
+		return
+	}
+	deliveryID, err := uuid.Parse(r.PathValue("deliveryID"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	delivery, err := h.store.Redeliver(ctx, scoreboardID, webhookID, deliveryID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	scoreboard.WriteJSONResponse(w, http.StatusAccepted, GenerateDeliveryResponse(delivery))
+}
+
+// parseIDs reads the scoreboard and webhook IDs of the path and checks the
+// admin token, writing the error response when either fails.
+func (h Handler) parseIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+	webhookID, err := uuid.Parse(r.PathValue("webhookID"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+	if !internal.IsPrivileged(r, h.adminToken) {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return scoreboardID, webhookID, true
+}
+
+func (h Handler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrScoreboardNotFound), errors.Is(err, ErrWebhookNotFound), errors.Is(err, ErrDeliveryNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		h.logger.Error("Webhook request failed", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func GenerateResponse(webhook Webhook) Response {
+	return Response{
+		ID:        webhook.ID.String(),
+		URL:       webhook.Url,
+		Events:    webhook.Events,
+		CreatedAt: webhook.CreatedAt.Time.Format(time.RFC3339),
+	}
+}
+
+func GenerateDeliveryResponse(delivery WebhookDelivery) DeliveryResponse {
+	response := DeliveryResponse{
+		ID:        delivery.ID.String(),
+		Event:     delivery.Event,
+		Status:    delivery.Status,
+		Attempts:  delivery.Attempts,
+		CreatedAt: delivery.CreatedAt.Time.Format(time.RFC3339),
+		Payload:   delivery.Payload,
+	}
+	if delivery.ResponseStatus.Valid {
+		response.ResponseStatus = &delivery.ResponseStatus.Int32
+	}
+	if delivery.LastError.Valid {
+		response.LastError = &delivery.LastError.String
+	}
+	if delivery.Status == StatusPending {
+		nextAttemptAt := delivery.NextAttemptAt.Time.Format(time.RFC3339)
+		response.NextAttemptAt = &nextAttemptAt
+	}
+	if delivery.DeliveredAt.Valid {
+		deliveredAt := delivery.DeliveredAt.Time.Format(time.RFC3339)
+		response.DeliveredAt = &deliveredAt
+	}
+	return response
+}
@@ -0,0 +1,83 @@
+-- name: ListWebhooks :many
+SELECT * FROM webhooks
+WHERE scoreboard_id = $1
+ORDER BY created_at;
+
+-- name: GetWebhook :one
+SELECT * FROM webhooks
+WHERE id = $1 AND scoreboard_id = $2;
+
+-- name: CreateWebhook :one
+INSERT INTO webhooks (
+    id, scoreboard_id, url, secret, events, created_at
+) VALUES (
+    gen_random_uuid(),
+    $1, $2, $3, $4,
+    CURRENT_TIMESTAMP
+)
+RETURNING *;
+
+-- name: DeleteWebhook :exec
+DELETE FROM webhooks WHERE id = $1 AND scoreboard_id = $2;
+
+-- name: ListSubscribedWebhooks :many
+SELECT * FROM webhooks
+WHERE scoreboard_id = $1 AND sqlc.arg(event)::TEXT = ANY(events);
+
+-- name: EnqueueDelivery :one
+INSERT INTO webhook_deliveries (
+    id, webhook_id, event, dedupe_key, payload, next_attempt_at, created_at
+) VALUES (
+    gen_random_uuid(),
+    $1, $2, $3, $4, $5, $5
+)
+ON CONFLICT (webhook_id, dedupe_key) DO NOTHING
+RETURNING *;
+
+-- name: ClaimDeliveries :many
+UPDATE webhook_deliveries d
+SET next_attempt_at = sqlc.arg(lease_until)
+FROM webhooks w
+WHERE w.id = d.webhook_id AND d.id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE status = 'pending' AND next_attempt_at <= sqlc.arg(now)
+    ORDER BY next_attempt_at
+    LIMIT sqlc.arg(batch)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING d.id, d.webhook_id, d.event, d.payload, d.attempts, w.url, w.secret;
+
+-- name: RecordAttempt :one
+UPDATE webhook_deliveries
+SET status = $2,
+    attempts = attempts + 1,
+    next_attempt_at = $3,
+    response_status = $4,
+    last_error = $5,
+    delivered_at = $6
+WHERE id = $1
+RETURNING *;
+
+-- name: ListDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE webhook_id = $1
+ORDER BY created_at DESC
+LIMIT $2;
+
+-- name: GetDelivery :one
+SELECT * FROM webhook_deliveries
+WHERE id = $1 AND webhook_id = $2;
+
+-- name: ListFrozenBoards :many
+SELECT w.id AS webhook_id, c.scoreboard_id, c.freeze_at,
+    ('board_frozen:' || c.freeze_at::TEXT)::TEXT AS dedupe_key
+FROM contests c
+JOIN webhooks w ON w.scoreboard_id = c.scoreboard_id
+WHERE c.freeze_at <= sqlc.arg(now)
+    AND c.freeze_at >= w.created_at
+    AND c.unfrozen_at IS NULL
+    AND 'board_frozen' = ANY(w.events)
+    AND NOT EXISTS (
+        SELECT 1 FROM webhook_deliveries d
+        WHERE d.webhook_id = w.id AND d.dedupe_key = 'board_frozen:' || c.freeze_at::TEXT
+    );
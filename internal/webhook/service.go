@@ -0,0 +1,305 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"scoreboard-api/internal/scoreboard"
+)
+
+var (
+	ErrScoreboardNotFound = errors.New("scoreboard not found")
+	ErrWebhookNotFound    = errors.New("webhook not found")
+	ErrDeliveryNotFound   = errors.New("delivery not found")
+)
+
+// Postgres error codes raised by the webhook constraints.
+const foreignKeyViolation = "23503"
+
+// Event is a kind of change a webhook can subscribe to.
+type Event string
+
+const (
+	// EventScoreSubmitted fires whenever a player's entry is written.
+	EventScoreSubmitted Event = "score_submitted"
+	// EventNewLeader fires when a player takes sole first place.
+	EventNewLeader Event = "new_leader"
+	// EventBoardFrozen fires when the contest on a board reaches its
+	// freeze time.
+	EventBoardFrozen Event = "board_frozen"
+)
+
+// Board gives access to the ranked entries of a scoreboard.
+type Board interface {
+	Top(ctx context.Context, scoreboardID uuid.UUID, limit int) ([]scoreboard.Standing, error)
+	Settings(ctx context.Context, scoreboardID uuid.UUID) (scoreboard.ScoreboardSetting, error)
+}
+
+// Payload is the JSON body posted to a webhook.
+type Payload struct {
+	Event        Event  `json:"event"`
+	ScoreboardID string `json:"scoreboardId"`
+	UserID       string `json:"userId,omitempty"`
+	Score        *int64 `json:"score,omitempty"`
+	// PreviousScore is unset for a player's first entry.
+	PreviousScore *int64 `json:"previousScore,omitempty"`
+	FreezeAt      string `json:"freezeAt,omitempty"`
+	OccurredAt    string `json:"occurredAt"`
+}
+
+// Service keeps the webhooks of each scoreboard and queues a delivery to
+// every hook subscribed to a change. It observes the scoreboard service.
+type Service struct {
+	queries *Queries
+	board   Board
+	client  *http.Client
+	logger  *zap.Logger
+	tracer  trace.Tracer
+	// wake tells the dispatcher a delivery is due now.
+	wake chan struct{}
+}
+
+var _ scoreboard.Observer = (*Service)(nil)
+
+func NewService(logger *zap.Logger, db DBTX, board Board) *Service {
+	return &Service{
+		queries: New(db),
+		board:   board,
+		client:  &http.Client{Timeout: deliveryTimeout},
+		logger:  logger,
+		tracer:  otel.Tracer("webhook/service"),
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+func (s *Service) List(ctx context.Context, scoreboardID uuid.UUID) ([]Webhook, error) {
+	traceCtx, span := s.tracer.Start(ctx, "List")
+	defer span.End()
+	webhooks, err := s.queries.ListWebhooks(traceCtx, scoreboardID)
+	if err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// Create registers a webhook with a new signing secret.
+func (s *Service) Create(ctx context.Context, scoreboardID uuid.UUID, url string, events []Event) (Webhook, error) {
+	traceCtx, span := s.tracer.Start(ctx, "Create")
+	defer span.End()
+	secret, err := newSecret()
+	if err != nil {
+		return Webhook{}, err
+	}
+	names := make([]string, len(events))
+	for i, event := range events {
+		names[i] = string(event)
+	}
+	webhook, err := s.queries.CreateWebhook(traceCtx, CreateWebhookParams{
+		ScoreboardID: scoreboardID,
+		Url:          url,
+		Secret:       secret,
+		Events:       names,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return Webhook{}, ErrScoreboardNotFound
+		}
+		return Webhook{}, err
+	}
+	return webhook, nil
+}
+
+func (s *Service) Delete(ctx context.Context, scoreboardID, webhookID uuid.UUID) error {
+	traceCtx, span := s.tracer.Start(ctx, "Delete")
+	defer span.End()
+	return s.queries.DeleteWebhook(traceCtx, DeleteWebhookParams{ID: webhookID, ScoreboardID: scoreboardID})
+}
+
+// Deliveries lists the latest deliveries of a webhook, newest first.
+func (s *Service) Deliveries(ctx context.Context, scoreboardID, webhookID uuid.UUID, limit int32) ([]WebhookDelivery, error) {
+	traceCtx, span := s.tracer.Start(ctx, "Deliveries")
+	defer span.End()
+	if _, err := s.get(traceCtx, scoreboardID, webhookID); err != nil {
+		return nil, err
+	}
+	deliveries, err := s.queries.ListDeliveries(traceCtx, ListDeliveriesParams{WebhookID: webhookID, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// Redeliver queues a new delivery with the payload of an earlier one, which
+// is left as it was.
+func (s *Service) Redeliver(ctx context.Context, scoreboardID, webhookID, deliveryID uuid.UUID) (WebhookDelivery, error) {
+	traceCtx, span := s.tracer.Start(ctx, "Redeliver")
+	defer span.End()
+	if _, err := s.get(traceCtx, scoreboardID, webhookID); err != nil {
+		return WebhookDelivery{}, err
+	}
+	delivery, err := s.queries.GetDelivery(traceCtx, GetDeliveryParams{ID: deliveryID, WebhookID: webhookID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return WebhookDelivery{}, ErrDeliveryNotFound
+		}
+		return WebhookDelivery{}, err
+	}
+	redelivery, err := s.queries.EnqueueDelivery(traceCtx, EnqueueDeliveryParams{
+		WebhookID:     webhookID,
+		Event:         delivery.Event,
+		Payload:       delivery.Payload,
+		NextAttemptAt: timestamp(time.Now()),
+	})
+	if err != nil {
+		return WebhookDelivery{}, err
+	}
+	s.poke()
+	return redelivery, nil
+}
+
+func (s *Service) get(ctx context.Context, scoreboardID, webhookID uuid.UUID) (Webhook, error) {
+	webhook, err := s.queries.GetWebhook(ctx, GetWebhookParams{ID: webhookID, ScoreboardID: scoreboardID})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Webhook{}, ErrWebhookNotFound
+		}
+		return Webhook{}, err
+	}
+	return webhook, nil
+}
+
+// ObserveEntry queues score_submitted for every written entry, and
+// new_leader when the write put the player alone in first place. Deliveries
+// are keyed by the event, so a change observed twice is queued once.
+func (s *Service) ObserveEntry(ctx context.Context, change scoreboard.EntryChange) error {
+	// Another instance already queued the deliveries of its own changes.
+	if change.Remote || change.After == nil {
+		return nil
+	}
+	traceCtx, span := s.tracer.Start(ctx, "ObserveEntry")
+	defer span.End()
+
+	now := time.Now().UTC()
+	payload := Payload{
+		Event:        EventScoreSubmitted,
+		ScoreboardID: change.ScoreboardID.String(),
+		UserID:       change.UserID.String(),
+		Score:        &change.After.Score,
+		OccurredAt:   now.Format(time.RFC3339),
+	}
+	if change.After.UpdatedAt.Valid {
+		payload.OccurredAt = change.After.UpdatedAt.Time.Format(time.RFC3339)
+	}
+	if change.Before != nil {
+		payload.PreviousScore = &change.Before.Score
+	}
+	if err := s.enqueue(traceCtx, change.ScoreboardID, payload, eventKey(EventScoreSubmitted, change.EventID)); err != nil {
+		return err
+	}
+
+	leads, err := s.tookLead(traceCtx, change, now)
+	if err != nil || !leads {
+		return err
+	}
+	payload.Event = EventNewLeader
+	return s.enqueue(traceCtx, change.ScoreboardID, payload, eventKey(EventNewLeader, change.EventID))
+}
+
+// eventKey dedupes the deliveries of an event log entry. Changes without an
+// event ID are not deduped.
+func eventKey(event Event, eventID int64) string {
+	if eventID == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", event, eventID)
+}
+
+// tookLead reports whether the change put the player alone in first place
+// when they were not before. The runner-up is untouched by the change, so
+// the player led before if their old score beat the runner-up's.
+func (s *Service) tookLead(ctx context.Context, change scoreboard.EntryChange, now time.Time) (bool, error) {
+	rows, err := s.board.Top(ctx, change.ScoreboardID, 2)
+	if err != nil {
+		return false, err
+	}
+	if len(rows) == 0 || rows[0].UserID != change.UserID || (len(rows) > 1 && rows[1].Rank == 1) {
+		return false, nil
+	}
+	if change.Before == nil {
+		return true, nil
+	}
+	if len(rows) == 1 {
+		return false, nil
+	}
+	settings, err := s.board.Settings(ctx, change.ScoreboardID)
+	if err != nil {
+		return false, err
+	}
+	return settings.Effective(*change.Before, now) <= rows[1].Effective, nil
+}
+
+// enqueue queues a delivery of the payload to every webhook of the board
+// subscribed to its event. A non-empty key queues it at most once per hook.
+func (s *Service) enqueue(ctx context.Context, scoreboardID uuid.UUID, payload Payload, key string) error {
+	webhooks, err := s.queries.ListSubscribedWebhooks(ctx, ListSubscribedWebhooksParams{
+		ScoreboardID: scoreboardID,
+		Event:        string(payload.Event),
+	})
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	for _, webhook := range webhooks {
+		_, err := s.queries.EnqueueDelivery(ctx, EnqueueDeliveryParams{
+			WebhookID:     webhook.ID,
+			Event:         string(payload.Event),
+			DedupeKey:     pgtype.Text{String: key, Valid: key != ""},
+			Payload:       body,
+			NextAttemptAt: timestamp(time.Now()),
+		})
+		// A keyed delivery that was already queued returns no row.
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+	}
+	s.poke()
+	return nil
+}
+
+// poke wakes the dispatcher without waiting for it.
+func (s *Service) poke() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func newSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+func timestamp(t time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{Time: t.UTC(), Valid: true}
+}
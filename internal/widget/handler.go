@@ -0,0 +1,266 @@
+// Package widget serves leaderboards as small server-rendered pages that
+// other sites embed in an iframe, plus a script that builds the iframe.
+package widget
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"scoreboard-api/internal/scoreboard"
+	"scoreboard-api/internal/user"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultLimit = 10
+	maxLimit     = 100
+	// Auto-refresh is off by default, and otherwise between minRefresh and
+	// maxRefresh seconds.
+	minRefresh = 5
+	maxRefresh = 3600
+	// maxAge is how long browsers and proxies may cache a page without
+	// auto-refresh.
+	maxAge = 30
+)
+
+// color accepts #rgb and #rrggbb colors, without the #.
+var color = regexp.MustCompile(`^[0-9a-fA-F]{3}([0-9a-fA-F]{3})?$`)
+
+// Store is the part of the scoreboard service the widget uses.
+type Store interface {
+	Get(ctx context.Context, id uuid.UUID) (scoreboard.Scoreboard, error)
+	Top(ctx context.Context, scoreboardID uuid.UUID, limit int) ([]scoreboard.Standing, error)
+}
+
+// Users is the part of the user service the widget uses to name players.
+type Users interface {
+	ListByIDs(ctx context.Context, ids []uuid.UUID) ([]user.User, error)
+}
+
+// Theme holds the colors of a page.
+type Theme struct {
+	Background string
+	Color      string
+	Accent     string
+	Muted      string
+}
+
+var themes = map[string]Theme{
+	"light": {Background: "#ffffff", Color: "#1f2328", Accent: "#0969da", Muted: "#eaeef2"},
+	"dark":  {Background: "#0d1117", Color: "#e6edf3", Accent: "#2f81f7", Muted: "#21262d"},
+}
+
+type row struct {
+	Rank    int64
+	Player  string
+	Picture string
+	Score   int64
+}
+
+type view struct {
+	Name      string
+	ShowTitle bool
+	Theme     Theme
+	Refresh   int
+	Rows      []row
+	UpdatedAt string
+}
+
+var page = template.Must(template.New("leaderboard").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="UTF-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  {{- if .Refresh}}
+  <meta http-equiv="refresh" content="{{.Refresh}}">
+  {{- end}}
+  <title>{{.Name}}</title>
+  <style>
+    body { margin: 0; padding: 0.75em; font-family: system-ui, sans-serif; background: {{.Theme.Background}}; color: {{.Theme.Color}}; }
+    h1 { margin: 0 0 0.5em; font-size: 1.1em; color: {{.Theme.Accent}}; }
+    table { width: 100%; border-collapse: collapse; }
+    td { padding: 0.35em 0.5em; border-bottom: 1px solid {{.Theme.Muted}}; }
+    td.rank { width: 2.5em; font-weight: bold; color: {{.Theme.Accent}}; }
+    td.score { text-align: right; font-variant-numeric: tabular-nums; }
+    img { width: 1.5em; height: 1.5em; border-radius: 50%; vertical-align: middle; margin-right: 0.5em; }
+    p { margin: 0.5em 0 0; font-size: 0.75em; opacity: 0.7; }
+  </style>
+</head>
+<body>
+  {{- if .ShowTitle}}
+  <h1>{{.Name}}</h1>
+  {{- end}}
+  <table>
+    {{- range .Rows}}
+    <tr>
+      <td class="rank">#{{.Rank}}</td>
+      <td>{{if .Picture}}<img src="{{.Picture}}" alt="">{{end}}{{.Player}}</td>
+      <td class="score">{{.Score}}</td>
+    </tr>
+    {{- else}}
+    <tr><td>No scores yet.</td></tr>
+    {{- end}}
+  </table>
+  <p>Updated {{.UpdatedAt}}</p>
+</body>
+</html>
+`))
+
+type Handler struct {
+	logger *zap.Logger
+	store  Store
+	users  Users
+}
+
+func NewHandler(logger *zap.Logger, store Store, users Users) Handler {
+	return Handler{
+		logger: logger,
+		store:  store,
+		users:  users,
+	}
+}
+
+// LeaderboardHandler renders the top of a scoreboard for an iframe. The
+// query string chooses what is shown:
+//
+//	limit       number of players, 10 by default and at most 100
+//	theme       light or dark
+//	background  page color as hex, without the #
+//	color       text color
+//	accent      title and rank color
+//	refresh     seconds between reloads, off by default
+//	title       0 hides the scoreboard name
+func (h Handler) LeaderboardHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	scoreboardID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid UUID format", http.StatusBadRequest)
+		return
+	}
+	query := r.URL.Query()
+	limit := defaultLimit
+	if value := query.Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxLimit {
+			http.Error(w, "limit must be between 1 and 100", http.StatusBadRequest)
+			return
+		}
+	}
+	refresh := 0
+	if value := query.Get("refresh"); value != "" {
+		refresh, err = strconv.Atoi(value)
+		if err != nil || refresh < minRefresh || refresh > maxRefresh {
+			http.Error(w, "refresh must be between 5 and 3600 seconds", http.StatusBadRequest)
+			return
+		}
+	}
+	themeName := query.Get("theme")
+	if themeName == "" {
+		themeName = "light"
+	}
+	theme, ok := themes[themeName]
+	if !ok {
+		http.Error(w, "theme must be light or dark", http.StatusBadRequest)
+		return
+	}
+	for _, option := range []struct {
+		name   string
+		target *string
+	}{
+		{"background", &theme.Background},
+		{"color", &theme.Color},
+		{"accent", &theme.Accent},
+	} {
+		value := query.Get(option.name)
+		if value == "" {
+			continue
+		}
+		if !color.MatchString(value) {
+			http.Error(w, option.name+" must be a hex color such as 1f2328", http.StatusBadRequest)
+			return
+		}
+		*option.target = "#" + value
+	}
+
+	board, err := h.store.Get(ctx, scoreboardID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	standings, err := h.store.Top(ctx, scoreboardID, limit)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	rows, err := h.rows(ctx, standings)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	// Any site may frame the page.
+	w.Header().Set("Content-Security-Policy", "frame-ancestors *")
+	if refresh > 0 {
+		w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(min(refresh, maxAge)))
+	} else {
+		w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(maxAge))
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err = page.Execute(w, view{
+		Name:      board.Name.String,
+		ShowTitle: query.Get("title") != "0",
+		Theme:     theme,
+		Refresh:   refresh,
+		Rows:      rows,
+		UpdatedAt: time.Now().UTC().Format("15:04 UTC"),
+	})
+	if err != nil {
+		h.logger.Error("Failed to render leaderboard widget", zap.Error(err))
+	}
+}
+
+// rows names the players of the standings by their profiles. Players without
+// one are shown by the start of their ID.
+func (h Handler) rows(ctx context.Context, standings []scoreboard.Standing) ([]row, error) {
+	ids := make([]uuid.UUID, len(standings))
+	for index, standing := range standings {
+		ids[index] = standing.UserID
+	}
+	profiles, err := h.users.ListByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]user.User, len(profiles))
+	for _, profile := range profiles {
+		byID[profile.ID] = profile
+	}
+
+	rows := make([]row, len(standings))
+	for index, standing := range standings {
+		rows[index] = row{Rank: standing.Rank, Player: standing.UserID.String()[:8], Score: standing.Score}
+		if profile, ok := byID[standing.UserID]; ok {
+			if profile.Name.Valid && profile.Name.String != "" {
+				rows[index].Player = profile.Name.String
+			}
+			rows[index].Picture = profile.Picture.String
+		}
+	}
+	return rows, nil
+}
+
+func (h Handler) writeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, pgx.ErrNoRows) {
+		http.Error(w, "Scoreboard not found", http.StatusNotFound)
+		return
+	}
+	h.logger.Error("Failed to read leaderboard widget", zap.Error(err))
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
@@ -0,0 +1,95 @@
+package widget
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"scoreboard-api/internal/memory"
+	"scoreboard-api/internal/scoreboard"
+	"scoreboard-api/internal/user"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+func TestLeaderboardHandler(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	store := db.Scoreboards()
+	service := scoreboard.NewServiceWithQuerier(zap.NewNop(), store, store)
+	users := user.NewServiceWithQuerier(zap.NewNop(), db.Users())
+
+	board, err := service.Create(ctx, pgtype.Text{String: "Speedrun <Any%>", Valid: true})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	ada, err := users.CreateWithProfile(ctx, "ada@example.com", "Ada", "Ada", "", "https://example.com/ada.png", "en", true)
+	if err != nil {
+		t.Fatalf("CreateWithProfile() error = %v", err)
+	}
+	anonymous := uuid.New()
+	for _, entry := range []scoreboard.SubmitScoreParams{
+		{ScoreboardID: board.ID, UserID: ada.ID, Score: 120},
+		{ScoreboardID: board.ID, UserID: anonymous, Score: 80},
+		{ScoreboardID: board.ID, UserID: uuid.New(), Score: 40},
+	} {
+		if _, err := service.Submit(ctx, entry); err != nil {
+			t.Fatalf("Submit() error = %v", err)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /embed/scoreboards/{id}", NewHandler(zap.NewNop(), service, users).LeaderboardHandler)
+	get := func(query string) (*http.Response, string) {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/embed/scoreboards/"+board.ID.String()+query, nil))
+		body, _ := io.ReadAll(recorder.Body)
+		return recorder.Result(), string(body)
+	}
+
+	response, body := get("?limit=2&theme=dark&accent=ff8800&refresh=10")
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", response.StatusCode, body)
+	}
+	for _, want := range []string{
+		"Speedrun &lt;Any%&gt;",
+		`<img src="https://example.com/ada.png" alt="">Ada`,
+		anonymous.String()[:8],
+		"color: #ff8800",
+		"background: #0d1117",
+		`<meta http-equiv="refresh" content="10">`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("page does not contain %q:\n%s", want, body)
+		}
+	}
+	if strings.Count(body, `class="rank"`) != 2 {
+		t.Errorf("page shows %d players, want 2", strings.Count(body, `class="rank"`))
+	}
+	if got := response.Header.Get("Content-Security-Policy"); got != "frame-ancestors *" {
+		t.Errorf("Content-Security-Policy = %q, want any site to frame the page", got)
+	}
+	if got := response.Header.Get("Cache-Control"); got != "public, max-age=10" {
+		t.Errorf("Cache-Control = %q, want the refresh interval", got)
+	}
+
+	if _, body := get("?title=0"); strings.Contains(body, "<h1>") || strings.Contains(body, "http-equiv") {
+		t.Errorf("page with title=0 and no refresh = %s", body)
+	}
+	for _, query := range []string{"?accent=red", "?background=fff%3B%7D", "?theme=neon", "?limit=0", "?refresh=1"} {
+		if response, _ := get(query); response.StatusCode != http.StatusBadRequest {
+			t.Errorf("GET %s status = %d, want 400", query, response.StatusCode)
+		}
+	}
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/embed/scoreboards/"+uuid.NewString(), nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("missing board status = %d, want 404", recorder.Code)
+	}
+}
@@ -0,0 +1,41 @@
+package widget
+
+import "net/http"
+
+// script replaces every element with a data-scoreboard attribute by an
+// iframe of its leaderboard. The other data- attributes of the element are
+// passed on as options, and data-height sets the height of the iframe:
+//
+//	<div data-scoreboard="ID" data-limit="5" data-theme="dark"></div>
+//	<script src="https://scores.example.com/embed/widget.js" async></script>
+const script = `(function () {
+  var origin = new URL(document.currentScript.src).origin;
+  var options = ['limit', 'theme', 'background', 'color', 'accent', 'refresh', 'title'];
+  document.querySelectorAll('[data-scoreboard]').forEach(function (element) {
+    if (element.querySelector('iframe')) {
+      return;
+    }
+    var params = new URLSearchParams();
+    options.forEach(function (name) {
+      if (element.dataset[name]) {
+        params.set(name, element.dataset[name]);
+      }
+    });
+    var frame = document.createElement('iframe');
+    frame.src = origin + '/embed/scoreboards/' + encodeURIComponent(element.dataset.scoreboard) + '?' + params;
+    frame.title = 'Leaderboard';
+    frame.loading = 'lazy';
+    frame.style.border = '0';
+    frame.style.width = '100%';
+    frame.height = element.dataset.height || '400';
+    element.appendChild(frame);
+  });
+})();
+`
+
+// ScriptHandler serves the script that embeds leaderboards.
+func (h Handler) ScriptHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	_, _ = w.Write([]byte(script))
+}